@@ -14,6 +14,14 @@ const (
 	InstanceStateTerminated = "terminated"
 )
 
+// Security group rule directions
+const (
+	// RuleDirectionIngress represents an inbound security group rule
+	RuleDirectionIngress = "ingress"
+	// RuleDirectionEgress represents an outbound security group rule
+	RuleDirectionEgress = "egress"
+)
+
 // AWS API error patterns
 const (
 	// ErrorPatternInvalidInstanceID is the error pattern for invalid instance IDs
@@ -74,6 +74,24 @@ type SecurityGroup struct {
 	GroupName string `json:"group_name"`
 }
 
+// SecurityGroupRule represents a single ingress or egress rule of a security group
+type SecurityGroupRule struct {
+	// Direction is either RuleDirectionIngress or RuleDirectionEgress
+	Direction string `json:"direction"`
+
+	// Protocol is the IP protocol (tcp, udp, icmp or -1 for all)
+	Protocol string `json:"protocol"`
+
+	// FromPort is the start of the port range
+	FromPort int `json:"from_port"`
+
+	// ToPort is the end of the port range
+	ToPort int `json:"to_port"`
+
+	// CIDRBlocks lists the IPv4 ranges the rule applies to
+	CIDRBlocks []string `json:"cidr_blocks"`
+}
+
 // ToJSON converts the EC2Instance to JSON string
 func (e *EC2Instance) ToJSON() (string, error) {
 	data, err := json.MarshalIndent(e, "", "  ")
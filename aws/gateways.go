@@ -0,0 +1,39 @@
+package aws
+
+// NAT gateway connectivity types
+const (
+	// NATConnectivityPublic represents a NAT gateway with internet access
+	NATConnectivityPublic = "public"
+	// NATConnectivityPrivate represents a NAT gateway for private routing only
+	NATConnectivityPrivate = "private"
+)
+
+// NATGateway represents an AWS NAT gateway configuration
+type NATGateway struct {
+	// NATGatewayID is the unique identifier for the NAT gateway
+	NATGatewayID string `json:"nat_gateway_id"`
+
+	// SubnetID is the ID of the subnet the NAT gateway is placed in
+	SubnetID string `json:"subnet_id"`
+
+	// ConnectivityType is either public or private
+	ConnectivityType string `json:"connectivity_type"`
+
+	// AllocationID is the Elastic IP allocation associated with a public NAT gateway
+	AllocationID *string `json:"allocation_id,omitempty"`
+
+	// Tags is a map of tags associated with the NAT gateway
+	Tags map[string]string `json:"tags"`
+}
+
+// InternetGateway represents an AWS internet gateway configuration
+type InternetGateway struct {
+	// InternetGatewayID is the unique identifier for the internet gateway
+	InternetGatewayID string `json:"internet_gateway_id"`
+
+	// VPCAttachment is the ID of the attached VPC, empty when detached
+	VPCAttachment string `json:"vpc_attachment"`
+
+	// Tags is a map of tags associated with the internet gateway
+	Tags map[string]string `json:"tags"`
+}
@@ -0,0 +1,75 @@
+package aws
+
+// S3 lifecycle rule statuses
+const (
+	// LifecycleRuleEnabled marks a lifecycle rule that is applied
+	LifecycleRuleEnabled = "Enabled"
+	// LifecycleRuleDisabled marks a lifecycle rule that is kept but not applied
+	LifecycleRuleDisabled = "Disabled"
+)
+
+// S3Bucket represents an AWS S3 bucket configuration
+type S3Bucket struct {
+	// BucketName is the globally unique name of the bucket
+	BucketName string `json:"bucket"`
+
+	// Versioning indicates if object versioning is enabled
+	Versioning bool `json:"versioning"`
+
+	// Policy is the bucket policy JSON document, empty when no policy is attached
+	Policy string `json:"policy,omitempty"`
+
+	// LifecycleRules are the lifecycle rules configured on the bucket
+	LifecycleRules []S3LifecycleRule `json:"lifecycle_rules,omitempty"`
+
+	// Tags is a map of tags associated with the bucket
+	Tags map[string]string `json:"tags"`
+
+	// PublicAccessBlock is the bucket's public access block, nil when none is set
+	PublicAccessBlock *S3PublicAccessBlock `json:"public_access_block,omitempty"`
+
+	// Encryption is the default server-side encryption, nil when none is set
+	Encryption *S3Encryption `json:"encryption,omitempty"`
+
+	// LoggingTarget is the bucket access logs are delivered to, empty when
+	// access logging is disabled
+	LoggingTarget string `json:"logging_target,omitempty"`
+}
+
+// S3PublicAccessBlock represents an S3 bucket public access block
+type S3PublicAccessBlock struct {
+	BlockPublicACLs       bool `json:"block_public_acls"`
+	BlockPublicPolicy     bool `json:"block_public_policy"`
+	IgnorePublicACLs      bool `json:"ignore_public_acls"`
+	RestrictPublicBuckets bool `json:"restrict_public_buckets"`
+}
+
+// S3Encryption represents an S3 bucket default encryption configuration
+type S3Encryption struct {
+	// SSEAlgorithm is AES256 or aws:kms
+	SSEAlgorithm string `json:"sse_algorithm"`
+
+	// KMSMasterKeyID is the KMS key used with aws:kms, empty for the AWS managed key
+	KMSMasterKeyID string `json:"kms_master_key_id,omitempty"`
+}
+
+// S3LifecycleRule represents a single S3 bucket lifecycle rule
+type S3LifecycleRule struct {
+	// ID uniquely identifies the rule within the bucket
+	ID string `json:"id"`
+
+	// Prefix limits the rule to objects whose keys start with it
+	Prefix string `json:"prefix,omitempty"`
+
+	// Status is either Enabled or Disabled
+	Status string `json:"status"`
+
+	// ExpirationDays deletes current object versions this many days after creation
+	ExpirationDays *int `json:"expiration_days,omitempty"`
+
+	// TransitionDays moves objects to TransitionStorageClass this many days after creation
+	TransitionDays *int `json:"transition_days,omitempty"`
+
+	// TransitionStorageClass is the storage class objects are moved to
+	TransitionStorageClass string `json:"transition_storage_class,omitempty"`
+}
@@ -0,0 +1,199 @@
+// Package cloudformation reads CloudFormation templates as the expected state
+// for drift detection, producing the same configuration shape the Terraform
+// parsers do.
+package cloudformation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"firefly-task/terraform"
+)
+
+// ec2InstanceType is the CloudFormation resource type mapped to aws_instance
+const ec2InstanceType = "AWS::EC2::Instance"
+
+// template is the subset of a CloudFormation template read by ParseTemplate
+type template struct {
+	Resources map[string]resource `json:"Resources"`
+}
+
+type resource struct {
+	Type       string                 `json:"Type"`
+	Properties map[string]interface{} `json:"Properties"`
+}
+
+// ParseTemplate reads a JSON CloudFormation template and returns its
+// AWS::EC2::Instance resources keyed by logical ID. Each config gets the
+// resource ID "aws_instance.<LogicalID>" so it passes the detector's resource
+// type check. Properties set with intrinsic functions such as Ref or
+// Fn::GetAtt cannot be resolved without a stack; they are left empty and
+// listed in UnknownAttributes so drift detection skips them.
+func ParseTemplate(r io.Reader) (map[string]*terraform.TerraformConfig, error) {
+	var tmpl template
+	if err := json.NewDecoder(r).Decode(&tmpl); err != nil {
+		return nil, fmt.Errorf("failed to parse CloudFormation template: %w", err)
+	}
+
+	configs := make(map[string]*terraform.TerraformConfig)
+	for logicalID, res := range tmpl.Resources {
+		if res.Type != ec2InstanceType {
+			continue
+		}
+		config, err := instanceConfig(logicalID, res.Properties)
+		if err != nil {
+			return nil, fmt.Errorf("resource %s: %w", logicalID, err)
+		}
+		configs[logicalID] = config
+	}
+
+	return configs, nil
+}
+
+// instanceConfig maps AWS::EC2::Instance properties onto a TerraformConfig.
+// Attribute names in UnknownAttributes use the drift detector's names.
+func instanceConfig(logicalID string, props map[string]interface{}) (*terraform.TerraformConfig, error) {
+	config := &terraform.TerraformConfig{
+		ResourceID:   "aws_instance." + logicalID,
+		ResourceName: logicalID,
+	}
+	unknown := func(attr string) {
+		config.UnknownAttributes = append(config.UnknownAttributes, attr)
+	}
+
+	stringFields := []struct {
+		prop  string
+		attr  string
+		field *string
+	}{
+		{"InstanceType", "instance_type", &config.InstanceType},
+		{"ImageId", "ami", &config.AMI},
+		{"KeyName", "key_name", &config.KeyName},
+		{"SubnetId", "subnet_id", &config.SubnetID},
+		{"AvailabilityZone", "availability_zone", &config.AvailabilityZone},
+		{"PrivateIpAddress", "private_ip", &config.PrivateIP},
+	}
+	for _, f := range stringFields {
+		value, resolved := stringProp(props, f.prop)
+		if !resolved {
+			unknown(f.attr)
+			continue
+		}
+		*f.field = value
+	}
+
+	if groups, resolved := stringListProp(props, "SecurityGroupIds"); resolved {
+		config.SecurityGroups = groups
+	} else {
+		unknown("security_groups")
+	}
+
+	boolFields := []struct {
+		prop  string
+		attr  string
+		field **bool
+	}{
+		{"EbsOptimized", "ebs_optimized", &config.EBSOptimized},
+		{"Monitoring", "monitoring", &config.Monitoring},
+		{"SourceDestCheck", "source_dest_check", &config.SourceDestCheck},
+	}
+	for _, f := range boolFields {
+		if isIntrinsic(props[f.prop]) {
+			unknown(f.attr)
+			continue
+		}
+		value, err := boolProp(props, f.prop)
+		if err != nil {
+			return nil, err
+		}
+		*f.field = value
+	}
+
+	if tags, ok := props["Tags"].([]interface{}); ok {
+		config.Tags = make(map[string]string, len(tags))
+		for _, tag := range tags {
+			entry, ok := tag.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid tag entry: %v", tag)
+			}
+			if isIntrinsic(entry["Key"]) || isIntrinsic(entry["Value"]) {
+				config.Tags = nil
+				unknown("tags")
+				break
+			}
+			key, _ := entry["Key"].(string)
+			value, _ := entry["Value"].(string)
+			if key == "" {
+				return nil, fmt.Errorf("tag entry missing Key: %v", tag)
+			}
+			config.Tags[key] = value
+		}
+	} else if isIntrinsic(props["Tags"]) {
+		unknown("tags")
+	}
+
+	sort.Strings(config.UnknownAttributes)
+	return config, nil
+}
+
+// isIntrinsic reports whether value is an intrinsic function call such as
+// {"Ref": "Subnet"} or {"Fn::GetAtt": [...]}
+func isIntrinsic(value interface{}) bool {
+	_, ok := value.(map[string]interface{})
+	return ok
+}
+
+// stringProp returns a literal string property. resolved is false when the
+// property is set with an intrinsic function.
+func stringProp(props map[string]interface{}, name string) (value string, resolved bool) {
+	switch v := props[name].(type) {
+	case nil:
+		return "", true
+	case string:
+		return v, true
+	default:
+		return "", false
+	}
+}
+
+// stringListProp returns the strings in a list property. resolved is false
+// when the list, or any element of it, is set with an intrinsic function,
+// since a partial list would be reported as drift.
+func stringListProp(props map[string]interface{}, name string) (values []string, resolved bool) {
+	value, exists := props[name]
+	if !exists || value == nil {
+		return nil, true
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, s)
+	}
+	return values, true
+}
+
+// boolProp returns a boolean property, accepting the "true"/"false" strings
+// CloudFormation templates commonly use
+func boolProp(props map[string]interface{}, name string) (*bool, error) {
+	switch v := props[name].(type) {
+	case bool:
+		return &v, nil
+	case string:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean for %s: %q", name, v)
+		}
+		return &b, nil
+	default:
+		return nil, nil
+	}
+}
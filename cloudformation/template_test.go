@@ -0,0 +1,108 @@
+package cloudformation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sampleTemplate = `{
+  "AWSTemplateFormatVersion": "2010-09-09",
+  "Resources": {
+    "WebServer": {
+      "Type": "AWS::EC2::Instance",
+      "Properties": {
+        "InstanceType": "t3.micro",
+        "ImageId": "ami-12345678",
+        "SubnetId": {"Ref": "PublicSubnet"},
+        "SecurityGroupIds": ["sg-123", {"Ref": "WebSecurityGroup"}],
+        "Monitoring": "true",
+        "Tags": [
+          {"Key": "Name", "Value": "web"},
+          {"Key": "Environment", "Value": "prod"}
+        ]
+      }
+    },
+    "WebSecurityGroup": {
+      "Type": "AWS::EC2::SecurityGroup",
+      "Properties": {"GroupDescription": "web"}
+    }
+  }
+}`
+
+func TestParseTemplate(t *testing.T) {
+	configs, err := ParseTemplate(strings.NewReader(sampleTemplate))
+	if err != nil {
+		t.Fatalf("ParseTemplate() unexpected error: %v", err)
+	}
+	if len(configs) != 1 {
+		t.Fatalf("Expected 1 instance, got %d", len(configs))
+	}
+
+	config, ok := configs["WebServer"]
+	if !ok {
+		t.Fatal("Expected config keyed by logical ID WebServer")
+	}
+	if config.ResourceID != "aws_instance.WebServer" {
+		t.Errorf("Expected resource ID aws_instance.WebServer, got %s", config.ResourceID)
+	}
+	if config.InstanceType != "t3.micro" {
+		t.Errorf("Expected instance type t3.micro, got %s", config.InstanceType)
+	}
+	if config.AMI != "ami-12345678" {
+		t.Errorf("Expected AMI ami-12345678, got %s", config.AMI)
+	}
+	if config.SubnetID != "" {
+		t.Errorf("Expected unresolved Ref subnet to be empty, got %s", config.SubnetID)
+	}
+	if config.SecurityGroups != nil {
+		t.Errorf("Expected a partly unresolved security group list to be omitted, got %v", config.SecurityGroups)
+	}
+	if !reflect.DeepEqual(config.UnknownAttributes, []string{"security_groups", "subnet_id"}) {
+		t.Errorf("Expected subnet_id and security_groups to be unknown, got %v", config.UnknownAttributes)
+	}
+	if config.Monitoring == nil || !*config.Monitoring {
+		t.Errorf("Expected monitoring to be true, got %v", config.Monitoring)
+	}
+	if config.Tags["Name"] != "web" || config.Tags["Environment"] != "prod" {
+		t.Errorf("Unexpected tags: %v", config.Tags)
+	}
+}
+
+func TestParseTemplate_Errors(t *testing.T) {
+	tests := map[string]string{
+		"invalid json":    `{"Resources": `,
+		"invalid bool":    `{"Resources": {"A": {"Type": "AWS::EC2::Instance", "Properties": {"EbsOptimized": "maybe"}}}}`,
+		"tag missing key": `{"Resources": {"A": {"Type": "AWS::EC2::Instance", "Properties": {"Tags": [{"Value": "x"}]}}}}`,
+	}
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseTemplate(strings.NewReader(body)); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestParseTemplate_IntrinsicsAreUnknown(t *testing.T) {
+	body := `{"Resources": {"App": {"Type": "AWS::EC2::Instance", "Properties": {
+		"InstanceType": "t3.micro",
+		"ImageId": {"Ref": "LatestAmi"},
+		"SecurityGroupIds": [{"Fn::GetAtt": ["AppSecurityGroup", "GroupId"]}],
+		"EbsOptimized": {"Ref": "UseEbsOptimized"},
+		"Tags": [{"Key": "Name", "Value": {"Fn::Sub": "${AWS::StackName}-app"}}]
+	}}}}`
+	configs, err := ParseTemplate(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("ParseTemplate() unexpected error: %v", err)
+	}
+
+	config := configs["App"]
+	want := []string{"ami", "ebs_optimized", "security_groups", "tags"}
+	if !reflect.DeepEqual(config.UnknownAttributes, want) {
+		t.Errorf("Expected unknown attributes %v, got %v", want, config.UnknownAttributes)
+	}
+	if config.InstanceType != "t3.micro" || config.AMI != "" || config.Tags != nil || config.EBSOptimized != nil {
+		t.Errorf("Expected only literal properties to be set, got %+v", config)
+	}
+}
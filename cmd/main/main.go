@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"syscall"
 
 	"firefly-task/config"
 	"firefly-task/pkg/app"
@@ -34,12 +35,26 @@ func main() {
 
 	// Create command handler and execute with error handling middleware
 	cmdHandler := app.NewCommandHandler(appInstance)
-	if err := executeWithErrorHandling(cmdHandler); err != nil {
+	err = executeWithErrorHandling(cmdHandler)
+	if sig := appInstance.InterruptSignal(); sig != nil {
+		fmt.Fprintf(os.Stderr, "Interrupted by %s\n", sig)
+		os.Exit(signalExitCode(sig))
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
+// signalExitCode returns the conventional 128+n exit code for a run stopped
+// by signal n
+func signalExitCode(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return 128 + int(s)
+	}
+	return 1
+}
+
 // executeWithErrorHandling wraps command execution with proper error handling and logging
 func executeWithErrorHandling(cmdHandler *app.CommandHandler) error {
 	// Get logging configuration from environment or use defaults
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 // Config holds all configuration values for the application
@@ -21,6 +22,33 @@ type Config struct {
 	InputFile     string
 	Concurrency   int
 	Attribute     string
+
+	// FailOnAttributes lists attributes whose drift makes the command fail
+	FailOnAttributes []string
+
+	// Since and Until restrict output to results detected within the window
+	Since *time.Time
+	Until *time.Time
+
+	// MinExpectedResources fails a batch run that evaluated fewer resources,
+	// catching inputs that silently parsed to (almost) nothing. 0 disables it.
+	MinExpectedResources int
+
+	// ConcurrentRecommendations analyzes results for recommendations in a
+	// separate goroutine while the report is generated
+	ConcurrentRecommendations bool
+
+	// ShutdownGracePeriod bounds how long registered sinks may flush pending
+	// deliveries on shutdown. 0 uses the application default.
+	ShutdownGracePeriod time.Duration
+
+	// WebhookURL receives the drift results of check and batch runs when
+	// set. Deliveries still pending at shutdown get ShutdownGracePeriod.
+	WebhookURL string
+
+	// StatusAddr serves /healthz and /status on this address while the
+	// application runs when set, e.g. "127.0.0.1:8080"
+	StatusAddr string
 }
 
 // OutputFormat represents valid output formats
@@ -76,6 +104,14 @@ func (c *Config) ValidateConfig() error {
 		return fmt.Errorf("concurrency cannot exceed 50, got %d", c.Concurrency)
 	}
 
+	if c.MinExpectedResources < 0 {
+		return fmt.Errorf("minimum expected resources cannot be negative, got %d", c.MinExpectedResources)
+	}
+
+	if c.ShutdownGracePeriod < 0 {
+		return fmt.Errorf("shutdown grace period cannot be negative, got %s", c.ShutdownGracePeriod)
+	}
+
 	// Normalize paths
 	if c.TerraformPath != "" {
 		c.TerraformPath = NormalizePath(c.TerraformPath)
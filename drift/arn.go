@@ -0,0 +1,111 @@
+package drift
+
+import (
+	"reflect"
+	"strings"
+)
+
+// ARNResource is implemented by resources identified by an ARN
+type ARNResource interface {
+	ResourceARN() string
+}
+
+// ARNNormalization selects the ARN components replaced with "*" before ARN
+// attribute values are compared, so the same resource in another partition,
+// region or account is treated as equal
+type ARNNormalization struct {
+	IgnorePartition bool `json:"ignore_partition,omitempty"`
+	IgnoreRegion    bool `json:"ignore_region,omitempty"`
+	IgnoreAccount   bool `json:"ignore_account,omitempty"`
+}
+
+// Enabled reports whether any component is normalized
+func (n ARNNormalization) Enabled() bool {
+	return n.IgnorePartition || n.IgnoreRegion || n.IgnoreAccount
+}
+
+// Normalize rewrites arn with the ignored components replaced by "*".
+// Values that are not ARNs are returned unchanged.
+func (n ARNNormalization) Normalize(arn string) string {
+	// arn:partition:service:region:account-id:resource
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" {
+		return arn
+	}
+
+	if n.IgnorePartition {
+		parts[1] = "*"
+	}
+	if n.IgnoreRegion {
+		parts[3] = "*"
+	}
+	if n.IgnoreAccount {
+		parts[4] = "*"
+	}
+	return strings.Join(parts, ":")
+}
+
+// extractARN returns the ARN of resource from ARNResource or from a string
+// field named ARN or Arn, or "" if it has none
+func extractARN(resource interface{}) string {
+	if r, ok := resource.(ARNResource); ok {
+		return r.ResourceARN()
+	}
+
+	v := reflect.ValueOf(resource)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+
+	for _, name := range []string{"ARN", "Arn"} {
+		field := v.FieldByName(name)
+		if !field.IsValid() {
+			continue
+		}
+		if field.Kind() == reflect.Ptr && !field.IsNil() {
+			field = field.Elem()
+		}
+		if field.Kind() == reflect.String {
+			return field.String()
+		}
+	}
+	return ""
+}
+
+// normalizeARNValues returns a copy of m with ARN strings, alone or in
+// slices, normalized
+func (d *DriftDetector) normalizeARNValues(m map[string]interface{}) map[string]interface{} {
+	normalization := d.config.ARNNormalization
+	normalized := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		switch v := value.(type) {
+		case string:
+			normalized[key] = normalization.Normalize(v)
+		case []string:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = normalization.Normalize(item)
+			}
+			normalized[key] = items
+		case []interface{}:
+			items := make([]interface{}, len(v))
+			for i, item := range v {
+				if s, ok := item.(string); ok {
+					items[i] = normalization.Normalize(s)
+				} else {
+					items[i] = item
+				}
+			}
+			normalized[key] = items
+		default:
+			normalized[key] = value
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,58 @@
+package drift
+
+import "testing"
+
+type arnOnlyQueue struct {
+	ARN               string
+	VisibilityTimeout int
+}
+
+type arnProviderTopic struct {
+	arn string
+}
+
+func (t *arnProviderTopic) ResourceARN() string { return t.arn }
+
+func TestExtractResourceID_ARN(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.ARNNormalization = ARNNormalization{IgnoreRegion: true, IgnoreAccount: true}
+	detector := NewDriftDetector(config)
+
+	actual := &arnOnlyQueue{ARN: "arn:aws:sqs:us-east-1:111111111111:jobs", VisibilityTimeout: 30}
+	expected := &arnOnlyQueue{ARN: "arn:aws:sqs:eu-west-1:222222222222:jobs", VisibilityTimeout: 30}
+
+	result, err := detector.DetectDrift(actual, expected)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.ResourceID != actual.ARN {
+		t.Errorf("Expected the real ARN as resource ID, got %s", result.ResourceID)
+	}
+	if result.IsDrifted {
+		t.Errorf("Expected normalized ARNs to compare equal, got %+v", result.DriftDetails[0])
+	}
+
+	plain := NewDriftDetector(DefaultDetectionConfig())
+	result, err = plain.DetectDrift(actual, expected)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.IsDrifted {
+		t.Error("Expected ARNs from different accounts to drift without normalization")
+	}
+
+	topic := &arnProviderTopic{arn: "arn:aws:sns:us-east-1:111111111111:alerts"}
+	if id := plain.extractResourceID(topic); id != topic.arn {
+		t.Errorf("Expected ARN from ARNResource, got %s", id)
+	}
+}
+
+func TestARNNormalization_Normalize(t *testing.T) {
+	n := ARNNormalization{IgnorePartition: true}
+	if got := n.Normalize("arn:aws-us-gov:s3:::bucket/key:with:colons"); got != "arn:*:s3:::bucket/key:with:colons" {
+		t.Errorf("Unexpected normalized ARN: %s", got)
+	}
+	if got := n.Normalize("not-an-arn"); got != "not-an-arn" {
+		t.Errorf("Expected non-ARN values unchanged, got %s", got)
+	}
+}
@@ -1,11 +1,18 @@
 package drift
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
+	"net"
 	"reflect"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+
+	"firefly-task/pkg/interfaces"
+	"firefly-task/pkg/numfmt"
 )
 
 // compareString compares two string values according to the provided configuration
@@ -41,10 +48,29 @@ func compareNumeric(actual, expected float64, config AttributeConfig) (bool, str
 
 // compareArray compares two arrays/slices according to the provided configuration
 func compareArray(actual, expected []interface{}, config AttributeConfig) (bool, string) {
+	if config.ComparisonType == KeyedObjectArray && config.KeyField != "" {
+		return compareKeyedObjectArray(actual, expected, config.KeyField)
+	}
+
+	if config.ComparisonType == ArrayUnordered && config.ReportSetDelta {
+		delta := computeSetDelta(actual, expected)
+		if len(delta.Added) == 0 && len(delta.Removed) == 0 {
+			return true, "array comparison (unordered): all elements match"
+		}
+		return false, describeSetDelta(delta, len(expected))
+	}
+
 	if len(actual) != len(expected) {
+		if config.ComparisonType != ArrayUnordered && !(isMapSlice(actual) && isMapSlice(expected)) {
+			return compareArrayOrdered(actual, expected)
+		}
 		return false, fmt.Sprintf("array length mismatch: %d vs %d", len(actual), len(expected))
 	}
 
+	if isMapSlice(actual) && isMapSlice(expected) {
+		return compareObjectArray(actual, expected, config.ComparisonType == ArrayUnordered)
+	}
+
 	if config.ComparisonType == ArrayUnordered {
 		return compareArrayUnordered(actual, expected)
 	}
@@ -53,13 +79,370 @@ func compareArray(actual, expected []interface{}, config AttributeConfig) (bool,
 	return compareArrayOrdered(actual, expected)
 }
 
+// compareKeyedObjectArray matches objects by keyField and reports the first
+// object, in key order, that was removed, added or changed
+func compareKeyedObjectArray(actual, expected []interface{}, keyField string) (bool, string) {
+	index := func(items []interface{}) (map[string]map[string]interface{}, error) {
+		byKey := make(map[string]map[string]interface{}, len(items))
+		for _, item := range items {
+			m, err := convertToMap(item)
+			if err != nil {
+				return nil, err
+			}
+			byKey[fmt.Sprintf("%v", m[keyField])] = normalizeObject(m).(map[string]interface{})
+		}
+		return byKey, nil
+	}
+
+	actualByKey, err1 := index(actual)
+	expectedByKey, err2 := index(expected)
+	if err1 != nil || err2 != nil {
+		return false, fmt.Sprintf("keyed array conversion error: %v, %v", err1, err2)
+	}
+
+	keys := make([]string, 0, len(actualByKey)+len(expectedByKey))
+	for key := range expectedByKey {
+		keys = append(keys, key)
+	}
+	for key := range actualByKey {
+		if _, ok := expectedByKey[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		actualObj, inActual := actualByKey[key]
+		expectedObj, inExpected := expectedByKey[key]
+		switch {
+		case !inActual:
+			return false, fmt.Sprintf("object with %s '%s' removed", keyField, key)
+		case !inExpected:
+			return false, fmt.Sprintf("object with %s '%s' added", keyField, key)
+		}
+
+		fields := make([]string, 0, len(expectedObj))
+		for field := range expectedObj {
+			fields = append(fields, field)
+		}
+		for field := range actualObj {
+			if _, ok := expectedObj[field]; !ok {
+				fields = append(fields, field)
+			}
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			if !deepEqual(actualObj[field], expectedObj[field]) {
+				return false, fmt.Sprintf("object with %s '%s' changed: %s %v vs %v",
+					keyField, key, field, actualObj[field], expectedObj[field])
+			}
+		}
+	}
+
+	return true, fmt.Sprintf("keyed array comparison: all objects match by %s", keyField)
+}
+
+// compareJSONSemantic compares two JSON documents structurally. Array order
+// is ignored, matching how policy documents treat statements and actions.
+// Values that are not valid JSON fall back to string comparison.
+func compareJSONSemantic(actual, expected interface{}, config AttributeConfig) (bool, string) {
+	actualStr := convertToStringWithPrecision(actual, config.FloatPrecision)
+	expectedStr := convertToStringWithPrecision(expected, config.FloatPrecision)
+
+	var actualDoc, expectedDoc interface{}
+	if json.Unmarshal([]byte(actualStr), &actualDoc) != nil || json.Unmarshal([]byte(expectedStr), &expectedDoc) != nil {
+		return compareString(actualStr, expectedStr, AttributeConfig{CaseSensitive: true})
+	}
+
+	actualCanonical, err1 := json.Marshal(canonicalJSON(actualDoc))
+	expectedCanonical, err2 := json.Marshal(canonicalJSON(expectedDoc))
+	if err1 != nil || err2 != nil {
+		return false, fmt.Sprintf("json canonicalization error: %v, %v", err1, err2)
+	}
+
+	if string(actualCanonical) != string(expectedCanonical) {
+		return false, fmt.Sprintf("json documents differ: %s vs %s", actualCanonical, expectedCanonical)
+	}
+	return true, "json semantic comparison: documents are equivalent"
+}
+
+// compareIP compares two IP addresses by value. Values that are not IP
+// addresses fall back to string comparison.
+func compareIP(actual, expected interface{}, config AttributeConfig) (bool, string) {
+	actualStr := convertToString(actual)
+	expectedStr := convertToString(expected)
+
+	actualIP, expectedIP := parseIP(actualStr), parseIP(expectedStr)
+	if actualIP == nil || expectedIP == nil {
+		return compareString(actualStr, expectedStr, AttributeConfig{CaseSensitive: config.CaseSensitive})
+	}
+
+	if !actualIP.Equal(expectedIP) {
+		return false, fmt.Sprintf("ip addresses differ: %s vs %s", actualIP, expectedIP)
+	}
+	return true, fmt.Sprintf("ip comparison: %s", actualIP)
+}
+
+// compareChain tries each comparison type in config.ComparisonChain in order
+// and reports which one matched, or the last comparator's description when
+// none did
+func compareChain(actual, expected interface{}, config AttributeConfig) (bool, string) {
+	chain := config.ComparisonChain
+	config.ComparisonChain = nil
+
+	var description string
+	for _, ct := range chain {
+		config.ComparisonType = ct
+		var equal bool
+		equal, description = CompareValues(actual, expected, config)
+		if equal {
+			return true, fmt.Sprintf("matched by %s comparison: %s", ct, description)
+		}
+	}
+	return false, fmt.Sprintf("no comparison in chain %s matched: %s", comparisonChainName(chain), description)
+}
+
+// comparisonChainName joins the names of the comparison types in chain
+func comparisonChainName(chain []ComparisonType) string {
+	names := make([]string, len(chain))
+	for i, ct := range chain {
+		names[i] = ct.String()
+	}
+	return strings.Join(names, ",")
+}
+
+// compareSemver compares two semantic versions. Values that are not versions
+// fall back to string comparison.
+func compareSemver(actual, expected interface{}, config AttributeConfig) (bool, string) {
+	actualStr := convertToString(actual)
+	expectedStr := convertToString(expected)
+
+	if config.VersionConstraint != "" {
+		return compareVersionConstraint(actualStr, config.VersionConstraint)
+	}
+
+	actualVersion, ok1 := parseSemver(actualStr)
+	expectedVersion, ok2 := parseSemver(expectedStr)
+	if !ok1 || !ok2 {
+		return compareString(actualStr, expectedStr, AttributeConfig{CaseSensitive: config.CaseSensitive})
+	}
+
+	if actualVersion != expectedVersion {
+		return false, fmt.Sprintf("versions differ: %s vs %s", actualVersion, expectedVersion)
+	}
+	return true, fmt.Sprintf("semver comparison: %s", actualVersion)
+}
+
+// compareRegex reports whether the AWS value matches config.Pattern. The
+// expected value only needs to be present; an invalid pattern never matches.
+func compareRegex(actual interface{}, config AttributeConfig) (bool, string) {
+	re, err := regexp.Compile(config.Pattern)
+	if err != nil {
+		return false, fmt.Sprintf("invalid regex pattern %q: %v", config.Pattern, err)
+	}
+	actualStr := convertToString(actual)
+	if !re.MatchString(actualStr) {
+		return false, fmt.Sprintf("regex comparison: '%s' does not match %q", actualStr, config.Pattern)
+	}
+	return true, fmt.Sprintf("regex comparison: '%s' matches %q", actualStr, config.Pattern)
+}
+
+// parseIP parses s as an IP address, also accepting IPv4 octets with leading
+// zeros, which net.ParseIP rejects. It returns nil if s is not an IP address.
+func parseIP(s string) net.IP {
+	s = strings.TrimSpace(s)
+	if ip := net.ParseIP(s); ip != nil {
+		return ip
+	}
+
+	octets := strings.Split(s, ".")
+	if len(octets) != 4 {
+		return nil
+	}
+	for i, octet := range octets {
+		if octet == "" || strings.TrimLeft(octet, "0123456789") != "" {
+			return nil
+		}
+		n, err := strconv.Atoi(octet)
+		if err != nil || n > 255 {
+			return nil
+		}
+		octets[i] = strconv.Itoa(n)
+	}
+	return net.ParseIP(strings.Join(octets, "."))
+}
+
+// canonicalJSON sorts every array in a decoded JSON document by the encoding
+// of its elements. Object keys are already sorted by encoding/json.
+func canonicalJSON(doc interface{}) interface{} {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = canonicalJSON(item)
+		}
+		return v
+	case []interface{}:
+		encoded := make([]string, len(v))
+		for i, item := range v {
+			v[i] = canonicalJSON(item)
+			b, _ := json.Marshal(v[i])
+			encoded[i] = string(b)
+		}
+		sort.Sort(byEncoding{items: v, keys: encoded})
+		return v
+	default:
+		return v
+	}
+}
+
+// byEncoding sorts items by their precomputed JSON encodings
+type byEncoding struct {
+	items []interface{}
+	keys  []string
+}
+
+func (b byEncoding) Len() int           { return len(b.items) }
+func (b byEncoding) Less(i, j int) bool { return b.keys[i] < b.keys[j] }
+func (b byEncoding) Swap(i, j int) {
+	b.items[i], b.items[j] = b.items[j], b.items[i]
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+}
+
+// isMapSlice reports whether every element of a non-empty slice is a map
+func isMapSlice(values []interface{}) bool {
+	if len(values) == 0 {
+		return false
+	}
+	for _, v := range values {
+		if v == nil || reflect.ValueOf(v).Kind() != reflect.Map {
+			return false
+		}
+	}
+	return true
+}
+
+// compareObjectArray compares arrays of maps (block attributes) by normalizing
+// each element to a canonical form. Unordered comparison treats the arrays
+// as multisets.
+func compareObjectArray(actual, expected []interface{}, unordered bool) (bool, string) {
+	actualKeys := make([]string, len(actual))
+	expectedKeys := make([]string, len(expected))
+	for i, v := range actual {
+		actualKeys[i] = canonicalObject(v)
+	}
+	for i, v := range expected {
+		expectedKeys[i] = canonicalObject(v)
+	}
+
+	if !unordered {
+		for i := range actualKeys {
+			if actualKeys[i] != expectedKeys[i] {
+				return false, fmt.Sprintf("object array element mismatch at index %d: %s vs %s", i, actualKeys[i], expectedKeys[i])
+			}
+		}
+		return true, "object array comparison (ordered): all elements match"
+	}
+
+	counts := make(map[string]int, len(expectedKeys))
+	for _, key := range expectedKeys {
+		counts[key]++
+	}
+
+	var unexpected []string
+	for _, key := range actualKeys {
+		if counts[key] > 0 {
+			counts[key]--
+			continue
+		}
+		unexpected = append(unexpected, key)
+	}
+
+	if len(unexpected) == 0 {
+		return true, "object array comparison (unordered): all elements match"
+	}
+
+	var missing []string
+	for _, key := range expectedKeys {
+		if counts[key] > 0 {
+			counts[key]--
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(unexpected)
+	sort.Strings(missing)
+
+	return false, fmt.Sprintf("object array mismatch (unordered): unexpected %s, missing %s",
+		strings.Join(unexpected, ", "), strings.Join(missing, ", "))
+}
+
+// canonicalObject renders a value as JSON with map keys sorted, so that maps
+// with equal content but different concrete types produce the same string
+func canonicalObject(value interface{}) string {
+	data, err := json.Marshal(normalizeObject(value))
+	if err != nil {
+		return convertToString(value)
+	}
+	return string(data)
+}
+
+// normalizeObject converts nested maps and slices to generic forms
+func normalizeObject(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Map:
+		m, err := convertToMap(value)
+		if err != nil {
+			return value
+		}
+		for k, item := range m {
+			m[k] = normalizeObject(item)
+		}
+		return m
+	case reflect.Slice, reflect.Array:
+		items, err := convertToSlice(value)
+		if err != nil {
+			return value
+		}
+		for i, item := range items {
+			items[i] = normalizeObject(item)
+		}
+		return items
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return normalizeObject(v.Elem().Interface())
+	default:
+		return value
+	}
+}
+
 // compareArrayOrdered compares arrays considering element order
 func compareArrayOrdered(actual, expected []interface{}) (bool, string) {
-	for i := 0; i < len(actual); i++ {
+	common := min(len(actual), len(expected))
+	for i := 0; i < common; i++ {
 		if !deepEqual(actual[i], expected[i]) {
+			if len(actual) != len(expected) {
+				return false, fmt.Sprintf("array element mismatch at index %d: %v vs %v (length %d vs %d)",
+					i, actual[i], expected[i], len(actual), len(expected))
+			}
 			return false, fmt.Sprintf("array element mismatch at index %d: %v vs %v", i, actual[i], expected[i])
 		}
 	}
+
+	// The shorter array is a prefix of the longer one, so report the tail
+	switch {
+	case len(actual) > len(expected):
+		return false, fmt.Sprintf("array length mismatch: %d vs %d, extra elements from index %d: %v",
+			len(actual), len(expected), common, actual[common:])
+	case len(actual) < len(expected):
+		return false, fmt.Sprintf("array length mismatch: %d vs %d, missing elements from index %d: %v",
+			len(actual), len(expected), common, expected[common:])
+	}
 	return true, "array comparison (ordered): all elements match"
 }
 
@@ -70,10 +453,10 @@ func compareArrayUnordered(actual, expected []interface{}) (bool, string) {
 	expectedStrs := make([]string, len(expected))
 
 	for i, v := range actual {
-		actualStrs[i] = fmt.Sprintf("%v", v)
+		actualStrs[i] = unorderedKey(v)
 	}
 	for i, v := range expected {
-		expectedStrs[i] = fmt.Sprintf("%v", v)
+		expectedStrs[i] = unorderedKey(v)
 	}
 
 	sort.Strings(actualStrs)
@@ -88,8 +471,105 @@ func compareArrayUnordered(actual, expected []interface{}) (bool, string) {
 	return true, "array comparison (unordered): all elements match"
 }
 
+// unorderedKey is the form compareArrayUnordered matches elements by
+func unorderedKey(value interface{}) string {
+	return fmt.Sprintf("%v", value)
+}
+
+// computeSetDelta diffs actual against expected as multisets, matching
+// elements the way compareArray does without a delta: arrays of maps by
+// canonicalObject and other arrays by unorderedKey. Added and Removed are
+// sorted by that key.
+func computeSetDelta(actual, expected []interface{}) *interfaces.SetDelta {
+	elementKey := unorderedKey
+	if isMapSlice(actual) && isMapSlice(expected) {
+		elementKey = canonicalObject
+	}
+
+	remaining := make(map[string][]interface{}, len(expected))
+	for _, v := range expected {
+		key := elementKey(v)
+		remaining[key] = append(remaining[key], v)
+	}
+
+	delta := &interfaces.SetDelta{Added: []interface{}{}, Removed: []interface{}{}}
+	var addedKeys []string
+	added := make(map[string][]interface{})
+	for _, v := range actual {
+		key := elementKey(v)
+		if len(remaining[key]) > 0 {
+			remaining[key] = remaining[key][1:]
+			delta.Matched++
+			continue
+		}
+		if len(added[key]) == 0 {
+			addedKeys = append(addedKeys, key)
+		}
+		added[key] = append(added[key], v)
+	}
+
+	sort.Strings(addedKeys)
+	for _, key := range addedKeys {
+		delta.Added = append(delta.Added, added[key]...)
+	}
+	removedKeys := make([]string, 0, len(remaining))
+	for key, values := range remaining {
+		if len(values) > 0 {
+			removedKeys = append(removedKeys, key)
+		}
+	}
+	sort.Strings(removedKeys)
+	for _, key := range removedKeys {
+		delta.Removed = append(delta.Removed, remaining[key]...)
+	}
+	return delta
+}
+
+// describeSetDelta summarizes a set delta against the expected element count
+func describeSetDelta(delta *interfaces.SetDelta, expectedCount int) string {
+	return fmt.Sprintf("array set mismatch (unordered): %d of %d elements match, added %v, removed %v",
+		delta.Matched, expectedCount, delta.Added, delta.Removed)
+}
+
+// setDeltaFor computes the set delta between two array values, or nil if
+// either is not an array
+func setDeltaFor(actual, expected interface{}) *interfaces.SetDelta {
+	actualSlice, err := convertToSlice(actual)
+	if err != nil {
+		return nil
+	}
+	expectedSlice, err := convertToSlice(expected)
+	if err != nil {
+		return nil
+	}
+	return computeSetDelta(actualSlice, expectedSlice)
+}
+
 // compareMap compares two maps key by key
 func compareMap(actual, expected map[string]interface{}, config AttributeConfig) (bool, string) {
+	if len(config.IgnoreKeys) > 0 {
+		actual = dropIgnoredKeys(actual, config.IgnoreKeys)
+		expected = dropIgnoredKeys(expected, config.IgnoreKeys)
+	}
+
+	if config.CaseInsensitiveKeys {
+		var conflict string
+		if actual, conflict = foldMapKeys(actual); conflict != "" {
+			return false, "key case conflict in actual map: " + conflict
+		}
+		if expected, conflict = foldMapKeys(expected); conflict != "" {
+			return false, "key case conflict in expected map: " + conflict
+		}
+	}
+
+	if limit := config.maxDepth(); exceedsDepth(reflect.ValueOf(actual), limit) || exceedsDepth(reflect.ValueOf(expected), limit) {
+		return false, depthExceeded(limit)
+	}
+
+	if len(actual) >= largeMapThreshold || len(expected) >= largeMapThreshold {
+		return compareLargeMap(actual, expected)
+	}
+
 	if len(actual) != len(expected) {
 		return false, fmt.Sprintf("map size mismatch: %d vs %d keys", len(actual), len(expected))
 	}
@@ -116,8 +596,119 @@ func compareMap(actual, expected map[string]interface{}, config AttributeConfig)
 	return true, "map comparison: all key-value pairs match"
 }
 
+// dropIgnoredKeys returns m without the keys matching any of patterns. m is
+// returned unchanged when no key matches.
+func dropIgnoredKeys(m map[string]interface{}, patterns []string) map[string]interface{} {
+	var filtered map[string]interface{}
+	for key := range m {
+		if !matchesAnyGlob(patterns, key) {
+			continue
+		}
+		if filtered == nil {
+			filtered = make(map[string]interface{}, len(m))
+			for k, v := range m {
+				filtered[k] = v
+			}
+		}
+		delete(filtered, key)
+	}
+	if filtered == nil {
+		return m
+	}
+	return filtered
+}
+
+// matchesAnyGlob reports whether name equals or matches one of patterns
+func matchesAnyGlob(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matchGlob(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// largeMapThreshold is the entry count from which compareMap collects
+	// every changed key instead of stopping at the first
+	largeMapThreshold = 256
+	// maxReportedMapKeys caps the changed keys listed for a large map
+	maxReportedMapKeys = 10
+)
+
+// compareLargeMap compares maps too large to report key by key. Every
+// changed key is collected and the description lists the first
+// maxReportedMapKeys of them. There is no hash fast path for equal maps:
+// hashing both maps touches every entry just as the diff does, and measured
+// slower than it.
+func compareLargeMap(actual, expected map[string]interface{}) (bool, string) {
+	var changed []string
+	missing := 0
+	for key, expectedValue := range expected {
+		actualValue, exists := actual[key]
+		if !exists {
+			missing++
+		}
+		if !exists || !deepEqual(actualValue, expectedValue) {
+			changed = append(changed, key)
+		}
+	}
+	// With equal sizes and no missing keys, actual cannot have extra keys
+	if len(actual) != len(expected) || missing > 0 {
+		for key := range actual {
+			if _, exists := expected[key]; !exists {
+				changed = append(changed, key)
+			}
+		}
+	}
+	if len(changed) == 0 {
+		return true, "map comparison: all key-value pairs match"
+	}
+	sort.Strings(changed)
+
+	listed := changed
+	if len(listed) > maxReportedMapKeys {
+		listed = listed[:maxReportedMapKeys]
+	}
+	desc := fmt.Sprintf("map mismatch (%d vs %d keys), changed keys: %s", len(actual), len(expected), strings.Join(listed, ", "))
+	if more := len(changed) - len(listed); more > 0 {
+		desc += fmt.Sprintf(" (+%d more)", more)
+	}
+	return false, desc
+}
+
+// foldMapKeys lower-cases the keys of m. If two keys fold to the same value
+// the returned conflict describes them, since either value could be meant.
+func foldMapKeys(m map[string]interface{}) (map[string]interface{}, string) {
+	folded := make(map[string]interface{}, len(m))
+	originals := make(map[string]string, len(m))
+	for key, value := range m {
+		lower := strings.ToLower(key)
+		if previous, exists := originals[lower]; exists {
+			first, second := previous, key
+			if second < first {
+				first, second = second, first
+			}
+			return nil, fmt.Sprintf("'%s' and '%s' both match '%s'", first, second, lower)
+		}
+		originals[lower] = key
+		folded[lower] = value
+	}
+	return folded, ""
+}
+
 // compareNestedObject compares nested objects/structures
 func compareNestedObject(actual, expected interface{}, config AttributeConfig) (bool, string) {
+	return compareNestedObjectAt(actual, expected, config, 0)
+}
+
+// compareNestedObjectAt compares nested objects found depth levels below the
+// attribute, giving up once depth passes the configured limit
+func compareNestedObjectAt(actual, expected interface{}, config AttributeConfig, depth int) (bool, string) {
+	if depth > config.maxDepth() {
+		return false, depthExceeded(config.maxDepth())
+	}
+
 	// Handle nil cases
 	if actual == nil && expected == nil {
 		return true, "both values are nil"
@@ -169,7 +760,7 @@ func compareNestedObject(actual, expected interface{}, config AttributeConfig) (
 		}
 		return compareMap(actualMap, expectedMap, config)
 	case reflect.Struct:
-		return compareStruct(actualValue, expectedValue, config)
+		return compareStruct(actualValue, expectedValue, config, depth)
 	case reflect.Ptr:
 		if actualValue.IsNil() && expectedValue.IsNil() {
 			return true, "both pointers are nil"
@@ -177,7 +768,7 @@ func compareNestedObject(actual, expected interface{}, config AttributeConfig) (
 		if actualValue.IsNil() || expectedValue.IsNil() {
 			return false, fmt.Sprintf("pointer nil mismatch: %v vs %v", actual, expected)
 		}
-		return compareNestedObject(actualValue.Elem().Interface(), expectedValue.Elem().Interface(), config)
+		return compareNestedObjectAt(actualValue.Elem().Interface(), expectedValue.Elem().Interface(), config, depth+1)
 	default:
 		// Fallback to deep equal
 		isEqual := deepEqual(actual, expected)
@@ -186,7 +777,7 @@ func compareNestedObject(actual, expected interface{}, config AttributeConfig) (
 }
 
 // compareStruct compares two struct values field by field
-func compareStruct(actualValue, expectedValue reflect.Value, config AttributeConfig) (bool, string) {
+func compareStruct(actualValue, expectedValue reflect.Value, config AttributeConfig, depth int) (bool, string) {
 	structType := actualValue.Type()
 
 	for i := 0; i < structType.NumField(); i++ {
@@ -206,9 +797,10 @@ func compareStruct(actualValue, expectedValue reflect.Value, config AttributeCon
 			Tolerance:      config.Tolerance,
 			CaseSensitive:  config.CaseSensitive,
 			Required:       config.Required,
+			MaxDepth:       config.MaxDepth,
 		}
 
-		isEqual, description := compareNestedObject(actualField.Interface(), expectedField.Interface(), fieldConfig)
+		isEqual, description := compareNestedObjectAt(actualField.Interface(), expectedField.Interface(), fieldConfig, depth+1)
 		if !isEqual {
 			return false, fmt.Sprintf("struct field '%s' mismatch: %s", field.Name, description)
 		}
@@ -217,6 +809,52 @@ func compareStruct(actualValue, expectedValue reflect.Value, config AttributeCon
 	return true, "struct comparison: all fields match"
 }
 
+// depthExceeded describes a comparison stopped by the depth limit
+func depthExceeded(limit int) string {
+	return fmt.Sprintf("comparison depth exceeded: values are nested more than %d levels deep", limit)
+}
+
+// exceedsDepth reports whether v nests maps, slices, pointers or structs more
+// than limit levels deep. It stops descending at the limit, so cyclic values
+// terminate.
+func exceedsDepth(v reflect.Value, limit int) bool {
+	if limit < 0 {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return false
+		}
+		return exceedsDepth(v.Elem(), limit)
+	case reflect.Ptr:
+		if v.IsNil() {
+			return false
+		}
+		return exceedsDepth(v.Elem(), limit-1)
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if exceedsDepth(iter.Value(), limit-1) {
+				return true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if exceedsDepth(v.Index(i), limit-1) {
+				return true
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if exceedsDepth(v.Field(i), limit-1) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // deepEqual performs a deep equality check between two values
 func deepEqual(a, b interface{}) bool {
 	return reflect.DeepEqual(a, b)
@@ -262,6 +900,22 @@ func convertToString(value interface{}) string {
 	return fmt.Sprintf("%v", value)
 }
 
+// convertToStringWithPrecision converts a value to string, rounding floats to
+// the given number of decimals when precision is set
+func convertToStringWithPrecision(value interface{}, precision *int) string {
+	if precision == nil {
+		return convertToString(value)
+	}
+	switch v := value.(type) {
+	case float64:
+		return numfmt.FormatFloat(v, *precision)
+	case float32:
+		return numfmt.FormatFloat(float64(v), *precision)
+	default:
+		return convertToString(value)
+	}
+}
+
 // convertToSlice attempts to convert an interface{} to []interface{}
 func convertToSlice(value interface{}) ([]interface{}, error) {
 	if value == nil {
@@ -301,25 +955,59 @@ func convertToMap(value interface{}) (map[string]interface{}, error) {
 	return result, nil
 }
 
+func isSliceKind(kind reflect.Kind) bool {
+	return kind == reflect.Slice || kind == reflect.Array
+}
+
 // CompareValues is a high-level function that compares two values using the appropriate comparator
 func CompareValues(actual, expected interface{}, config AttributeConfig) (bool, string) {
+	if len(config.ComparisonChain) > 0 {
+		return compareChain(actual, expected, config)
+	}
+
 	// Handle nil cases first
 	if actual == nil && expected == nil {
 		return true, "both values are nil"
 	}
+	if config.EmptyEqualsAbsent && isEmptyOrAbsent(actual) && isEmptyOrAbsent(expected) {
+		return true, "absent and empty values are treated as equal"
+	}
 	if actual == nil || expected == nil {
 		return false, fmt.Sprintf("nil mismatch: %v vs %v", actual, expected)
 	}
 
+	if config.ComparisonType == JSONSemanticMatch {
+		return compareJSONSemantic(actual, expected, config)
+	}
+	if config.ComparisonType == IPMatch {
+		return compareIP(actual, expected, config)
+	}
+	if config.ComparisonType == SemverMatch {
+		return compareSemver(actual, expected, config)
+	}
+	if config.ComparisonType == RegexMatch {
+		return compareRegex(actual, config)
+	}
+
 	// Try to determine the best comparison method based on the types
 	actualValue := reflect.ValueOf(actual)
 	expectedValue := reflect.ValueOf(expected)
 
+	// Slices of different element types (e.g. []map[string]string and
+	// []interface{}) are still compared element-wise
+	if actualValue.Type() != expectedValue.Type() && isSliceKind(actualValue.Kind()) && isSliceKind(expectedValue.Kind()) {
+		actualSlice, err1 := convertToSlice(actual)
+		expectedSlice, err2 := convertToSlice(expected)
+		if err1 == nil && err2 == nil {
+			return compareArray(actualSlice, expectedSlice, config)
+		}
+	}
+
 	// If types don't match, try to convert them
 	if actualValue.Type() != expectedValue.Type() {
 		// Try string conversion first
-		actualStr := convertToString(actual)
-		expectedStr := convertToString(expected)
+		actualStr := convertToStringWithPrecision(actual, config.FloatPrecision)
+		expectedStr := convertToStringWithPrecision(expected, config.FloatPrecision)
 		return compareString(actualStr, expectedStr, config)
 	}
 
@@ -354,3 +1042,70 @@ func CompareValues(actual, expected interface{}, config AttributeConfig) (bool,
 		return compareNestedObject(actual, expected, config)
 	}
 }
+
+// isEmptyOrAbsent reports whether v is nil, a nil pointer, or an empty
+// slice, array or map
+func isEmptyOrAbsent(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return true
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len() == 0
+	default:
+		return false
+	}
+}
+
+// CompareValuesWithReason is CompareValues plus the reason code for a
+// difference: ReasonTypeMismatch when the values are of incompatible kinds
+// (e.g. a string and a list), otherwise ReasonValueChanged. The code is empty
+// when the values are equal.
+func CompareValuesWithReason(actual, expected interface{}, config AttributeConfig) (bool, string, interfaces.ReasonCode) {
+	equal, description := CompareValues(actual, expected, config)
+	if equal {
+		return true, description, ""
+	}
+	return false, description, differenceReason(actual, expected)
+}
+
+// differenceReason classifies a difference between two unequal values
+func differenceReason(actual, expected interface{}) interfaces.ReasonCode {
+	if actual == nil || expected == nil {
+		return interfaces.ReasonValueChanged
+	}
+	if kindCategory(reflect.ValueOf(actual)) != kindCategory(reflect.ValueOf(expected)) {
+		return interfaces.ReasonTypeMismatch
+	}
+	return interfaces.ReasonValueChanged
+}
+
+// kindCategory groups reflect kinds that CompareValues can compare with
+// each other, dereferencing pointers
+func kindCategory(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "nil"
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "list"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return v.Kind().String()
+	}
+}
@@ -1,7 +1,12 @@
 package drift
 
 import (
+	"fmt"
+	"math"
+	"strings"
 	"testing"
+
+	"firefly-task/pkg/interfaces"
 )
 
 func TestCompareString(t *testing.T) {
@@ -171,6 +176,140 @@ func TestCompareArray(t *testing.T) {
 	}
 }
 
+func TestCompareArray_OrderedDivergence(t *testing.T) {
+	config := AttributeConfig{ComparisonType: ArrayOrdered}
+
+	tests := []struct {
+		name     string
+		actual   []interface{}
+		expected []interface{}
+		wantDesc string
+	}{
+		{
+			name:     "mid-list change",
+			actual:   []interface{}{"a", "b", "x", "d"},
+			expected: []interface{}{"a", "b", "c", "d"},
+			wantDesc: "array element mismatch at index 2: x vs c",
+		},
+		{
+			name:     "missing tail",
+			actual:   []interface{}{"a", "b"},
+			expected: []interface{}{"a", "b", "c", "d"},
+			wantDesc: "array length mismatch: 2 vs 4, missing elements from index 2: [c d]",
+		},
+		{
+			name:     "extra tail",
+			actual:   []interface{}{"a", "b", "c"},
+			expected: []interface{}{"a", "b"},
+			wantDesc: "array length mismatch: 3 vs 2, extra elements from index 2: [c]",
+		},
+		{
+			name:     "change before length mismatch",
+			actual:   []interface{}{"x", "b"},
+			expected: []interface{}{"a", "b", "c"},
+			wantDesc: "array element mismatch at index 0: x vs a (length 2 vs 3)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, desc := compareArray(tt.actual, tt.expected, config)
+			if equal {
+				t.Fatalf("compareArray() = true, want false")
+			}
+			if desc != tt.wantDesc {
+				t.Errorf("compareArray() description = %q, want %q", desc, tt.wantDesc)
+			}
+		})
+	}
+}
+
+func TestCompareValues_ObjectArrays(t *testing.T) {
+	unordered := AttributeConfig{ComparisonType: ArrayUnordered}
+	ordered := AttributeConfig{ComparisonType: ArrayOrdered}
+
+	tests := []struct {
+		name      string
+		actual    interface{}
+		expected  interface{}
+		config    AttributeConfig
+		wantEqual bool
+	}{
+		{
+			name: "reordered tag blocks are equal",
+			actual: []map[string]interface{}{
+				{"key": "Name", "value": "web", "propagate_at_launch": true},
+				{"key": "Env", "value": "prod", "propagate_at_launch": false},
+			},
+			expected: []map[string]interface{}{
+				{"key": "Env", "value": "prod", "propagate_at_launch": false},
+				{"key": "Name", "value": "web", "propagate_at_launch": true},
+			},
+			config:    unordered,
+			wantEqual: true,
+		},
+		{
+			name: "different element map types are normalized",
+			actual: []map[string]string{
+				{"key": "Name", "value": "web"},
+			},
+			expected: []interface{}{
+				map[string]interface{}{"value": "web", "key": "Name"},
+			},
+			config:    unordered,
+			wantEqual: true,
+		},
+		{
+			name: "changed object is detected",
+			actual: []map[string]interface{}{
+				{"key": "Name", "value": "web"},
+				{"key": "Env", "value": "staging"},
+			},
+			expected: []map[string]interface{}{
+				{"key": "Env", "value": "prod"},
+				{"key": "Name", "value": "web"},
+			},
+			config:    unordered,
+			wantEqual: false,
+		},
+		{
+			name: "duplicate objects are counted",
+			actual: []map[string]interface{}{
+				{"key": "Name", "value": "web"},
+				{"key": "Name", "value": "web"},
+			},
+			expected: []map[string]interface{}{
+				{"key": "Name", "value": "web"},
+				{"key": "Env", "value": "prod"},
+			},
+			config:    unordered,
+			wantEqual: false,
+		},
+		{
+			name: "ordered comparison respects order",
+			actual: []map[string]interface{}{
+				{"key": "Env", "value": "prod"},
+				{"key": "Name", "value": "web"},
+			},
+			expected: []map[string]interface{}{
+				{"key": "Name", "value": "web"},
+				{"key": "Env", "value": "prod"},
+			},
+			config:    ordered,
+			wantEqual: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotEqual, description := CompareValues(tt.actual, tt.expected, tt.config)
+			if gotEqual != tt.wantEqual {
+				t.Errorf("CompareValues() = %v, want %v (%s)", gotEqual, tt.wantEqual, description)
+			}
+		})
+	}
+}
+
 func TestCompareMap(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -245,6 +384,368 @@ func TestCompareMap(t *testing.T) {
 	}
 }
 
+func TestCompareMap_CaseInsensitiveKeys(t *testing.T) {
+	config := AttributeConfig{ComparisonType: MapComparison, CaseInsensitiveKeys: true}
+
+	tests := []struct {
+		name      string
+		actual    map[string]interface{}
+		expected  map[string]interface{}
+		wantEqual bool
+		wantDesc  string
+	}{
+		{
+			name:      "keys differing only in case",
+			actual:    map[string]interface{}{"Name": "web", "Env": "prod"},
+			expected:  map[string]interface{}{"name": "web", "env": "prod"},
+			wantEqual: true,
+		},
+		{
+			name:      "value difference still detected",
+			actual:    map[string]interface{}{"Name": "web"},
+			expected:  map[string]interface{}{"name": "api"},
+			wantEqual: false,
+			wantDesc:  "map value mismatch for key 'name'",
+		},
+		{
+			name:      "keys folding to the same name",
+			actual:    map[string]interface{}{"Name": "web", "name": "api"},
+			expected:  map[string]interface{}{"name": "web"},
+			wantEqual: false,
+			wantDesc:  "'Name' and 'name' both match 'name'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotEqual, desc := compareMap(tt.actual, tt.expected, config)
+			if gotEqual != tt.wantEqual {
+				t.Errorf("compareMap() = %v, want %v (%s)", gotEqual, tt.wantEqual, desc)
+			}
+			if tt.wantDesc != "" && !strings.Contains(desc, tt.wantDesc) {
+				t.Errorf("Expected description to contain %q, got %q", tt.wantDesc, desc)
+			}
+		})
+	}
+
+	if equal, _ := compareMap(map[string]interface{}{"Name": "web"}, map[string]interface{}{"name": "web"}, AttributeConfig{ComparisonType: MapComparison}); equal {
+		t.Error("Expected key case to matter without CaseInsensitiveKeys")
+	}
+}
+
+func TestCompareValues_MapIgnoreKeys(t *testing.T) {
+	config := AttributeConfig{ComparisonType: MapComparison, IgnoreKeys: []string{"aws:*", "LastScanned"}}
+	expected := map[string]string{"Name": "web", "Env": "prod"}
+
+	actual := map[string]string{
+		"Name":                          "web",
+		"Env":                           "prod",
+		"aws:cloudformation:stack-name": "web-stack",
+		"LastScanned":                   "2024-05-01",
+	}
+	if equal, desc := CompareValues(actual, expected, config); !equal {
+		t.Errorf("Expected ignored keys not to cause drift, got %s", desc)
+	}
+
+	actual["Env"] = "staging"
+	equal, desc := CompareValues(actual, expected, config)
+	if equal {
+		t.Fatal("Expected a real tag change to still be detected")
+	}
+	if !strings.Contains(desc, "'Env'") {
+		t.Errorf("Expected description to name the changed key, got %q", desc)
+	}
+
+	// Without IgnoreKeys the managed tags are drift
+	actual["Env"] = "prod"
+	if equal, _ := CompareValues(actual, expected, AttributeConfig{ComparisonType: MapComparison}); equal {
+		t.Error("Expected managed tags to be drift without IgnoreKeys")
+	}
+	if _, ok := actual["aws:cloudformation:stack-name"]; !ok {
+		t.Error("Expected the input map not to be modified")
+	}
+}
+
+func largeTagMap(n int) map[string]interface{} {
+	m := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		m[fmt.Sprintf("tag-%05d", i)] = fmt.Sprintf("value-%d", i)
+	}
+	return m
+}
+
+func TestCompareMap_LargeMaps(t *testing.T) {
+	config := AttributeConfig{ComparisonType: MapComparison}
+
+	if equal, desc := compareMap(largeTagMap(5000), largeTagMap(5000), config); !equal {
+		t.Fatalf("Expected identical large maps to be equal, got %q", desc)
+	}
+
+	expected := largeTagMap(5000)
+	for i := 0; i < 25; i++ {
+		expected[fmt.Sprintf("tag-%05d", i)] = "changed"
+	}
+	equal, desc := compareMap(largeTagMap(5000), expected, config)
+	if equal {
+		t.Fatal("Expected large maps with changed values to differ")
+	}
+	if !strings.Contains(desc, "tag-00000") || strings.Contains(desc, "tag-00010") {
+		t.Errorf("Expected the first %d changed keys to be listed, got %q", maxReportedMapKeys, desc)
+	}
+	if !strings.Contains(desc, "(+15 more)") {
+		t.Errorf("Expected a +15 more note, got %q", desc)
+	}
+
+	delete(expected, "tag-04999")
+	if equal, desc := compareMap(largeTagMap(5000), expected, config); equal || !strings.Contains(desc, "5000 vs 4999 keys") {
+		t.Errorf("Expected a size mismatch to be reported, got %v %q", equal, desc)
+	}
+
+	// NaN never equals itself, on large maps as on small ones
+	withNaN := func() map[string]interface{} {
+		m := largeTagMap(5000)
+		m["ratio"] = math.NaN()
+		return m
+	}
+	if equal, _ := compareMap(withNaN(), withNaN(), config); equal {
+		t.Error("Expected a NaN value not to compare equal")
+	}
+}
+
+// BenchmarkCompareMap_Large measures the capped key-by-key diff of large tag
+// maps. Equal maps are scanned in full; a hash fast path was measured slower
+// than the scan and is not used.
+func BenchmarkCompareMap_Large(b *testing.B) {
+	config := AttributeConfig{ComparisonType: MapComparison}
+	actual := largeTagMap(5000)
+
+	b.Run("equal", func(b *testing.B) {
+		expected := largeTagMap(5000)
+		for i := 0; i < b.N; i++ {
+			compareMap(actual, expected, config)
+		}
+	})
+
+	b.Run("changed", func(b *testing.B) {
+		expected := largeTagMap(5000)
+		expected["tag-02500"] = "changed"
+		for i := 0; i < b.N; i++ {
+			compareMap(actual, expected, config)
+		}
+	})
+}
+
+func TestCompareValues_EmptyEqualsAbsent(t *testing.T) {
+	config := AttributeConfig{ComparisonType: MapComparison, EmptyEqualsAbsent: true}
+
+	var nilMap map[string]string
+	equalCases := []struct{ actual, expected interface{} }{
+		{nil, map[string]string{}},
+		{[]string{}, nil},
+		{nilMap, map[string]interface{}{}},
+	}
+	for _, tc := range equalCases {
+		if equal, desc := CompareValues(tc.actual, tc.expected, config); !equal {
+			t.Errorf("CompareValues(%#v, %#v) = false (%s), want true", tc.actual, tc.expected, desc)
+		}
+	}
+
+	if equal, _ := CompareValues(nil, map[string]string{}, AttributeConfig{ComparisonType: MapComparison}); equal {
+		t.Error("Expected nil and empty map to differ without EmptyEqualsAbsent")
+	}
+	if equal, _ := CompareValues(map[string]string{}, map[string]string{"Env": "prod"}, config); equal {
+		t.Error("Expected tags removed from a present attribute to be reported")
+	}
+	if equal, _ := CompareValues(nil, "", config); equal {
+		t.Error("Expected EmptyEqualsAbsent to leave empty strings alone")
+	}
+}
+
+func TestCompareValues_IPMatch(t *testing.T) {
+	config := AttributeConfig{ComparisonType: IPMatch, CaseSensitive: true}
+
+	tests := []struct {
+		name     string
+		actual   interface{}
+		expected interface{}
+		want     bool
+	}{
+		{"ipv4 leading zeros", "10.0.001.5", "10.0.1.5", true},
+		{"ipv6 casing", "2001:DB8::1", "2001:db8::1", true},
+		{"ipv6 expanded", "2001:0db8:0000:0000:0000:0000:0000:0001", "2001:db8::1", true},
+		{"ipv4 changed", "10.0.1.5", "10.0.1.6", false},
+		{"ipv6 changed", "2001:db8::1", "2001:db8::2", false},
+		{"unparseable equal", "pending", "pending", true},
+		{"unparseable differs", "pending", "10.0.1.5", false},
+		{"octet out of range", "10.0.1.256", "10.0.1.0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, desc := CompareValues(tt.actual, tt.expected, config); got != tt.want {
+				t.Errorf("CompareValues(%v, %v) = %v (%s), want %v", tt.actual, tt.expected, got, desc, tt.want)
+			}
+		})
+	}
+
+	defaults := DefaultDetectionConfig()
+	for _, attr := range []string{"public_ip", "private_ip"} {
+		if got := defaults.AttributeConfigs[attr].ComparisonType; got != IPMatch {
+			t.Errorf("Expected default %s comparison to be %s, got %s", attr, IPMatch, got)
+		}
+	}
+}
+
+func TestCompareValues_ComparisonChain(t *testing.T) {
+	config := DefaultDetectionConfig().AttributeConfigs["engine_version"]
+	if len(config.ComparisonChain) == 0 {
+		t.Fatal("Expected engine_version to use a comparison chain by default")
+	}
+
+	if equal, _ := CompareValues("v1.2.3", "1.2.3", AttributeConfig{ComparisonType: ExactMatch, CaseSensitive: true}); equal {
+		t.Fatal("Expected exact comparison alone to report v1.2.3 and 1.2.3 as different")
+	}
+
+	equal, desc := CompareValues("v1.2.3", "1.2.3", config)
+	if !equal {
+		t.Fatalf("Expected the chain to match v1.2.3 and 1.2.3, got %s", desc)
+	}
+	if !strings.HasPrefix(desc, "matched by semver comparison") {
+		t.Errorf("Expected the description to name the semver comparator, got %q", desc)
+	}
+
+	equal, desc = CompareValues("1.2.3", "1.3.0", config)
+	if equal {
+		t.Fatal("Expected a genuine version change to drift")
+	}
+	if !strings.Contains(desc, "no comparison in chain semver,exact matched") {
+		t.Errorf("Unexpected description for a failed chain: %q", desc)
+	}
+
+	for _, tc := range []struct{ actual, expected string }{
+		{"1.2", "1.2.0"},
+		{"1.2.3+build.5", "1.2.3"},
+		{"1.2.3-rc.1", "v1.2.3-rc.1"},
+	} {
+		if equal, desc := CompareValues(tc.actual, tc.expected, AttributeConfig{ComparisonType: SemverMatch}); !equal {
+			t.Errorf("Expected %s and %s to be equal versions, got %s", tc.actual, tc.expected, desc)
+		}
+	}
+}
+
+func TestCompareValues_SemverConstraint(t *testing.T) {
+	if equal, desc := CompareValues("14.07", "14.7", AttributeConfig{ComparisonType: SemverMatch}); !equal {
+		t.Errorf("Expected 14.07 and 14.7 to be equal versions, got %s", desc)
+	}
+
+	config := AttributeConfig{ComparisonType: SemverMatch, VersionConstraint: ">=14.0, <15"}
+	tests := []struct {
+		actual string
+		want   bool
+	}{
+		{"14.0", true},
+		{"14.10.2", true},
+		{"v14.7", true},
+		{"13.9", false},
+		{"15.0.0", false},
+		{"15.0.0-rc.1", true},
+		{"14.0.0-beta", false},
+	}
+	for _, tt := range tests {
+		if equal, desc := CompareValues(tt.actual, "14.7", config); equal != tt.want {
+			t.Errorf("CompareValues(%s) with %s = %v (%s), want %v", tt.actual, config.VersionConstraint, equal, desc, tt.want)
+		}
+	}
+
+	equal, desc := CompareValues("fourteen", "14.7", config)
+	if equal {
+		t.Error("Expected a malformed version not to satisfy the constraint")
+	}
+	if !strings.Contains(desc, "not a valid version") {
+		t.Errorf("Expected the description to report the malformed version, got %q", desc)
+	}
+
+	// Without a constraint, malformed versions fall back to string comparison
+	if equal, _ := CompareValues("latest", "latest", AttributeConfig{ComparisonType: SemverMatch, CaseSensitive: true}); !equal {
+		t.Error("Expected identical non-version strings to be equal")
+	}
+	if equal, _ := CompareValues("latest", "stable", AttributeConfig{ComparisonType: SemverMatch, CaseSensitive: true}); equal {
+		t.Error("Expected different non-version strings to drift")
+	}
+}
+
+func TestCompareValues_RegexMatch(t *testing.T) {
+	config := AttributeConfig{ComparisonType: RegexMatch, Pattern: `^app-logs-[a-z0-9]{8}$`}
+
+	if equal, desc := CompareValues("app-logs-3f9a1c2b", "app-logs", config); !equal {
+		t.Errorf("Expected a generated suffix to match the pattern, got %s", desc)
+	}
+	if equal, _ := CompareValues("app-logs-backup", "app-logs", config); equal {
+		t.Error("Expected a value outside the pattern to drift")
+	}
+	if equal, _ := CompareValues("app-logs-3f9a1c2b", nil, config); equal {
+		t.Error("Expected a missing expected value to drift")
+	}
+
+	invalid := AttributeConfig{ComparisonType: RegexMatch, Pattern: `app-logs-[`}
+	equal, desc := CompareValues("app-logs-3f9a1c2b", "app-logs", invalid)
+	if equal {
+		t.Error("Expected an invalid pattern never to match")
+	}
+	if !strings.Contains(desc, "invalid regex pattern") {
+		t.Errorf("Expected the description to report the invalid pattern, got %q", desc)
+	}
+
+	// Round-trips through the config file format
+	file := AttributeConfigFileFromConfig(config)
+	if file.ComparisonType != "regex_match" || file.Pattern != config.Pattern {
+		t.Errorf("Unexpected config file form: %+v", file)
+	}
+	if roundTripped := file.ToAttributeConfig(); roundTripped.ComparisonType != RegexMatch || roundTripped.Pattern != config.Pattern {
+		t.Errorf("Unexpected round-tripped config: %+v", roundTripped)
+	}
+}
+
+func TestCompareValues_DepthLimit(t *testing.T) {
+	nested := func(levels int, leaf string) map[string]interface{} {
+		m := map[string]interface{}{"value": leaf}
+		for i := 1; i < levels; i++ {
+			m = map[string]interface{}{"child": m}
+		}
+		return m
+	}
+
+	config := AttributeConfig{ComparisonType: MapComparison}
+	equal, desc := CompareValues(nested(DefaultMaxCompareDepth+10, "a"), nested(DefaultMaxCompareDepth+10, "a"), config)
+	if equal {
+		t.Fatal("Expected values past the depth limit to be reported as different")
+	}
+	if !strings.Contains(desc, "comparison depth exceeded") {
+		t.Errorf("Expected a depth exceeded description, got %q", desc)
+	}
+
+	if equal, desc := CompareValues(nested(8, "a"), nested(8, "a"), config); !equal {
+		t.Errorf("Expected shallow maps to compare normally, got %s", desc)
+	}
+	if equal, _ := CompareValues(nested(8, "a"), nested(8, "b"), config); equal {
+		t.Error("Expected a change in a shallow nested map to be detected")
+	}
+
+	config.MaxDepth = 4
+	if _, desc := CompareValues(nested(8, "a"), nested(8, "a"), config); !strings.Contains(desc, "more than 4 levels") {
+		t.Errorf("Expected the configured depth limit to apply, got %q", desc)
+	}
+
+	type node struct {
+		Next *node
+	}
+	cyclic := &node{}
+	cyclic.Next = cyclic
+	if equal, desc := CompareValues(cyclic, cyclic, AttributeConfig{ComparisonType: NestedObject}); equal || !strings.Contains(desc, "comparison depth exceeded") {
+		t.Errorf("Expected a cyclic structure to stop at the depth limit, got %v (%s)", equal, desc)
+	}
+}
+
 func TestCompareNestedObject(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -373,6 +874,51 @@ func TestConvertToString(t *testing.T) {
 	}
 }
 
+func TestConvertToStringWithPrecision(t *testing.T) {
+	two := 2
+	zero := 0
+
+	tests := []struct {
+		name      string
+		value     interface{}
+		precision *int
+		want      string
+	}{
+		{"no precision keeps %v", 3.140000001, nil, "3.140000001"},
+		{"rounds float64", 3.140000001, &two, "3.14"},
+		{"rounds float32", float32(3.14), &two, "3.14"},
+		{"drops trailing zeros", 2.5, &two, "2.5"},
+		{"zero decimals", 2.71828, &zero, "3"},
+		{"non-float untouched", 42, &two, "42"},
+		{"string untouched", "3.140000001", &two, "3.140000001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := convertToStringWithPrecision(tt.value, tt.precision); got != tt.want {
+				t.Errorf("convertToStringWithPrecision() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareValues_FloatPrecision(t *testing.T) {
+	// Mismatched types fall back to string comparison
+	actual := 3.140000001
+	expected := float32(3.14)
+
+	config := AttributeConfig{ComparisonType: ExactMatch, CaseSensitive: true}
+	if equal, _ := CompareValues(actual, expected, config); equal {
+		t.Error("Expected drift without a configured precision")
+	}
+
+	config.FloatPrecision = new(int)
+	*config.FloatPrecision = 2
+	if equal, description := CompareValues(actual, expected, config); !equal {
+		t.Errorf("Expected values to match at 2 decimals: %s", description)
+	}
+}
+
 func TestConvertToSlice(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -572,3 +1118,60 @@ func mapsEqual(a, b map[string]interface{}) bool {
 	}
 	return true
 }
+
+func TestCompareValuesWithReason(t *testing.T) {
+	config := AttributeConfig{ComparisonType: ExactMatch, CaseSensitive: true}
+	tests := []struct {
+		name     string
+		actual   interface{}
+		expected interface{}
+		want     interfaces.ReasonCode
+	}{
+		{"equal", "a", "a", ""},
+		{"changed string", "a", "b", interfaces.ReasonValueChanged},
+		{"changed number", 1, 2.5, interfaces.ReasonValueChanged},
+		{"list vs scalar", []string{"a"}, "a,b", interfaces.ReasonTypeMismatch},
+		{"map vs string", map[string]string{"a": "b"}, "a", interfaces.ReasonTypeMismatch},
+		{"nil vs value", nil, "a", interfaces.ReasonValueChanged},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, _, reason := CompareValuesWithReason(tt.actual, tt.expected, config)
+			if equal != (tt.want == "") {
+				t.Errorf("CompareValuesWithReason() equal = %v", equal)
+			}
+			if reason != tt.want {
+				t.Errorf("CompareValuesWithReason() reason = %q, want %q", reason, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareArray_ReportSetDeltaKeepsEquality(t *testing.T) {
+	tests := []struct {
+		name     string
+		actual   []interface{}
+		expected []interface{}
+	}{
+		{"scalars differing only in type", []interface{}{"1", 2}, []interface{}{1, "2"}},
+		{"reordered scalars", []interface{}{"a", "b"}, []interface{}{"b", "a"}},
+		{"different scalars", []interface{}{"a", "b"}, []interface{}{"a", "c"}},
+		{"object arrays differing in value type", []interface{}{map[string]interface{}{"port": "80"}}, []interface{}{map[string]interface{}{"port": 80}}},
+		{"reordered object arrays", []interface{}{map[string]interface{}{"port": 80}, map[string]interface{}{"port": 443}}, []interface{}{map[string]interface{}{"port": 443}, map[string]interface{}{"port": 80}}},
+		{"different lengths", []interface{}{"a"}, []interface{}{"a", "a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plain, _ := compareArray(tt.actual, tt.expected, AttributeConfig{ComparisonType: ArrayUnordered})
+			reported, _ := compareArray(tt.actual, tt.expected, AttributeConfig{ComparisonType: ArrayUnordered, ReportSetDelta: true})
+			if plain != reported {
+				t.Errorf("ReportSetDelta changed equality: %v without, %v with", plain, reported)
+			}
+			delta := computeSetDelta(tt.actual, tt.expected)
+			if empty := len(delta.Added) == 0 && len(delta.Removed) == 0; empty != plain {
+				t.Errorf("Set delta %+v disagrees with equality %v", delta, plain)
+			}
+		})
+	}
+}
@@ -32,7 +32,7 @@ func NewConcreteDriftDetector(logger *logrus.Logger) interfaces.DriftDetector {
 	}
 
 	// Create a default detection config
-	config := DetectionConfig{}
+	config := DetectionConfig{FailOnResourceTypeMismatch: true}
 	detector := NewDriftDetector(config)
 	return &ConcreteDriftDetector{
 		detector: detector,
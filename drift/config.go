@@ -5,8 +5,16 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+
+	"firefly-task/pkg/interfaces"
+	"firefly-task/pkg/logging"
 )
 
 // ConfigManager handles loading and saving drift detection configurations
@@ -21,25 +29,154 @@ func NewConfigManager(configPath string) *ConfigManager {
 	}
 }
 
-// LoadConfig loads configuration from file or returns default if file doesn't exist
+// ConfigSource identifies where an effective configuration value came from
+type ConfigSource string
+
+const (
+	// SourceDefault marks a value taken from DefaultDetectionConfig
+	SourceDefault ConfigSource = "default"
+	// SourceFile marks a value read from the configuration file
+	SourceFile ConfigSource = "file"
+	// SourceEnv marks a value overridden by an environment variable
+	SourceEnv ConfigSource = "env"
+)
+
+// Environment variables that override the configuration file and defaults
+const (
+	EnvMaxConcurrency = "FIREFLY_DRIFT_MAX_CONCURRENCY"
+	EnvTimeoutSeconds = "FIREFLY_DRIFT_TIMEOUT_SECONDS"
+	EnvStrictMode     = "FIREFLY_DRIFT_STRICT_MODE"
+)
+
+// EffectiveConfig is the fully merged detection configuration along with the
+// source of each top-level setting, keyed by its JSON name
+type EffectiveConfig struct {
+	Config  DetectionConfigFile     `json:"config"`
+	Sources map[string]ConfigSource `json:"sources"`
+}
+
+// LoadConfig loads configuration from file or returns default if file doesn't
+// exist. Environment overrides are applied on top of either.
 func (cm *ConfigManager) LoadConfig() (DetectionConfig, error) {
+	config, _, err := cm.load()
+	return config, err
+}
+
+// EffectiveConfig returns the configuration LoadConfig would produce together
+// with where each setting came from
+func (cm *ConfigManager) EffectiveConfig() (*EffectiveConfig, error) {
+	config, sources, err := cm.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return &EffectiveConfig{
+		Config:  DetectionConfigFileFromConfig(config),
+		Sources: sources,
+	}, nil
+}
+
+// load reads the configuration and records the source of each setting
+func (cm *ConfigManager) load() (DetectionConfig, map[string]ConfigSource, error) {
+	var config DetectionConfig
+	source := SourceDefault
+
 	if _, err := os.Stat(cm.configPath); os.IsNotExist(err) {
-		// Return default config if file doesn't exist
-		return DefaultDetectionConfig(), nil
+		// Use default config if file doesn't exist
+		config = DefaultDetectionConfig()
+	} else {
+		data, err := ioutil.ReadFile(cm.configPath)
+		if err != nil {
+			return DetectionConfig{}, nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+
+		var configFile DetectionConfigFile
+		err = json.Unmarshal(data, &configFile)
+		if err != nil {
+			return DetectionConfig{}, nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+		config = configFile.ToDetectionConfig()
+		source = SourceFile
 	}
 
-	data, err := ioutil.ReadFile(cm.configPath)
+	sources, err := configKeys(config)
 	if err != nil {
-		return DetectionConfig{}, fmt.Errorf("failed to read config file: %w", err)
+		return DetectionConfig{}, nil, err
+	}
+	for key := range sources {
+		sources[key] = source
 	}
 
-	var config DetectionConfigFile
-	err = json.Unmarshal(data, &config)
+	overridden, err := applyEnvOverrides(&config)
 	if err != nil {
-		return DetectionConfig{}, fmt.Errorf("failed to parse config file: %w", err)
+		return DetectionConfig{}, nil, err
+	}
+	for _, key := range overridden {
+		sources[key] = SourceEnv
+	}
+
+	return config, sources, nil
+}
+
+// configKeys returns the top-level JSON keys of the serialized configuration
+func configKeys(config DetectionConfig) (map[string]ConfigSource, error) {
+	data, err := json.Marshal(DetectionConfigFileFromConfig(config))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to inspect config: %w", err)
 	}
 
-	return config.ToDetectionConfig(), nil
+	keys := make(map[string]ConfigSource, len(raw))
+	for key := range raw {
+		keys[key] = ""
+	}
+	return keys, nil
+}
+
+// applyEnvOverrides applies the FIREFLY_DRIFT_* environment variables and
+// returns the JSON keys of the settings they changed
+func applyEnvOverrides(config *DetectionConfig) ([]string, error) {
+	var overridden []string
+	record := func(variable, key string, value interface{}) {
+		logging.Info("Applied environment override to drift config",
+			"variable", variable,
+			"setting", key,
+			"value", value)
+		overridden = append(overridden, key)
+	}
+
+	if value := os.Getenv(EnvMaxConcurrency); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", EnvMaxConcurrency, value, err)
+		}
+		config.MaxConcurrency = n
+		record(EnvMaxConcurrency, "max_concurrency", n)
+	}
+
+	if value := os.Getenv(EnvTimeoutSeconds); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", EnvTimeoutSeconds, value, err)
+		}
+		config.Timeout = time.Duration(n) * time.Second
+		record(EnvTimeoutSeconds, "timeout_seconds", n)
+	}
+
+	if value := os.Getenv(EnvStrictMode); value != "" {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", EnvStrictMode, value, err)
+		}
+		config.StrictMode = b
+		record(EnvStrictMode, "strict_mode", b)
+	}
+
+	return overridden, nil
 }
 
 // SaveConfig saves configuration to file
@@ -66,20 +203,54 @@ func (cm *ConfigManager) SaveConfig(config DetectionConfig) error {
 
 // DetectionConfigFile represents the JSON structure for configuration files
 type DetectionConfigFile struct {
-	AttributeConfigs  map[string]AttributeConfigFile `json:"attribute_configs"`
-	DefaultConfig     AttributeConfigFile            `json:"default_config"`
-	IgnoredAttributes []string                       `json:"ignored_attributes"`
-	StrictMode        bool                           `json:"strict_mode"`
-	MaxConcurrency    int                            `json:"max_concurrency"`
-	TimeoutSeconds    int                            `json:"timeout_seconds"`
-	Extensions        ExtensionConfig                `json:"extensions,omitempty"`
+	AttributeConfigs   map[string]AttributeConfigFile `json:"attribute_configs"`
+	DefaultConfig      AttributeConfigFile            `json:"default_config"`
+	IgnoredAttributes  []string                       `json:"ignored_attributes"`
+	StrictMode         bool                           `json:"strict_mode"`
+	MaxConcurrency     int                            `json:"max_concurrency"`
+	TimeoutSeconds     int                            `json:"timeout_seconds"`
+	FailOnUnknownType  bool                           `json:"fail_on_unknown_resource_type,omitempty"`
+	SeverityFloors     []SeverityFloorRule            `json:"severity_floor_rules,omitempty"`
+	TraceComparisons   bool                           `json:"trace_comparisons,omitempty"`
+	CompareSGRules     bool                           `json:"compare_security_group_rules,omitempty"`
+	CollectStats       bool                           `json:"collect_stats,omitempty"`
+	FloatPrecision     *int                           `json:"float_precision,omitempty"`
+	AttributeAliases   map[string][]string            `json:"attribute_aliases,omitempty"`
+	FailOnTypeMismatch *bool                          `json:"fail_on_resource_type_mismatch,omitempty"`
+	ARNNormalization   *ARNNormalization              `json:"arn_normalization,omitempty"`
+	ValidateResults    bool                           `json:"validate_results,omitempty"`
+	DeterministicBatch bool                           `json:"deterministic_batch,omitempty"`
+	ResourceTypes      map[string]ResourceTypeFile    `json:"resource_type_configs,omitempty"`
+	EmptyEqualsAbsent  bool                           `json:"empty_equals_absent,omitempty"`
+	OnlyAttributes     []string                       `json:"only_attributes,omitempty"`
+	IntersectionOnly   bool                           `json:"intersection_only,omitempty"`
+	IncludeMatches     bool                           `json:"include_matches,omitempty"`
+	SGRuleDetails      bool                           `json:"security_group_rule_details,omitempty"`
+	StrictModeDetails  bool                           `json:"strict_mode_details,omitempty"`
+	Extensions         ExtensionConfig                `json:"extensions,omitempty"`
 }
 
 // AttributeConfigFile represents the JSON structure for attribute configurations
 type AttributeConfigFile struct {
-	ComparisonType string   `json:"comparison_type"`
-	CaseSensitive  bool     `json:"case_sensitive"`
-	Tolerance      *float64 `json:"tolerance,omitempty"`
+	ComparisonType      string   `json:"comparison_type"`
+	CaseSensitive       bool     `json:"case_sensitive"`
+	Tolerance           *float64 `json:"tolerance,omitempty"`
+	FloatPrecision      *int     `json:"float_precision,omitempty"`
+	CaseInsensitiveKeys bool     `json:"case_insensitive_keys,omitempty"`
+	KeyField            string   `json:"key_field,omitempty"`
+	EmptyEqualsAbsent   bool     `json:"empty_equals_absent,omitempty"`
+	ComparisonChain     []string `json:"comparison_chain,omitempty"`
+	MaxDepth            int      `json:"max_depth,omitempty"`
+	ReportSetDelta      bool     `json:"report_set_delta,omitempty"`
+	Pattern             string   `json:"pattern,omitempty"`
+	VersionConstraint   string   `json:"version_constraint,omitempty"`
+	IgnoreKeys          []string `json:"ignore_keys,omitempty"`
+}
+
+// ResourceTypeFile represents the JSON structure for per-resource-type settings
+type ResourceTypeFile struct {
+	AttributeConfigs map[string]AttributeConfigFile `json:"attribute_configs,omitempty"`
+	DefaultConfig    *AttributeConfigFile           `json:"default_config,omitempty"`
 }
 
 // ExtensionConfig holds configuration for extending drift detection
@@ -115,24 +286,81 @@ func (dcf DetectionConfigFile) ToDetectionConfig() DetectionConfig {
 		timeout = 30 * time.Second
 	}
 
-	return DetectionConfig{
+	config := DetectionConfig{
 		AttributeConfigs:  attributeConfigs,
 		DefaultConfig:     dcf.DefaultConfig.ToAttributeConfig(),
 		IgnoredAttributes: dcf.IgnoredAttributes,
 		StrictMode:        dcf.StrictMode,
 		MaxConcurrency:    dcf.MaxConcurrency,
 		Timeout:           timeout,
+
+		FailOnUnknownResourceType:  dcf.FailOnUnknownType,
+		SeverityFloorRules:         dcf.SeverityFloors,
+		TraceComparisons:           dcf.TraceComparisons,
+		CompareSecurityGroupRules:  dcf.CompareSGRules,
+		CollectStats:               dcf.CollectStats,
+		FloatPrecision:             dcf.FloatPrecision,
+		AttributeAliases:           dcf.AttributeAliases,
+		FailOnResourceTypeMismatch: dcf.FailOnTypeMismatch == nil || *dcf.FailOnTypeMismatch,
+		ValidateResults:            dcf.ValidateResults,
+		DeterministicBatch:         dcf.DeterministicBatch,
+		EmptyEqualsAbsent:          dcf.EmptyEqualsAbsent,
+		OnlyAttributes:             dcf.OnlyAttributes,
+		IntersectionOnly:           dcf.IntersectionOnly,
+		IncludeMatches:             dcf.IncludeMatches,
+		SecurityGroupRuleDetails:   dcf.SGRuleDetails,
+		StrictModeDetails:          dcf.StrictModeDetails,
+	}
+	if dcf.ARNNormalization != nil {
+		config.ARNNormalization = *dcf.ARNNormalization
+	}
+	if len(dcf.ResourceTypes) > 0 {
+		config.ResourceTypeConfigs = make(map[string]ResourceTypeConfig, len(dcf.ResourceTypes))
+		for resourceType, typeFile := range dcf.ResourceTypes {
+			var typeConfig ResourceTypeConfig
+			if len(typeFile.AttributeConfigs) > 0 {
+				typeConfig.AttributeConfigs = make(map[string]AttributeConfig, len(typeFile.AttributeConfigs))
+				for name, attrFile := range typeFile.AttributeConfigs {
+					typeConfig.AttributeConfigs[name] = attrFile.ToAttributeConfig()
+				}
+			}
+			if typeFile.DefaultConfig != nil {
+				defaultConfig := typeFile.DefaultConfig.ToAttributeConfig()
+				typeConfig.DefaultConfig = &defaultConfig
+			}
+			config.ResourceTypeConfigs[resourceType] = typeConfig
+		}
 	}
+	if len(dcf.Extensions.SeverityRules) > 0 {
+		config.SeverityOverrides = make(map[string]interfaces.SeverityLevel, len(dcf.Extensions.SeverityRules))
+		for attr, severity := range dcf.Extensions.SeverityRules {
+			config.SeverityOverrides[attr] = interfaces.SeverityLevel(strings.ToLower(severity))
+		}
+	}
+	return config
 }
 
 // ToAttributeConfig converts AttributeConfigFile to AttributeConfig
 func (acf AttributeConfigFile) ToAttributeConfig() AttributeConfig {
 	comparisonType := parseComparisonType(acf.ComparisonType)
-	return AttributeConfig{
-		ComparisonType: comparisonType,
-		CaseSensitive:  acf.CaseSensitive,
-		Tolerance:      acf.Tolerance,
+	config := AttributeConfig{
+		ComparisonType:      comparisonType,
+		CaseSensitive:       acf.CaseSensitive,
+		Tolerance:           acf.Tolerance,
+		FloatPrecision:      acf.FloatPrecision,
+		CaseInsensitiveKeys: acf.CaseInsensitiveKeys,
+		KeyField:            acf.KeyField,
+		EmptyEqualsAbsent:   acf.EmptyEqualsAbsent,
+		MaxDepth:            acf.MaxDepth,
+		ReportSetDelta:      acf.ReportSetDelta,
+		Pattern:             acf.Pattern,
+		VersionConstraint:   acf.VersionConstraint,
+		IgnoreKeys:          acf.IgnoreKeys,
 	}
+	for _, name := range acf.ComparisonChain {
+		config.ComparisonChain = append(config.ComparisonChain, parseComparisonType(name))
+	}
+	return config
 }
 
 // DetectionConfigFileFromConfig converts DetectionConfig to DetectionConfigFile
@@ -147,23 +375,81 @@ func DetectionConfigFileFromConfig(config DetectionConfig) DetectionConfigFile {
 		timeoutSeconds = 30
 	}
 
-	return DetectionConfigFile{
-		AttributeConfigs:  attributeConfigs,
-		DefaultConfig:     AttributeConfigFileFromConfig(config.DefaultConfig),
-		IgnoredAttributes: config.IgnoredAttributes,
-		StrictMode:        config.StrictMode,
-		MaxConcurrency:    config.MaxConcurrency,
-		TimeoutSeconds:    timeoutSeconds,
+	failOnTypeMismatch := config.FailOnResourceTypeMismatch
+	file := DetectionConfigFile{
+		AttributeConfigs:   attributeConfigs,
+		DefaultConfig:      AttributeConfigFileFromConfig(config.DefaultConfig),
+		IgnoredAttributes:  config.IgnoredAttributes,
+		StrictMode:         config.StrictMode,
+		MaxConcurrency:     config.MaxConcurrency,
+		TimeoutSeconds:     timeoutSeconds,
+		FailOnUnknownType:  config.FailOnUnknownResourceType,
+		SeverityFloors:     config.SeverityFloorRules,
+		TraceComparisons:   config.TraceComparisons,
+		CompareSGRules:     config.CompareSecurityGroupRules,
+		CollectStats:       config.CollectStats,
+		FloatPrecision:     config.FloatPrecision,
+		AttributeAliases:   config.AttributeAliases,
+		FailOnTypeMismatch: &failOnTypeMismatch,
+		ValidateResults:    config.ValidateResults,
+		DeterministicBatch: config.DeterministicBatch,
+		EmptyEqualsAbsent:  config.EmptyEqualsAbsent,
+		OnlyAttributes:     config.OnlyAttributes,
+		IntersectionOnly:   config.IntersectionOnly,
+		IncludeMatches:     config.IncludeMatches,
+		SGRuleDetails:      config.SecurityGroupRuleDetails,
+		StrictModeDetails:  config.StrictModeDetails,
 	}
+	if config.ARNNormalization.Enabled() {
+		normalization := config.ARNNormalization
+		file.ARNNormalization = &normalization
+	}
+	if len(config.ResourceTypeConfigs) > 0 {
+		file.ResourceTypes = make(map[string]ResourceTypeFile, len(config.ResourceTypeConfigs))
+		for resourceType, typeConfig := range config.ResourceTypeConfigs {
+			var typeFile ResourceTypeFile
+			if len(typeConfig.AttributeConfigs) > 0 {
+				typeFile.AttributeConfigs = make(map[string]AttributeConfigFile, len(typeConfig.AttributeConfigs))
+				for name, attrConfig := range typeConfig.AttributeConfigs {
+					typeFile.AttributeConfigs[name] = AttributeConfigFileFromConfig(attrConfig)
+				}
+			}
+			if typeConfig.DefaultConfig != nil {
+				defaultConfig := AttributeConfigFileFromConfig(*typeConfig.DefaultConfig)
+				typeFile.DefaultConfig = &defaultConfig
+			}
+			file.ResourceTypes[resourceType] = typeFile
+		}
+	}
+	if len(config.SeverityOverrides) > 0 {
+		file.Extensions.SeverityRules = make(map[string]string, len(config.SeverityOverrides))
+		for attr, severity := range config.SeverityOverrides {
+			file.Extensions.SeverityRules[attr] = string(severity)
+		}
+	}
+	return file
 }
 
 // AttributeConfigFileFromConfig converts AttributeConfig to AttributeConfigFile
 func AttributeConfigFileFromConfig(config AttributeConfig) AttributeConfigFile {
-	return AttributeConfigFile{
-		ComparisonType: comparisonTypeToString(config.ComparisonType),
-		CaseSensitive:  config.CaseSensitive,
-		Tolerance:      config.Tolerance,
+	file := AttributeConfigFile{
+		ComparisonType:      comparisonTypeToString(config.ComparisonType),
+		CaseSensitive:       config.CaseSensitive,
+		Tolerance:           config.Tolerance,
+		FloatPrecision:      config.FloatPrecision,
+		CaseInsensitiveKeys: config.CaseInsensitiveKeys,
+		KeyField:            config.KeyField,
+		EmptyEqualsAbsent:   config.EmptyEqualsAbsent,
+		MaxDepth:            config.MaxDepth,
+		ReportSetDelta:      config.ReportSetDelta,
+		Pattern:             config.Pattern,
+		VersionConstraint:   config.VersionConstraint,
+		IgnoreKeys:          config.IgnoreKeys,
+	}
+	for _, ct := range config.ComparisonChain {
+		file.ComparisonChain = append(file.ComparisonChain, comparisonTypeToString(ct))
 	}
+	return file
 }
 
 // parseComparisonType converts string to ComparisonType
@@ -183,6 +469,16 @@ func parseComparisonType(s string) ComparisonType {
 		return MapComparison
 	case "nested_object":
 		return NestedObject
+	case "json_semantic":
+		return JSONSemanticMatch
+	case "keyed_object_array":
+		return KeyedObjectArray
+	case "ip_match":
+		return IPMatch
+	case "semver_match":
+		return SemverMatch
+	case "regex_match":
+		return RegexMatch
 	default:
 		return ExactMatch
 	}
@@ -205,6 +501,16 @@ func comparisonTypeToString(ct ComparisonType) string {
 		return "map_comparison"
 	case NestedObject:
 		return "nested_object"
+	case JSONSemanticMatch:
+		return "json_semantic"
+	case KeyedObjectArray:
+		return "keyed_object_array"
+	case IPMatch:
+		return "ip_match"
+	case SemverMatch:
+		return "semver_match"
+	case RegexMatch:
+		return "regex_match"
 	default:
 		return "exact_match"
 	}
@@ -243,11 +549,71 @@ func (cv *ConfigValidator) ValidateConfig(config DetectionConfig) error {
 		}
 	}
 
+	for _, attr := range config.IgnoredAttributes {
+		if strings.TrimSpace(attr) == "" {
+			return fmt.Errorf("ignored_attributes cannot contain an empty entry")
+		}
+	}
+
+	for _, rule := range config.SeverityFloorRules {
+		if _, err := path.Match(rule.ResourcePattern, ""); err != nil {
+			return fmt.Errorf("invalid severity floor pattern '%s': %w", rule.ResourcePattern, err)
+		}
+		if severityValue(rule.MinSeverity) == 0 {
+			return fmt.Errorf("invalid severity floor '%s' for pattern '%s'", rule.MinSeverity, rule.ResourcePattern)
+		}
+	}
+
+	if err := validateAttributeAliases(config.AttributeAliases); err != nil {
+		return err
+	}
+
+	for attrName, severity := range config.SeverityOverrides {
+		if severityValue(severity) == 0 {
+			return fmt.Errorf("invalid severity override '%s' for attribute '%s'", severity, attrName)
+		}
+	}
+
 	// Validate default configuration
 	if err := cv.validateAttributeConfig("default", config.DefaultConfig); err != nil {
 		return fmt.Errorf("invalid default config: %w", err)
 	}
 
+	for resourceType, typeConfig := range config.ResourceTypeConfigs {
+		for attrName, attrConfig := range typeConfig.AttributeConfigs {
+			if err := cv.validateAttributeConfig(attrName, attrConfig); err != nil {
+				return fmt.Errorf("invalid config for attribute '%s' of resource type '%s': %w", attrName, resourceType, err)
+			}
+		}
+		if typeConfig.DefaultConfig == nil {
+			continue
+		}
+		if err := cv.validateAttributeConfig("default", *typeConfig.DefaultConfig); err != nil {
+			return fmt.Errorf("invalid default config for resource type '%s': %w", resourceType, err)
+		}
+	}
+
+	return nil
+}
+
+// validateAttributeAliases rejects an alias listed under more than one
+// canonical attribute name
+func validateAttributeAliases(aliases map[string][]string) error {
+	canonicals := make([]string, 0, len(aliases))
+	for canonical := range aliases {
+		canonicals = append(canonicals, canonical)
+	}
+	sort.Strings(canonicals)
+
+	owners := make(map[string]string)
+	for _, canonical := range canonicals {
+		for _, alias := range aliases[canonical] {
+			if owner, exists := owners[alias]; exists && owner != canonical {
+				return fmt.Errorf("attribute alias '%s' is listed under both '%s' and '%s'", alias, owner, canonical)
+			}
+			owners[alias] = canonical
+		}
+	}
 	return nil
 }
 
@@ -257,19 +623,61 @@ func (cv *ConfigValidator) validateAttributeConfig(attrName string, config Attri
 	validTypes := []ComparisonType{
 		ExactMatch, FuzzyMatch, NumericTolerance,
 		ArrayOrdered, ArrayUnordered, MapComparison, NestedObject,
+		JSONSemanticMatch, KeyedObjectArray, IPMatch, SemverMatch,
+		RegexMatch,
 	}
-
-	validType := false
-	for _, validT := range validTypes {
-		if config.ComparisonType == validT {
-			validType = true
-			break
+	isValid := func(ct ComparisonType) bool {
+		for _, validT := range validTypes {
+			if ct == validT {
+				return true
+			}
 		}
+		return false
 	}
 
-	if !validType {
+	if !isValid(config.ComparisonType) {
 		return fmt.Errorf("invalid comparison type: %v", config.ComparisonType)
 	}
+	for _, ct := range config.ComparisonChain {
+		if !isValid(ct) {
+			return fmt.Errorf("invalid comparison type in comparison chain: %v", ct)
+		}
+		if ct == KeyedObjectArray && config.KeyField == "" {
+			return fmt.Errorf("key_field is required for keyed_object_array comparison")
+		}
+		if ct == NumericTolerance && config.Tolerance == nil {
+			return fmt.Errorf("tolerance is required for numeric_tolerance comparison")
+		}
+		if ct == RegexMatch {
+			if err := validatePattern(config.Pattern); err != nil {
+				return err
+			}
+		}
+	}
+
+	if config.FloatPrecision != nil && (*config.FloatPrecision < 0 || *config.FloatPrecision > 15) {
+		return fmt.Errorf("float precision must be between 0 and 15, got %d", *config.FloatPrecision)
+	}
+
+	if config.ComparisonType == KeyedObjectArray && config.KeyField == "" {
+		return fmt.Errorf("key_field is required for keyed_object_array comparison")
+	}
+
+	if config.ComparisonType == RegexMatch {
+		if err := validatePattern(config.Pattern); err != nil {
+			return err
+		}
+	}
+
+	if config.VersionConstraint != "" {
+		if _, err := parseVersionConstraint(config.VersionConstraint); err != nil {
+			return err
+		}
+	}
+
+	if config.MaxDepth < 0 {
+		return fmt.Errorf("max depth must be non-negative, got %d", config.MaxDepth)
+	}
 
 	// Validate tolerance for numeric comparison
 	if config.ComparisonType == NumericTolerance {
@@ -287,6 +695,17 @@ func (cv *ConfigValidator) validateAttributeConfig(attrName string, config Attri
 	return nil
 }
 
+// validatePattern checks that a RegexMatch pattern is set and compiles
+func validatePattern(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("pattern is required for regex_match comparison")
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("invalid pattern for regex_match comparison: %w", err)
+	}
+	return nil
+}
+
 // GetStrictConfig returns a strict configuration for production environments
 func GetStrictConfig() DetectionConfig {
 	config := DefaultDetectionConfig()
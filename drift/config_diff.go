@@ -0,0 +1,264 @@
+package drift
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// ConfigChangeKind describes how a configuration entry differs between two configs
+type ConfigChangeKind string
+
+const (
+	// ConfigAdded means the entry only exists in the second config
+	ConfigAdded ConfigChangeKind = "added"
+	// ConfigRemoved means the entry only exists in the first config
+	ConfigRemoved ConfigChangeKind = "removed"
+	// ConfigChanged means the entry exists in both configs with different values
+	ConfigChanged ConfigChangeKind = "changed"
+)
+
+// ConfigChange is a single difference between two DetectionConfigs
+type ConfigChange struct {
+	Kind  ConfigChangeKind `json:"kind"`
+	Field string           `json:"field"`
+	Old   string           `json:"old,omitempty"`
+	New   string           `json:"new,omitempty"`
+}
+
+// String renders the change as a single readable line
+func (c ConfigChange) String() string {
+	switch c.Kind {
+	case ConfigAdded:
+		return fmt.Sprintf("+ %s: %s", c.Field, c.New)
+	case ConfigRemoved:
+		return fmt.Sprintf("- %s: %s", c.Field, c.Old)
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Field, c.Old, c.New)
+	}
+}
+
+// DiffConfigs lists the differences between config a and config b. Attribute
+// configs and ignored attributes are compared per entry; scalar settings are
+// reported as changes. The result is sorted by field name.
+func DiffConfigs(a, b DetectionConfig) []ConfigChange {
+	var changes []ConfigChange
+
+	scalar := func(field string, oldValue, newValue interface{}) {
+		oldStr, newStr := fmt.Sprintf("%v", oldValue), fmt.Sprintf("%v", newValue)
+		if oldStr != newStr {
+			changes = append(changes, ConfigChange{Kind: ConfigChanged, Field: field, Old: oldStr, New: newStr})
+		}
+	}
+
+	scalar("max_concurrency", a.MaxConcurrency, b.MaxConcurrency)
+	scalar("timeout", a.Timeout, b.Timeout)
+	scalar("strict_mode", a.StrictMode, b.StrictMode)
+	scalar("fail_on_unknown_resource_type", a.FailOnUnknownResourceType, b.FailOnUnknownResourceType)
+	scalar("fail_on_resource_type_mismatch", a.FailOnResourceTypeMismatch, b.FailOnResourceTypeMismatch)
+	scalar("trace_comparisons", a.TraceComparisons, b.TraceComparisons)
+	scalar("compare_security_group_rules", a.CompareSecurityGroupRules, b.CompareSecurityGroupRules)
+	scalar("collect_stats", a.CollectStats, b.CollectStats)
+	scalar("validate_results", a.ValidateResults, b.ValidateResults)
+	scalar("deterministic_batch", a.DeterministicBatch, b.DeterministicBatch)
+	scalar("empty_equals_absent", a.EmptyEqualsAbsent, b.EmptyEqualsAbsent)
+	scalar("only_attributes", a.OnlyAttributes, b.OnlyAttributes)
+	scalar("intersection_only", a.IntersectionOnly, b.IntersectionOnly)
+	scalar("include_matches", a.IncludeMatches, b.IncludeMatches)
+	scalar("security_group_rule_details", a.SecurityGroupRuleDetails, b.SecurityGroupRuleDetails)
+	scalar("strict_mode_details", a.StrictModeDetails, b.StrictModeDetails)
+	scalar("arn_normalization", fmt.Sprintf("%+v", a.ARNNormalization), fmt.Sprintf("%+v", b.ARNNormalization))
+	scalar("severity_overrides", formatSeverityOverrides(a.SeverityOverrides), formatSeverityOverrides(b.SeverityOverrides))
+	scalar("severity_floor_rules", formatSeverityFloorRules(a.SeverityFloorRules), formatSeverityFloorRules(b.SeverityFloorRules))
+	scalar("attribute_aliases", formatAttributeAliases(a.AttributeAliases), formatAttributeAliases(b.AttributeAliases))
+	scalar("float_precision", formatIntPtr(a.FloatPrecision), formatIntPtr(b.FloatPrecision))
+	scalar("default_config", describeAttributeConfig(a.DefaultConfig), describeAttributeConfig(b.DefaultConfig))
+
+	attributeConfigs := func(prefix string, oldConfigs, newConfigs map[string]AttributeConfig) {
+		for name, oldConfig := range oldConfigs {
+			field := prefix + name
+			newConfig, ok := newConfigs[name]
+			if !ok {
+				changes = append(changes, ConfigChange{Kind: ConfigRemoved, Field: field, Old: describeAttributeConfig(oldConfig)})
+				continue
+			}
+			scalar(field, describeAttributeConfig(oldConfig), describeAttributeConfig(newConfig))
+		}
+		for name, newConfig := range newConfigs {
+			if _, ok := oldConfigs[name]; !ok {
+				changes = append(changes, ConfigChange{Kind: ConfigAdded, Field: prefix + name, New: describeAttributeConfig(newConfig)})
+			}
+		}
+	}
+	attributeConfigs("attribute_configs.", a.AttributeConfigs, b.AttributeConfigs)
+	for resourceType, oldConfig := range a.ResourceTypeConfigs {
+		attributeConfigs("resource_type_configs."+resourceType+".attribute_configs.", oldConfig.AttributeConfigs, b.ResourceTypeConfigs[resourceType].AttributeConfigs)
+	}
+	for resourceType, newConfig := range b.ResourceTypeConfigs {
+		if _, ok := a.ResourceTypeConfigs[resourceType]; !ok {
+			attributeConfigs("resource_type_configs."+resourceType+".attribute_configs.", nil, newConfig.AttributeConfigs)
+		}
+	}
+
+	for resourceType, oldConfig := range a.ResourceTypeConfigs {
+		field := "resource_type_configs." + resourceType + ".default_config"
+		oldDefault := describeTypeDefault(oldConfig)
+		newConfig, ok := b.ResourceTypeConfigs[resourceType]
+		if !ok {
+			if oldDefault != "" {
+				changes = append(changes, ConfigChange{Kind: ConfigRemoved, Field: field, Old: oldDefault})
+			}
+			continue
+		}
+		newDefault := describeTypeDefault(newConfig)
+		switch {
+		case oldDefault == "" && newDefault != "":
+			changes = append(changes, ConfigChange{Kind: ConfigAdded, Field: field, New: newDefault})
+		case oldDefault != "" && newDefault == "":
+			changes = append(changes, ConfigChange{Kind: ConfigRemoved, Field: field, Old: oldDefault})
+		default:
+			scalar(field, oldDefault, newDefault)
+		}
+	}
+	for resourceType, newConfig := range b.ResourceTypeConfigs {
+		if _, ok := a.ResourceTypeConfigs[resourceType]; !ok && newConfig.DefaultConfig != nil {
+			changes = append(changes, ConfigChange{Kind: ConfigAdded, Field: "resource_type_configs." + resourceType + ".default_config", New: describeTypeDefault(newConfig)})
+		}
+	}
+
+	oldIgnored := make(map[string]bool, len(a.IgnoredAttributes))
+	for _, attr := range a.IgnoredAttributes {
+		oldIgnored[attr] = true
+	}
+	newIgnored := make(map[string]bool, len(b.IgnoredAttributes))
+	for _, attr := range b.IgnoredAttributes {
+		newIgnored[attr] = true
+	}
+	for attr := range oldIgnored {
+		if !newIgnored[attr] {
+			changes = append(changes, ConfigChange{Kind: ConfigRemoved, Field: "ignored_attributes", Old: attr})
+		}
+	}
+	for attr := range newIgnored {
+		if !oldIgnored[attr] {
+			changes = append(changes, ConfigChange{Kind: ConfigAdded, Field: "ignored_attributes", New: attr})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Field != changes[j].Field {
+			return changes[i].Field < changes[j].Field
+		}
+		return changes[i].Old+changes[i].New < changes[j].Old+changes[j].New
+	})
+
+	return changes
+}
+
+// FormatConfigChanges renders changes as a readable list, one change per line
+func FormatConfigChanges(changes []ConfigChange) string {
+	if len(changes) == 0 {
+		return "No configuration changes\n"
+	}
+
+	var sb strings.Builder
+	for _, change := range changes {
+		sb.WriteString(change.String())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// describeAttributeConfig summarizes the settings of an attribute config
+func describeAttributeConfig(ac AttributeConfig) string {
+	desc := fmt.Sprintf("type=%s case_sensitive=%t required=%t", ac.ComparisonType, ac.CaseSensitive, ac.Required)
+	if ac.AttributeName != "" {
+		desc += " attribute_name=" + ac.AttributeName
+	}
+	if ac.Description != "" {
+		desc += fmt.Sprintf(" description=%q", ac.Description)
+	}
+	if ac.Tolerance != nil {
+		desc += fmt.Sprintf(" tolerance=%g", *ac.Tolerance)
+	}
+	if ac.FloatPrecision != nil {
+		desc += fmt.Sprintf(" float_precision=%d", *ac.FloatPrecision)
+	}
+	if ac.CaseInsensitiveKeys {
+		desc += " case_insensitive_keys=true"
+	}
+	if ac.KeyField != "" {
+		desc += " key_field=" + ac.KeyField
+	}
+	if ac.EmptyEqualsAbsent {
+		desc += " empty_equals_absent=true"
+	}
+	if ac.MaxDepth != 0 {
+		desc += fmt.Sprintf(" max_depth=%d", ac.MaxDepth)
+	}
+	if ac.Pattern != "" {
+		desc += " pattern=" + ac.Pattern
+	}
+	if ac.VersionConstraint != "" {
+		desc += " version_constraint=" + ac.VersionConstraint
+	}
+	if len(ac.IgnoreKeys) > 0 {
+		desc += " ignore_keys=" + strings.Join(ac.IgnoreKeys, ",")
+	}
+	if ac.ReportSetDelta {
+		desc += " report_set_delta=true"
+	}
+	if len(ac.ComparisonChain) > 0 {
+		desc += " comparison_chain=" + comparisonChainName(ac.ComparisonChain)
+	}
+	return desc
+}
+
+// describeTypeDefault describes a resource type's default attribute config,
+// or returns "" when it has none
+func describeTypeDefault(config ResourceTypeConfig) string {
+	if config.DefaultConfig == nil {
+		return ""
+	}
+	return describeAttributeConfig(*config.DefaultConfig)
+}
+
+// formatIntPtr renders an optional int, using "unset" for nil
+func formatIntPtr(value *int) string {
+	if value == nil {
+		return "unset"
+	}
+	return fmt.Sprintf("%d", *value)
+}
+
+// formatSeverityOverrides renders overrides as sorted attr=severity pairs
+func formatSeverityOverrides(overrides map[string]interfaces.SeverityLevel) string {
+	pairs := make([]string, 0, len(overrides))
+	for attr, severity := range overrides {
+		pairs = append(pairs, attr+"="+string(severity))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// formatSeverityFloorRules renders rules as pattern>=severity pairs, in
+// evaluation order
+func formatSeverityFloorRules(rules []SeverityFloorRule) string {
+	pairs := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		pairs = append(pairs, rule.ResourcePattern+">="+string(rule.MinSeverity))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// formatAttributeAliases renders aliases as sorted name=alias|alias pairs
+func formatAttributeAliases(aliases map[string][]string) string {
+	pairs := make([]string, 0, len(aliases))
+	for name, names := range aliases {
+		pairs = append(pairs, name+"="+strings.Join(names, "|"))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
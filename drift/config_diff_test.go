@@ -0,0 +1,144 @@
+package drift
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	base := DefaultDetectionConfig()
+
+	modified := DefaultDetectionConfig()
+	modified.MaxConcurrency = base.MaxConcurrency + 5
+	modified.Timeout = base.Timeout + 10*time.Second
+	modified.StrictMode = !base.StrictMode
+	modified.AttributeConfigs = make(map[string]AttributeConfig, len(base.AttributeConfigs))
+	for name, config := range base.AttributeConfigs {
+		modified.AttributeConfigs[name] = config
+	}
+	delete(modified.AttributeConfigs, "tenancy")
+	modified.AttributeConfigs["state"] = AttributeConfig{ComparisonType: FuzzyMatch}
+	modified.AttributeConfigs["ipv6_addresses"] = AttributeConfig{ComparisonType: ArrayUnordered}
+	modified.IgnoredAttributes = append([]string{"tags"}, base.IgnoredAttributes[1:]...)
+
+	changes := DiffConfigs(base, modified)
+
+	expected := map[string]ConfigChangeKind{
+		"max_concurrency":                  ConfigChanged,
+		"timeout":                          ConfigChanged,
+		"strict_mode":                      ConfigChanged,
+		"attribute_configs.tenancy":        ConfigRemoved,
+		"attribute_configs.state":          ConfigChanged,
+		"attribute_configs.ipv6_addresses": ConfigAdded,
+	}
+	found := make(map[string]ConfigChangeKind)
+	var ignoredAdded, ignoredRemoved []string
+	for _, change := range changes {
+		if change.Field == "ignored_attributes" {
+			if change.Kind == ConfigAdded {
+				ignoredAdded = append(ignoredAdded, change.New)
+			} else {
+				ignoredRemoved = append(ignoredRemoved, change.Old)
+			}
+			continue
+		}
+		found[change.Field] = change.Kind
+	}
+
+	if len(found) != len(expected) {
+		t.Errorf("Expected %d field changes, got %d: %v", len(expected), len(found), changes)
+	}
+	for field, kind := range expected {
+		if found[field] != kind {
+			t.Errorf("Expected %s to be %s, got %q", field, kind, found[field])
+		}
+	}
+	if len(ignoredAdded) != 1 || ignoredAdded[0] != "tags" {
+		t.Errorf("Expected tags to be newly ignored, got %v", ignoredAdded)
+	}
+	if len(ignoredRemoved) != 1 || ignoredRemoved[0] != base.IgnoredAttributes[0] {
+		t.Errorf("Expected %s to be no longer ignored, got %v", base.IgnoredAttributes[0], ignoredRemoved)
+	}
+
+	rendered := FormatConfigChanges(changes)
+	if !strings.Contains(rendered, "- attribute_configs.tenancy:") {
+		t.Errorf("Expected removed attribute config in rendered list, got:\n%s", rendered)
+	}
+	if !strings.Contains(rendered, "~ strict_mode: false -> true") {
+		t.Errorf("Expected strict mode change in rendered list, got:\n%s", rendered)
+	}
+
+	if len(DiffConfigs(base, DefaultDetectionConfig())) != 0 {
+		t.Error("Expected no changes between identical configs")
+	}
+}
+
+// nonZeroValue returns a value of type typ that differs from its zero value
+// in every field, for exercising each configuration field in turn
+func nonZeroValue(typ reflect.Type) reflect.Value {
+	value := reflect.New(typ).Elem()
+	switch typ.Kind() {
+	case reflect.Bool:
+		value.SetBool(true)
+	case reflect.Int, reflect.Int64:
+		value.SetInt(7)
+	case reflect.Float64:
+		value.SetFloat(0.5)
+	case reflect.String:
+		value.SetString("x")
+	case reflect.Ptr:
+		value.Set(reflect.New(typ.Elem()))
+		value.Elem().Set(nonZeroValue(typ.Elem()))
+	case reflect.Slice:
+		value.Set(reflect.Append(reflect.MakeSlice(typ, 0, 1), nonZeroValue(typ.Elem())))
+	case reflect.Map:
+		value.Set(reflect.MakeMap(typ))
+		value.SetMapIndex(nonZeroValue(typ.Key()), nonZeroValue(typ.Elem()))
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			value.Field(i).Set(nonZeroValue(typ.Field(i).Type))
+		}
+	default:
+		panic("nonZeroValue: unsupported kind " + typ.Kind().String())
+	}
+	return value
+}
+
+// TestDiffConfigs_CoversEveryField fails when a field is added to
+// DetectionConfig, AttributeConfig or ResourceTypeConfig without DiffConfigs
+// reporting changes to it
+func TestDiffConfigs_CoversEveryField(t *testing.T) {
+	configType := reflect.TypeOf(DetectionConfig{})
+	for i := 0; i < configType.NumField(); i++ {
+		field := configType.Field(i)
+		var changed DetectionConfig
+		reflect.ValueOf(&changed).Elem().Field(i).Set(nonZeroValue(field.Type))
+		if len(DiffConfigs(DetectionConfig{}, changed)) == 0 {
+			t.Errorf("DiffConfigs does not report changes to DetectionConfig.%s", field.Name)
+		}
+	}
+
+	attributeType := reflect.TypeOf(AttributeConfig{})
+	for i := 0; i < attributeType.NumField(); i++ {
+		field := attributeType.Field(i)
+		var changed AttributeConfig
+		reflect.ValueOf(&changed).Elem().Field(i).Set(nonZeroValue(field.Type))
+		if describeAttributeConfig(AttributeConfig{}) == describeAttributeConfig(changed) {
+			t.Errorf("describeAttributeConfig does not describe AttributeConfig.%s", field.Name)
+		}
+	}
+
+	typeConfigType := reflect.TypeOf(ResourceTypeConfig{})
+	for i := 0; i < typeConfigType.NumField(); i++ {
+		field := typeConfigType.Field(i)
+		var changed ResourceTypeConfig
+		reflect.ValueOf(&changed).Elem().Field(i).Set(nonZeroValue(field.Type))
+		a := DetectionConfig{ResourceTypeConfigs: map[string]ResourceTypeConfig{"aws_instance": {}}}
+		b := DetectionConfig{ResourceTypeConfigs: map[string]ResourceTypeConfig{"aws_instance": changed}}
+		if len(DiffConfigs(a, b)) == 0 {
+			t.Errorf("DiffConfigs does not report changes to ResourceTypeConfig.%s", field.Name)
+		}
+	}
+}
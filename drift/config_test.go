@@ -5,8 +5,11 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
+
+	"firefly-task/pkg/interfaces"
 )
 
 func TestNewConfigManager(t *testing.T) {
@@ -187,6 +190,15 @@ func TestDetectionConfigFile_ToDetectionConfig(t *testing.T) {
 	if config.DefaultConfig.ComparisonType != ExactMatch {
 		t.Errorf("Expected default ExactMatch, got %v", config.DefaultConfig.ComparisonType)
 	}
+
+	if !config.FailOnResourceTypeMismatch {
+		t.Error("Expected FailOnResourceTypeMismatch to default to true")
+	}
+	disabled := false
+	configFile.FailOnTypeMismatch = &disabled
+	if configFile.ToDetectionConfig().FailOnResourceTypeMismatch {
+		t.Error("Expected fail_on_resource_type_mismatch false to disable the check")
+	}
 }
 
 func TestDetectionConfigFileFromConfig(t *testing.T) {
@@ -255,6 +267,7 @@ func TestParseComparisonType(t *testing.T) {
 		{"array_unordered", ArrayUnordered},
 		{"map_comparison", MapComparison},
 		{"nested_object", NestedObject},
+		{"regex_match", RegexMatch},
 		{"invalid_type", ExactMatch}, // Should default to ExactMatch
 		{"", ExactMatch},             // Should default to ExactMatch
 	}
@@ -281,6 +294,7 @@ func TestComparisonTypeToString(t *testing.T) {
 		{ArrayUnordered, "array_unordered"},
 		{MapComparison, "map_comparison"},
 		{NestedObject, "nested_object"},
+		{RegexMatch, "regex_match"},
 	}
 
 	for _, tt := range tests {
@@ -372,6 +386,59 @@ func TestConfigValidator_ValidateConfig(t *testing.T) {
 			}(),
 			wantError: false,
 		},
+		{
+			name: "valid glob ignored attributes",
+			config: DetectionConfig{
+				MaxConcurrency:    10,
+				Timeout:           30 * time.Second,
+				DefaultConfig:     AttributeConfig{ComparisonType: ExactMatch},
+				IgnoredAttributes: []string{"tags.*", "block_device_mappings[*].iops", "launch_time"},
+			},
+			wantError: false,
+		},
+		{
+			name: "invalid empty ignored attribute",
+			config: DetectionConfig{
+				MaxConcurrency:    10,
+				Timeout:           30 * time.Second,
+				DefaultConfig:     AttributeConfig{ComparisonType: ExactMatch},
+				IgnoredAttributes: []string{"launch_time", " "},
+			},
+			wantError: true,
+		},
+		{
+			name: "invalid alias shared by two attributes",
+			config: DetectionConfig{
+				MaxConcurrency: 10,
+				Timeout:        30 * time.Second,
+				DefaultConfig:  AttributeConfig{ComparisonType: ExactMatch},
+				AttributeAliases: map[string][]string{
+					"security_groups": {"vpc_security_group_ids"},
+					"sg_ids":          {"vpc_security_group_ids"},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "valid severity floor",
+			config: DetectionConfig{
+				MaxConcurrency:     10,
+				Timeout:            30 * time.Second,
+				DefaultConfig:      AttributeConfig{ComparisonType: ExactMatch},
+				SeverityFloorRules: []SeverityFloorRule{{ResourcePattern: "aws_iam_*", MinSeverity: interfaces.SeverityHigh}},
+			},
+			wantError: false,
+		},
+		{
+			name: "invalid severity floor - unknown severity",
+			config: DetectionConfig{
+				MaxConcurrency:     10,
+				Timeout:            30 * time.Second,
+				DefaultConfig:      AttributeConfig{ComparisonType: ExactMatch},
+				SeverityFloorRules: []SeverityFloorRule{{ResourcePattern: "aws_iam_*", MinSeverity: "severe"}},
+			},
+			wantError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -441,6 +508,42 @@ func TestConfigValidator_ValidateAttributeConfig(t *testing.T) {
 			}(),
 			wantError: true,
 		},
+		{
+			name:      "valid regex match",
+			attrName:  "bucket",
+			config:    AttributeConfig{ComparisonType: RegexMatch, Pattern: `^app-logs-[a-z0-9]{8}$`},
+			wantError: false,
+		},
+		{
+			name:      "invalid - missing regex pattern",
+			attrName:  "bucket",
+			config:    AttributeConfig{ComparisonType: RegexMatch},
+			wantError: true,
+		},
+		{
+			name:      "invalid - regex pattern does not compile",
+			attrName:  "bucket",
+			config:    AttributeConfig{ComparisonType: RegexMatch, Pattern: `app-logs-[`},
+			wantError: true,
+		},
+		{
+			name:      "valid semver constraint",
+			attrName:  "engine_version",
+			config:    AttributeConfig{ComparisonType: SemverMatch, VersionConstraint: ">=14.0, <15"},
+			wantError: false,
+		},
+		{
+			name:      "invalid - malformed semver constraint",
+			attrName:  "engine_version",
+			config:    AttributeConfig{ComparisonType: SemverMatch, VersionConstraint: ">=fourteen"},
+			wantError: true,
+		},
+		{
+			name:      "invalid - bad regex pattern in chain",
+			attrName:  "bucket",
+			config:    AttributeConfig{ComparisonType: ExactMatch, ComparisonChain: []ComparisonType{RegexMatch, ExactMatch}, Pattern: `(`},
+			wantError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -506,6 +609,10 @@ func TestJSONRoundTrip(t *testing.T) {
 				ComparisonType: FuzzyMatch,
 				CaseSensitive:  true,
 			},
+			"tags": {
+				ComparisonType: MapComparison,
+				IgnoreKeys:     []string{"aws:*", "LastScanned"},
+			},
 		},
 		DefaultConfig: AttributeConfig{
 			ComparisonType: ExactMatch,
@@ -570,6 +677,10 @@ func TestJSONRoundTrip(t *testing.T) {
 	} else if *testAttr.Tolerance != *originalTestAttr.Tolerance {
 		t.Errorf("test_attr Tolerance mismatch: expected %f, got %f", *originalTestAttr.Tolerance, *testAttr.Tolerance)
 	}
+
+	if got := resultConfig.AttributeConfigs["tags"].IgnoreKeys; !reflect.DeepEqual(got, []string{"aws:*", "LastScanned"}) {
+		t.Errorf("tags IgnoreKeys mismatch: got %v", got)
+	}
 }
 
 // Helper function for string contains check
@@ -589,3 +700,40 @@ func indexOfSubstring(s, substr string) int {
 	}
 	return -1
 }
+
+func TestConfigManager_EffectiveConfig_EnvOverride(t *testing.T) {
+	t.Setenv(EnvMaxConcurrency, "42")
+
+	cm := NewConfigManager(filepath.Join(t.TempDir(), "missing.json"))
+	effective, err := cm.EffectiveConfig()
+	if err != nil {
+		t.Fatalf("EffectiveConfig() error = %v", err)
+	}
+
+	if effective.Config.MaxConcurrency != 42 {
+		t.Errorf("Expected MaxConcurrency 42, got %d", effective.Config.MaxConcurrency)
+	}
+	if effective.Sources["max_concurrency"] != SourceEnv {
+		t.Errorf("Expected max_concurrency source env, got %q", effective.Sources["max_concurrency"])
+	}
+	if effective.Sources["strict_mode"] != SourceDefault {
+		t.Errorf("Expected strict_mode source default, got %q", effective.Sources["strict_mode"])
+	}
+
+	loaded, err := cm.LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if loaded.MaxConcurrency != 42 {
+		t.Errorf("Expected LoadConfig to apply env override, got %d", loaded.MaxConcurrency)
+	}
+}
+
+func TestConfigManager_EffectiveConfig_InvalidEnv(t *testing.T) {
+	t.Setenv(EnvStrictMode, "sometimes")
+
+	cm := NewConfigManager(filepath.Join(t.TempDir(), "missing.json"))
+	if _, err := cm.EffectiveConfig(); err == nil {
+		t.Error("Expected error for invalid strict mode override")
+	}
+}
@@ -0,0 +1,151 @@
+package drift
+
+import (
+	"sort"
+	"sync"
+)
+
+// Coverage reasons for ignored attributes
+const (
+	IgnoreReasonConfigured  = "listed in ignored_attributes"
+	IgnoreReasonNotSelected = "not in only_attributes"
+)
+
+// IgnoredAttribute is an attribute skipped during detection
+type IgnoredAttribute struct {
+	Attribute string `json:"attribute"`
+	Reason    string `json:"reason"`
+	Resources int    `json:"resources"`
+}
+
+// DefaultedAttribute is an attribute compared with a default config because
+// it has no entry in AttributeConfigs and no custom comparator
+type DefaultedAttribute struct {
+	ResourceType string `json:"resource_type"`
+	Attribute    string `json:"attribute"`
+	Resources    int    `json:"resources"`
+}
+
+// CoverageReport lists the attributes seen across detections that were
+// ignored or fell back to a default config, to help find gaps in the
+// detection configuration. Both lists are sorted.
+type CoverageReport struct {
+	ResourcesEvaluated int                  `json:"resources_evaluated"`
+	Ignored            []IgnoredAttribute   `json:"ignored"`
+	Defaulted          []DefaultedAttribute `json:"defaulted"`
+}
+
+type ignoredKey struct {
+	attribute string
+	reason    string
+}
+
+type defaultedKey struct {
+	resourceType string
+	attribute    string
+}
+
+// attributeCoverage collects ignored and defaulted attributes across detections
+type attributeCoverage struct {
+	mu        sync.Mutex
+	resources int
+	ignored   map[ignoredKey]int
+	defaulted map[defaultedKey]int
+}
+
+func (c *attributeCoverage) record(resourceType string, ignored []ignoredKey, defaulted []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ignored == nil {
+		c.ignored = make(map[ignoredKey]int)
+		c.defaulted = make(map[defaultedKey]int)
+	}
+	c.resources++
+	for _, key := range ignored {
+		c.ignored[key]++
+	}
+	for _, attr := range defaulted {
+		c.defaulted[defaultedKey{resourceType: resourceType, attribute: attr}]++
+	}
+}
+
+func (c *attributeCoverage) report() CoverageReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	report := CoverageReport{
+		ResourcesEvaluated: c.resources,
+		Ignored:            []IgnoredAttribute{},
+		Defaulted:          []DefaultedAttribute{},
+	}
+	for key, count := range c.ignored {
+		report.Ignored = append(report.Ignored, IgnoredAttribute{Attribute: key.attribute, Reason: key.reason, Resources: count})
+	}
+	for key, count := range c.defaulted {
+		report.Defaulted = append(report.Defaulted, DefaultedAttribute{ResourceType: key.resourceType, Attribute: key.attribute, Resources: count})
+	}
+
+	sort.Slice(report.Ignored, func(i, j int) bool {
+		a, b := report.Ignored[i], report.Ignored[j]
+		if a.Attribute != b.Attribute {
+			return a.Attribute < b.Attribute
+		}
+		return a.Reason < b.Reason
+	})
+	sort.Slice(report.Defaulted, func(i, j int) bool {
+		a, b := report.Defaulted[i], report.Defaulted[j]
+		if a.ResourceType != b.ResourceType {
+			return a.ResourceType < b.ResourceType
+		}
+		return a.Attribute < b.Attribute
+	})
+	return report
+}
+
+func (c *attributeCoverage) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.resources = 0
+	c.ignored = nil
+	c.defaulted = nil
+}
+
+// recordCoverage classifies the attributes of one resource as ignored,
+// defaulted or explicitly configured and adds them to the coverage report
+func (d *DriftDetector) recordCoverage(resourceType string, attributeNames []string) {
+	var ignored []ignoredKey
+	var defaulted []string
+	for _, attrName := range attributeNames {
+		if d.shouldIgnoreAttribute(attrName) {
+			ignored = append(ignored, ignoredKey{attribute: attrName, reason: IgnoreReasonConfigured})
+			continue
+		}
+		if !d.isSelectedAttribute(attrName) {
+			ignored = append(ignored, ignoredKey{attribute: attrName, reason: IgnoreReasonNotSelected})
+			continue
+		}
+		if _, ok := d.config.ResourceTypeConfigs[resourceType].AttributeConfigs[attrName]; ok {
+			continue
+		}
+		if _, ok := d.config.AttributeConfigs[attrName]; ok {
+			continue
+		}
+		if _, ok := d.comparators[attrName]; ok {
+			continue
+		}
+		defaulted = append(defaulted, attrName)
+	}
+	d.coverage.record(resourceType, ignored, defaulted)
+}
+
+// CoverageReport returns the attributes that were ignored or compared with
+// a default config across all detections since the last reset
+func (d *DriftDetector) CoverageReport() CoverageReport {
+	return d.coverage.report()
+}
+
+// ResetCoverage clears the collected attribute coverage
+func (d *DriftDetector) ResetCoverage() {
+	d.coverage.reset()
+}
@@ -0,0 +1,82 @@
+package drift
+
+import (
+	"testing"
+)
+
+func TestCoverageReport(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.IgnoredAttributes = []string{"launch_time"}
+	detector := NewDriftDetector(config)
+	detector.RegisterResourceMapper("*drift.reasonResource", func(resource interface{}) (map[string]interface{}, error) {
+		return resource.(*reasonResource).attrs, nil
+	})
+
+	pairs := []ResourcePair{
+		{
+			Index:           0,
+			AWSResource:     &reasonResource{attrs: map[string]interface{}{"instance_type": "t3.micro", "launch_time": "now", "owner_label": "web"}},
+			TerraformConfig: &reasonResource{attrs: map[string]interface{}{"instance_type": "t3.micro", "owner_label": "api"}},
+		},
+		{
+			Index:           1,
+			AWSResource:     &reasonResource{attrs: map[string]interface{}{"instance_type": "t3.micro", "launch_time": "now", "owner_label": "web"}},
+			TerraformConfig: &reasonResource{attrs: map[string]interface{}{"instance_type": "t3.micro", "launch_time": "now", "owner_label": "web"}},
+		},
+	}
+	if _, err := detector.DetectDriftBatch(pairs); err != nil {
+		t.Fatalf("DetectDriftBatch() error = %v", err)
+	}
+
+	report := detector.CoverageReport()
+	if report.ResourcesEvaluated != 2 {
+		t.Errorf("Expected 2 resources evaluated, got %d", report.ResourcesEvaluated)
+	}
+
+	if len(report.Ignored) != 1 || report.Ignored[0].Attribute != "launch_time" ||
+		report.Ignored[0].Reason != IgnoreReasonConfigured || report.Ignored[0].Resources != 2 {
+		t.Errorf("Expected launch_time ignored on 2 resources, got %+v", report.Ignored)
+	}
+
+	if len(report.Defaulted) != 1 {
+		t.Fatalf("Expected only owner_label to use the default config, got %+v", report.Defaulted)
+	}
+	defaulted := report.Defaulted[0]
+	if defaulted.Attribute != "owner_label" || defaulted.ResourceType != "*drift.reasonResource" || defaulted.Resources != 2 {
+		t.Errorf("Unexpected defaulted attribute %+v", defaulted)
+	}
+
+	detector.ResetCoverage()
+	if report := detector.CoverageReport(); report.ResourcesEvaluated != 0 || len(report.Defaulted) != 0 {
+		t.Errorf("Expected coverage to be cleared after reset, got %+v", report)
+	}
+}
+
+func TestCoverageReport_TypeScopedConfig(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.ResourceTypeConfigs = map[string]ResourceTypeConfig{
+		"*drift.reasonResource": {AttributeConfigs: map[string]AttributeConfig{
+			"owner_label": {ComparisonType: FuzzyMatch},
+		}},
+	}
+	detector := NewDriftDetector(config)
+	detector.RegisterResourceMapper("*drift.reasonResource", func(resource interface{}) (map[string]interface{}, error) {
+		return resource.(*reasonResource).attrs, nil
+	})
+	detector.RegisterResourceMapper("*drift.otherResource", func(resource interface{}) (map[string]interface{}, error) {
+		return resource.(*otherResource).attrs, nil
+	})
+
+	attrs := map[string]interface{}{"owner_label": "web"}
+	if _, err := detector.DetectDrift(&reasonResource{attrs: attrs}, &reasonResource{attrs: attrs}); err != nil {
+		t.Fatalf("DetectDrift() error = %v", err)
+	}
+	if _, err := detector.DetectDrift(&otherResource{attrs: attrs}, &otherResource{attrs: attrs}); err != nil {
+		t.Fatalf("DetectDrift() error = %v", err)
+	}
+
+	report := detector.CoverageReport()
+	if len(report.Defaulted) != 1 || report.Defaulted[0].ResourceType != "*drift.otherResource" {
+		t.Errorf("Expected owner_label to be defaulted only for the type without an override, got %+v", report.Defaulted)
+	}
+}
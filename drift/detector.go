@@ -1,8 +1,15 @@
 package drift
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"path"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 	"unicode"
@@ -20,10 +27,16 @@ type DetectionConfig struct {
 	// DefaultConfig is used for attributes not explicitly configured
 	DefaultConfig AttributeConfig
 
-	// IgnoredAttributes lists attributes to skip during comparison
+	// IgnoredAttributes lists attributes to skip during comparison. Entries
+	// may name nested map keys and array elements of an attribute value
+	// ("tags.Name", "block_device_mappings[0].iops"), which are dropped from
+	// both sides before the attribute is compared. Entries containing '*'
+	// are glob patterns (e.g. "tags.*" or "block_device_mappings[*].iops").
 	IgnoredAttributes []string
 
-	// StrictMode determines if unknown attributes should cause errors
+	// StrictMode makes DetectDrift return an UnexpectedAttributeError when an
+	// attribute that is not ignored exists on only one side, instead of
+	// reporting it as ordinary drift (see StrictModeDetails)
 	StrictMode bool
 
 	// MaxConcurrency limits the number of concurrent drift detections
@@ -31,6 +44,160 @@ type DetectionConfig struct {
 
 	// Timeout for individual drift detection operations
 	Timeout time.Duration
+
+	// FailOnUnknownResourceType makes DetectDrift return an
+	// UnknownResourceTypeError for resource types without a dedicated
+	// converter instead of falling back to reflection
+	FailOnUnknownResourceType bool
+
+	// SeverityFloorRules enforce a minimum severity for drift on resources
+	// matching a pattern
+	SeverityFloorRules []SeverityFloorRule
+
+	// TraceComparisons attaches a ComparisonTrace to each drift detail
+	// produced by a value comparison
+	TraceComparisons bool
+
+	// CompareSecurityGroupRules compares the rules granted by security groups
+	// instead of their IDs. Requires a resolver set with
+	// SetSecurityGroupRuleResolver.
+	CompareSecurityGroupRules bool
+
+	// CollectStats records per-comparison-type timings, available through
+	// DriftDetector.DetectionStats
+	CollectStats bool
+
+	// FloatPrecision is the default float rounding used when values are
+	// stringified for comparison, for attributes without their own setting
+	FloatPrecision *int
+
+	// AttributeAliases maps a logical attribute name to the other names it
+	// may appear under on either side (e.g. "security_groups" to
+	// "vpc_security_group_ids"). Aliased values are compared as one attribute.
+	AttributeAliases map[string][]string
+
+	// FailOnResourceTypeMismatch makes DetectDrift return a
+	// ResourceTypeMismatchError when the AWS resource type differs from the
+	// type declared by the Terraform config. On in DefaultDetectionConfig.
+	FailOnResourceTypeMismatch bool
+
+	// ARNNormalization rewrites ARNs in attribute values before comparison.
+	// Resource IDs keep the real ARN.
+	ARNNormalization ARNNormalization
+
+	// ValidateResults makes DetectDrift check each result with
+	// ValidateResult and return an error for inconsistent results. Intended
+	// for development, when custom comparators or hooks may misbehave.
+	ValidateResults bool
+
+	// DeterministicBatch makes DetectDriftBatch dispatch pairs in resource ID
+	// order and report errors in that order, so repeated runs over the same
+	// input produce identical output
+	DeterministicBatch bool
+
+	// SeverityOverrides sets the severity of value differences on the named
+	// attributes, replacing the built-in mapping (e.g. {"tags": "high"})
+	SeverityOverrides map[string]interfaces.SeverityLevel
+
+	// ResourceTypeConfigs holds settings for a single resource type, keyed
+	// by type (e.g. "aws_instance")
+	ResourceTypeConfigs map[string]ResourceTypeConfig
+
+	// EmptyEqualsAbsent treats a missing attribute, a nil value and an empty
+	// slice or map as equal for every attribute. AttributeConfig can enable
+	// it for single attributes.
+	EmptyEqualsAbsent bool
+
+	// OnlyAttributes restricts comparison to the listed attributes; every
+	// other attribute is skipped. IgnoredAttributes still applies within
+	// the set. Empty compares all attributes.
+	OnlyAttributes []string
+
+	// IntersectionOnly makes DetectDriftByID compare only resource IDs present
+	// on both sides, reporting the rest in PairingCoverage instead of as
+	// missing resource drift
+	IntersectionOnly bool
+
+	// IncludeMatches records attributes that were compared and found equal in
+	// DriftResult.MatchedDetails for auditing. It disables the identical
+	// resource short-circuit so every attribute is compared.
+	IncludeMatches bool
+
+	// SecurityGroupRuleDetails reports security group rule drift as one detail
+	// per rule, named like ingress_rule[tcp/443], instead of a single
+	// security_groups detail. Only applies with CompareSecurityGroupRules.
+	SecurityGroupRuleDetails bool
+
+	// StrictModeDetails records unexpected attributes as high severity drift
+	// details instead of returning an UnexpectedAttributeError. Only applies
+	// with StrictMode.
+	StrictModeDetails bool
+}
+
+// ResourceTypeConfig holds detection settings scoped to one resource type
+type ResourceTypeConfig struct {
+	// AttributeConfigs override the global AttributeConfigs for attributes of
+	// this type (e.g. engine_version only on aws_db_instance)
+	AttributeConfigs map[string]AttributeConfig
+
+	// DefaultConfig, when set, is used for attributes of this type that have
+	// no entry in either AttributeConfigs, in place of the global DefaultConfig
+	DefaultConfig *AttributeConfig
+}
+
+// SeverityFloorRule raises the severity of every drift detail on matching
+// resources to at least MinSeverity. ResourcePattern is a glob matched
+// against the resource type and the resource ID (e.g. "aws_iam_*").
+type SeverityFloorRule struct {
+	ResourcePattern string                   `json:"resource_pattern"`
+	MinSeverity     interfaces.SeverityLevel `json:"min_severity"`
+}
+
+// Matches reports whether the rule applies to the given resource
+func (r SeverityFloorRule) Matches(resourceType, resourceID string) bool {
+	if matched, err := path.Match(r.ResourcePattern, resourceType); err == nil && matched {
+		return true
+	}
+	matched, err := path.Match(r.ResourcePattern, resourceID)
+	return err == nil && matched
+}
+
+// UnknownResourceTypeError is returned when a resource type has no dedicated
+// converter and FailOnUnknownResourceType is enabled
+type UnknownResourceTypeError struct {
+	Type string
+}
+
+func (e *UnknownResourceTypeError) Error() string {
+	return fmt.Sprintf("unsupported resource type: %s", e.Type)
+}
+
+// UnexpectedAttributeError is returned in StrictMode when an attribute exists
+// on only one side of a comparison
+type UnexpectedAttributeError struct {
+	ResourceID string
+	Attribute  string
+	// InAWS is true when the attribute exists only on the AWS resource, false
+	// when it exists only in the Terraform configuration
+	InAWS bool
+}
+
+func (e *UnexpectedAttributeError) Error() string {
+	if e.InAWS {
+		return fmt.Sprintf("unexpected attribute %s on %s: present in AWS resource but not in Terraform configuration", e.Attribute, e.ResourceID)
+	}
+	return fmt.Sprintf("unexpected attribute %s on %s: present in Terraform configuration but not in AWS resource", e.Attribute, e.ResourceID)
+}
+
+// ResourceTypeMismatchError is returned when an AWS resource is paired with a
+// Terraform config declaring a different resource type
+type ResourceTypeMismatchError struct {
+	AWSType       string
+	TerraformType string
+}
+
+func (e *ResourceTypeMismatchError) Error() string {
+	return fmt.Sprintf("resource type mismatch: AWS resource is %s but Terraform config declares %s", e.AWSType, e.TerraformType)
 }
 
 // DefaultDetectionConfig returns a sensible default configuration
@@ -41,8 +208,8 @@ func DefaultDetectionConfig() DetectionConfig {
 			"instance_type":                        {ComparisonType: ExactMatch, CaseSensitive: true},
 			"ami":                                  {ComparisonType: ExactMatch, CaseSensitive: true},
 			"state":                                {ComparisonType: ExactMatch, CaseSensitive: false},
-			"public_ip":                            {ComparisonType: ExactMatch, CaseSensitive: true},
-			"private_ip":                           {ComparisonType: ExactMatch, CaseSensitive: true},
+			"public_ip":                            {ComparisonType: IPMatch, CaseSensitive: true},
+			"private_ip":                           {ComparisonType: IPMatch, CaseSensitive: true},
 			"public_dns":                           {ComparisonType: ExactMatch, CaseSensitive: false},
 			"private_dns":                          {ComparisonType: ExactMatch, CaseSensitive: false},
 			"security_groups":                      {ComparisonType: ArrayUnordered},
@@ -64,6 +231,15 @@ func DefaultDetectionConfig() DetectionConfig {
 			"root_device_name":                     {ComparisonType: ExactMatch, CaseSensitive: true},
 			"root_device_type":                     {ComparisonType: ExactMatch, CaseSensitive: false},
 			"block_device_mappings":                {ComparisonType: ArrayUnordered},
+			"policy":                               {ComparisonType: JSONSemanticMatch},
+			"lifecycle_rules":                      {ComparisonType: KeyedObjectArray, KeyField: "id"},
+			"bucket":                               {ComparisonType: ExactMatch, CaseSensitive: true},
+			"versioning":                           {ComparisonType: ExactMatch},
+			"public_access_block":                  {ComparisonType: MapComparison},
+			"server_side_encryption":               {ComparisonType: MapComparison},
+			"logging_target":                       {ComparisonType: ExactMatch, CaseSensitive: true},
+			"engine_version":                       {ComparisonType: ExactMatch, CaseSensitive: true, ComparisonChain: []ComparisonType{SemverMatch, ExactMatch}},
+			"version":                              {ComparisonType: ExactMatch, CaseSensitive: true, ComparisonChain: []ComparisonType{SemverMatch, ExactMatch}},
 		},
 		DefaultConfig: AttributeConfig{
 			ComparisonType: ExactMatch,
@@ -79,31 +255,78 @@ func DefaultDetectionConfig() DetectionConfig {
 		StrictMode:     false,
 		MaxConcurrency: 10,
 		Timeout:        30 * time.Second,
+
+		FailOnResourceTypeMismatch: true,
 	}
 }
 
 // DriftDetector handles drift detection operations
 type DriftDetector struct {
-	config DetectionConfig
-	mu     sync.RWMutex
+	config         DetectionConfig
+	sgRuleResolver SecurityGroupRuleResolver
+	beforeCompare  BeforeCompareHook
+	afterCompare   AfterCompareHook
+	ownerResolver  OwnerResolver
+	comparators    map[string]CustomComparator
+	mappers        map[string]ResourceMapper
+	stats          *detectionStats
+	coverage       *attributeCoverage
+	mu             sync.RWMutex
+
+	// disableShortCircuit forces a full comparison of identical resources
+	disableShortCircuit bool
 }
 
 // NewDriftDetector creates a new drift detector with the given configuration
 func NewDriftDetector(config DetectionConfig) *DriftDetector {
 	return &DriftDetector{
-		config: config,
+		config:   config,
+		stats:    &detectionStats{},
+		coverage: &attributeCoverage{},
 	}
 }
 
-// DetectDrift compares an AWS resource with its Terraform configuration
-func (d *DriftDetector) DetectDrift(awsResource interface{}, terraformConfig interface{}) (*interfaces.DriftResult, error) {
+// snapshot copies the settings a detection reads, so the comparison can run
+// without holding d.mu. The comparator and mapper maps are replaced rather
+// than modified by their setters, so sharing them is safe; stats and
+// coverage are shared with d.
+func (d *DriftDetector) snapshot() *DriftDetector {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
+	return &DriftDetector{
+		config:              d.config,
+		sgRuleResolver:      d.sgRuleResolver,
+		beforeCompare:       d.beforeCompare,
+		afterCompare:        d.afterCompare,
+		ownerResolver:       d.ownerResolver,
+		comparators:         d.comparators,
+		mappers:             d.mappers,
+		stats:               d.stats,
+		coverage:            d.coverage,
+		disableShortCircuit: d.disableShortCircuit,
+	}
+}
 
+// DetectDrift compares an AWS resource with its Terraform configuration. It
+// returns a *DetectionTimeoutError when the comparison runs longer than
+// DetectionConfig.Timeout; the deadline is checked between attributes.
+func (d *DriftDetector) DetectDrift(awsResource interface{}, terraformConfig interface{}) (*interfaces.DriftResult, error) {
+	return d.detectDriftContext(context.Background(), awsResource, terraformConfig)
+}
+
+// detectDrift performs the comparison for DetectDrift, giving up at the first
+// check after deadline when it is set. It must be called on a snapshot.
+func (d *DriftDetector) detectDrift(awsResource interface{}, terraformConfig interface{}, deadline time.Time) (*interfaces.DriftResult, error) {
 	if awsResource == nil || terraformConfig == nil {
 		return nil, fmt.Errorf("both AWS resource and Terraform configuration must be provided")
 	}
 
+	if d.config.FailOnResourceTypeMismatch {
+		if err := d.validateResourceTypes(awsResource, terraformConfig); err != nil {
+			return nil, err
+		}
+	}
+
 	// Convert resources to comparable maps
 	awsMap, err := d.resourceToMap(awsResource)
 	if err != nil {
@@ -115,37 +338,120 @@ func (d *DriftDetector) DetectDrift(awsResource interface{}, terraformConfig int
 		return nil, fmt.Errorf("failed to convert Terraform configuration: %w", err)
 	}
 
+	if d.config.ARNNormalization.Enabled() {
+		awsMap = d.normalizeARNValues(awsMap)
+		terraformMap = d.normalizeARNValues(terraformMap)
+	}
+
+	// Byte-identical resources cannot drift, so skip the per-attribute work
+	if d.shortCircuitAllowed() && resourceMapsIdentical(awsMap, terraformMap) {
+		d.recordCoverage(d.extractResourceType(awsResource), d.getAllAttributeNames(awsMap, terraformMap))
+		result := &interfaces.DriftResult{
+			ResourceID:    d.extractResourceID(awsResource),
+			ResourceType:  d.extractResourceType(awsResource),
+			DetectionTime: time.Now(),
+			DriftDetails:  []*interfaces.DriftDetail{},
+			Severity:      interfaces.SeverityNone,
+			Tags:          resourceTags(awsMap),
+		}
+		EnrichDisplayName(result)
+		d.enrichOwner(result)
+		return result, nil
+	}
+
+	// Unresolved references (e.g. aws_security_group.app.id) are skipped
+	// below, so they must not reach the rule resolver either
+	unknown := unknownAttributes(terraformConfig)
+	compareRules := d.config.CompareSecurityGroupRules && d.sgRuleResolver != nil && !unknown["security_groups"]
+	var ruleDetails []*interfaces.DriftDetail
+	if compareRules && d.config.SecurityGroupRuleDetails {
+		awsGroups, awsExists := awsMap["security_groups"]
+		terraformGroups, terraformExists := terraformMap["security_groups"]
+		if awsExists && terraformExists && !d.shouldIgnoreAttribute("security_groups") && d.isSelectedAttribute("security_groups") {
+			ruleDetails, err = d.securityGroupRuleDrift(awsGroups, terraformGroups)
+			if err != nil {
+				return nil, err
+			}
+			// Rule details replace the group-level comparison
+			delete(awsMap, "security_groups")
+			delete(terraformMap, "security_groups")
+		}
+	} else if compareRules {
+		for _, m := range []map[string]interface{}{awsMap, terraformMap} {
+			if groups, ok := m["security_groups"]; ok {
+				rules, err := d.resolveSecurityGroupRules(groups)
+				if err != nil {
+					return nil, err
+				}
+				m["security_groups"] = rules
+			}
+		}
+	}
+
 	// Perform drift detection
 	result := &interfaces.DriftResult{
 		ResourceID:    d.extractResourceID(awsResource),
 		ResourceType:  d.extractResourceType(awsResource),
 		DetectionTime: time.Now(),
 		DriftDetails:   []*interfaces.DriftDetail{},
+		Tags:           resourceTags(awsMap),
 	}
+	EnrichDisplayName(result)
+	d.enrichOwner(result)
 
 	// Get all unique attribute names
 	attributeNames := d.getAllAttributeNames(awsMap, terraformMap)
+	d.recordCoverage(result.ResourceType, attributeNames)
 
 	// Compare each attribute
 	for _, attrName := range attributeNames {
-		if d.shouldIgnoreAttribute(attrName) {
+		if err := d.checkDeadline(deadline, awsResource); err != nil {
+			return nil, err
+		}
+		if d.shouldIgnoreAttribute(attrName) || !d.isSelectedAttribute(attrName) || unknown[attrName] {
 			continue
 		}
 
-		awsValue, awsExists := awsMap[attrName]
-		terraformValue, terraformExists := terraformMap[attrName]
+		awsValue, awsExists := d.lookupAttribute(awsMap, attrName)
+		terraformValue, terraformExists := d.lookupAttribute(terraformMap, attrName)
 
 		// Handle missing attributes
 		if !awsExists && !terraformExists {
 			continue
 		}
 
+		if (!awsExists || !terraformExists) && d.getAttributeConfig(result.ResourceType, attrName).EmptyEqualsAbsent &&
+			isEmptyOrAbsent(awsValue) && isEmptyOrAbsent(terraformValue) {
+			continue
+		}
+
+		if (!awsExists || !terraformExists) && d.config.StrictMode {
+			unexpected := &UnexpectedAttributeError{ResourceID: result.ResourceID, Attribute: attrName, InAWS: awsExists}
+			if !d.config.StrictModeDetails {
+				return nil, unexpected
+			}
+			reason := interfaces.ReasonMissingInAWS
+			if awsExists {
+				reason = interfaces.ReasonMissingInTerraform
+			}
+			result.DriftDetails = append(result.DriftDetails, &interfaces.DriftDetail{
+				Attribute:     attrName,
+				ActualValue:   awsValue,
+				ExpectedValue: terraformValue,
+				Severity:      interfaces.SeverityHigh,
+				Description:   unexpected.Error(),
+				ReasonCode:    reason,
+			})
+			continue
+		}
+
 		if !awsExists {
 			result.DriftDetails = append(result.DriftDetails, &interfaces.DriftDetail{
 				Attribute:     attrName,
 				ActualValue:   nil,
 				ExpectedValue: terraformValue,
 				Description:   fmt.Sprintf("Attribute '%s' missing in AWS resource but present in Terraform configuration", attrName),
+				ReasonCode:    interfaces.ReasonMissingInAWS,
 			})
 			continue
 		}
@@ -157,26 +463,94 @@ func (d *DriftDetector) DetectDrift(awsResource interface{}, terraformConfig int
 					ExpectedValue: nil,
 					Severity:      interfaces.SeverityLow,
 					Description:   fmt.Sprintf("Attribute '%s' present in AWS resource but missing in Terraform configuration", attrName),
+					ReasonCode:    interfaces.ReasonMissingInTerraform,
 				})
 				continue
 			}
 
+		awsValue = d.dropIgnoredPaths(attrName, awsValue)
+		terraformValue = d.dropIgnoredPaths(attrName, terraformValue)
+
 		// Compare attribute values
-		config := d.getAttributeConfig(attrName)
-		isEqual, description := CompareValues(awsValue, terraformValue, config)
+		config := d.getAttributeConfig(result.ResourceType, attrName)
+		if compareRules && attrName == "security_groups" {
+			config = AttributeConfig{AttributeName: attrName, ComparisonType: ArrayUnordered, CaseSensitive: true, ReportSetDelta: config.ReportSetDelta}
+		}
+		if d.beforeCompare != nil {
+			awsValue, terraformValue = d.beforeCompare(attrName, awsValue, terraformValue)
+		}
+		var start time.Time
+		if d.config.CollectStats {
+			start = time.Now()
+		}
+		var isEqual bool
+		var description string
+		reason := interfaces.ReasonValueChanged
+		comparator, custom := d.comparators[attrName]
+		if custom {
+			isEqual, description, err = callComparator(comparator, attrName, result.ResourceID, awsValue, terraformValue)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			isEqual, description, reason = CompareValuesWithReason(awsValue, terraformValue, config)
+		}
+		if d.config.CollectStats {
+			d.stats.record(config.ComparisonType.String(), time.Since(start))
+		}
+		if d.afterCompare != nil {
+			d.afterCompare(attrName, awsValue, terraformValue, isEqual, description)
+		}
 
-		if !isEqual {
-			severity := d.determineSeverity(d.toSnakeCase(attrName), awsValue, terraformValue)
-			result.DriftDetails = append(result.DriftDetails, &interfaces.DriftDetail{
+		if isEqual && d.config.IncludeMatches {
+			result.MatchedDetails = append(result.MatchedDetails, &interfaces.DriftDetail{
 				Attribute:     attrName,
 				ActualValue:   awsValue,
 				ExpectedValue: terraformValue,
-				Severity:      toSeverityLevel(severity),
+				Severity:      interfaces.SeverityNone,
 				Description:   description,
+				Matched:       true,
 			})
 		}
+
+		if !isEqual {
+			severity := d.attributeSeverity(d.toSnakeCase(attrName), awsValue, terraformValue)
+			detail := &interfaces.DriftDetail{
+				Attribute:     attrName,
+				ActualValue:   awsValue,
+				ExpectedValue: terraformValue,
+				Severity:      severity,
+				Description:   description,
+				ReasonCode:    reason,
+			}
+			if !custom && config.ReportSetDelta && config.ComparisonType == ArrayUnordered && len(config.ComparisonChain) == 0 {
+				detail.SetDelta = setDeltaFor(awsValue, terraformValue)
+			}
+			if d.config.TraceComparisons && custom {
+				// The registered comparator decided, so the attribute config does not apply
+				detail.ComparisonTrace = &interfaces.ComparisonTrace{Comparator: "custom"}
+			} else if d.config.TraceComparisons {
+				detail.ComparisonTrace = &interfaces.ComparisonTrace{
+					Comparator:    config.ComparisonType.String(),
+					CaseSensitive: config.CaseSensitive,
+					Tolerance:     config.Tolerance,
+				}
+			}
+			result.DriftDetails = append(result.DriftDetails, detail)
+		}
+	}
+
+	if err := d.checkDeadline(deadline, awsResource); err != nil {
+		return nil, err
 	}
 
+	result.DriftDetails = append(result.DriftDetails, ruleDetails...)
+	result.DriftDetails = DedupeDriftDetails(result.DriftDetails)
+	sort.Slice(result.MatchedDetails, func(i, j int) bool {
+		return result.MatchedDetails[i].Attribute < result.MatchedDetails[j].Attribute
+	})
+	d.applySeverityFloors(result)
+
 	// Determine overall drift status
 	result.IsDrifted = len(result.DriftDetails) > 0
 	if result.IsDrifted {
@@ -191,9 +565,256 @@ func (d *DriftDetector) DetectDrift(awsResource interface{}, terraformConfig int
 		result.Severity = interfaces.SeverityNone
 	}
 
+	if d.config.ValidateResults {
+		if err := ValidateResult(result); err != nil {
+			return nil, err
+		}
+	}
+
 	return result, nil
 }
 
+// shortCircuitAllowed reports whether identical resources may skip the
+// per-attribute comparison. Anything observing or deciding individual
+// comparisons (hooks, custom comparators, stats, rule resolution) and any
+// comparison that can fail on equal values disables it.
+func (d *DriftDetector) shortCircuitAllowed() bool {
+	if d.disableShortCircuit || d.config.IncludeMatches || d.config.CollectStats {
+		return false
+	}
+	if d.beforeCompare != nil || d.afterCompare != nil || len(d.comparators) > 0 {
+		return false
+	}
+	if d.config.CompareSecurityGroupRules && d.sgRuleResolver != nil {
+		return false
+	}
+	if !reflexiveAttributeConfig(d.config.DefaultConfig) {
+		return false
+	}
+	for _, config := range d.config.AttributeConfigs {
+		if !reflexiveAttributeConfig(config) {
+			return false
+		}
+	}
+	for _, typeConfig := range d.config.ResourceTypeConfigs {
+		if typeConfig.DefaultConfig != nil && !reflexiveAttributeConfig(*typeConfig.DefaultConfig) {
+			return false
+		}
+		for _, config := range typeConfig.AttributeConfigs {
+			if !reflexiveAttributeConfig(config) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// reflexiveAttributeConfig reports whether config always treats equal values
+// as matching. RegexMatch and constrained SemverMatch validate only the AWS
+// value, so they can report drift between identical resources.
+func reflexiveAttributeConfig(config AttributeConfig) bool {
+	types := config.ComparisonChain
+	if len(types) == 0 {
+		types = []ComparisonType{config.ComparisonType}
+	}
+	for _, comparisonType := range types {
+		switch comparisonType {
+		case RegexMatch:
+			return false
+		case SemverMatch:
+			if config.VersionConstraint != "" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// resourceMapsIdentical reports whether two resource maps hash to the same
+// canonical form. Map keys are hashed in sorted order, so the result does not
+// depend on iteration order. Values that cannot be hashed are never
+// considered identical.
+func resourceMapsIdentical(a, b map[string]interface{}) bool {
+	hashA, ok := resourceMapHash(a)
+	if !ok {
+		return false
+	}
+	hashB, ok := resourceMapHash(b)
+	if !ok {
+		return false
+	}
+	return hashA == hashB
+}
+
+// resourceMapHash returns the SHA-256 of the canonical form of m
+func resourceMapHash(m map[string]interface{}) ([sha256.Size]byte, bool) {
+	buf, ok := appendCanonical(make([]byte, 0, 512), m)
+	if !ok {
+		return [sha256.Size]byte{}, false
+	}
+	return sha256.Sum256(buf), true
+}
+
+// appendCanonical appends a type-tagged, order-independent encoding of value
+// to buf. Values of different Go types never encode identically, so only
+// resources the full comparison would also find equal are short-circuited.
+// Common resource value types avoid reflection.
+func appendCanonical(buf []byte, value interface{}) ([]byte, bool) {
+	switch v := value.(type) {
+	case nil:
+		return append(buf, 'n'), true
+	case string:
+		return appendCanonicalString(buf, v), true
+	case bool:
+		return strconv.AppendBool(append(buf, 'b'), v), true
+	case []string:
+		if v == nil {
+			return append(buf, 'n'), true
+		}
+		buf = strconv.AppendInt(append(buf, 'S', '['), int64(len(v)), 10)
+		for _, item := range v {
+			buf = appendCanonicalString(buf, item)
+		}
+		return append(buf, ']'), true
+	case map[string]string:
+		if v == nil {
+			return append(buf, 'n'), true
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		buf = strconv.AppendInt(append(buf, 'S', '{'), int64(len(keys)), 10)
+		for _, key := range keys {
+			buf = appendCanonicalString(appendCanonicalString(buf, key), v[key])
+		}
+		return append(buf, '}'), true
+	case map[string]interface{}:
+		if v == nil {
+			return append(buf, 'n'), true
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		buf = strconv.AppendInt(append(buf, '{'), int64(len(keys)), 10)
+		var ok bool
+		for _, key := range keys {
+			if buf, ok = appendCanonical(appendCanonicalString(buf, key), v[key]); !ok {
+				return buf, false
+			}
+		}
+		return append(buf, '}'), true
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return append(buf, 'n'), true
+		}
+		return appendCanonical(buf, rv.Elem().Interface())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return append(strconv.AppendInt(appendCanonicalType(buf, 'i', rv), rv.Int(), 10), ';'), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return append(strconv.AppendUint(appendCanonicalType(buf, 'u', rv), rv.Uint(), 10), ';'), true
+	case reflect.Float32, reflect.Float64:
+		return append(strconv.AppendFloat(appendCanonicalType(buf, 'f', rv), rv.Float(), 'g', -1, 64), ';'), true
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return append(buf, 'n'), true
+		}
+		buf = strconv.AppendInt(append(appendCanonicalType(buf, 'a', rv), '['), int64(rv.Len()), 10)
+		var ok bool
+		for i := 0; i < rv.Len(); i++ {
+			if buf, ok = appendCanonical(buf, rv.Index(i).Interface()); !ok {
+				return buf, false
+			}
+		}
+		return append(buf, ']'), true
+	default:
+		// Structs and other values fall back to their JSON encoding, which
+		// sorts map keys
+		data, err := json.Marshal(value)
+		if err != nil {
+			return buf, false
+		}
+		buf = strconv.AppendInt(append(buf, 'j'), int64(len(data)), 10)
+		return append(append(buf, ':'), data...), true
+	}
+}
+
+// appendCanonicalType appends tag and the length-prefixed Go type name of rv
+func appendCanonicalType(buf []byte, tag byte, rv reflect.Value) []byte {
+	return appendCanonicalString(append(buf, tag), rv.Type().String())
+}
+
+// appendCanonicalString appends a length-prefixed string to buf
+func appendCanonicalString(buf []byte, s string) []byte {
+	buf = strconv.AppendInt(append(buf, 's'), int64(len(s)), 10)
+	return append(append(buf, ':'), s...)
+}
+
+// applySeverityFloors raises detail severities to the floor of any matching rule
+func (d *DriftDetector) applySeverityFloors(result *interfaces.DriftResult) {
+	if len(d.config.SeverityFloorRules) == 0 || len(result.DriftDetails) == 0 {
+		return
+	}
+
+	floor := interfaces.SeverityNone
+	for _, rule := range d.config.SeverityFloorRules {
+		if rule.Matches(result.ResourceType, result.ResourceID) && severityValue(rule.MinSeverity) > severityValue(floor) {
+			floor = rule.MinSeverity
+		}
+	}
+
+	if floor == interfaces.SeverityNone {
+		return
+	}
+
+	for _, detail := range result.DriftDetails {
+		if severityValue(detail.Severity) < severityValue(floor) {
+			detail.Severity = floor
+		}
+	}
+}
+
+// DedupeDriftDetails collapses details with the same attribute, expected
+// value and actual value into the first of them, keeping the highest
+// severity among the duplicates. Order is otherwise preserved.
+func DedupeDriftDetails(details []*interfaces.DriftDetail) []*interfaces.DriftDetail {
+	if len(details) < 2 {
+		return details
+	}
+
+	deduped := make([]*interfaces.DriftDetail, 0, len(details))
+	byAttribute := make(map[string][]*interfaces.DriftDetail)
+	for _, detail := range details {
+		if detail == nil {
+			deduped = append(deduped, detail)
+			continue
+		}
+		var kept *interfaces.DriftDetail
+		for _, seen := range byAttribute[detail.Attribute] {
+			if reflect.DeepEqual(seen.ExpectedValue, detail.ExpectedValue) && reflect.DeepEqual(seen.ActualValue, detail.ActualValue) {
+				kept = seen
+				break
+			}
+		}
+		if kept == nil {
+			byAttribute[detail.Attribute] = append(byAttribute[detail.Attribute], detail)
+			deduped = append(deduped, detail)
+			continue
+		}
+		if severityValue(detail.Severity) > severityValue(kept.Severity) {
+			kept.Severity = detail.Severity
+		}
+	}
+	return deduped
+}
+
 func toSeverityLevel(s DriftSeverity) interfaces.SeverityLevel {
 	switch s {
 	case SeverityCritical:
@@ -247,16 +868,31 @@ func (d *DriftDetector) toSnakeCase(str string) string {
 
 // DetectDriftBatch performs drift detection on multiple resource pairs concurrently
 func (d *DriftDetector) DetectDriftBatch(resourcePairs []ResourcePair) ([]*interfaces.DriftResult, error) {
+	return d.DetectDriftBatchContext(context.Background(), resourcePairs)
+}
+
+// DetectDriftBatchContext performs drift detection on multiple resource pairs
+// concurrently until ctx is cancelled. On cancellation it stops dispatching
+// work, abandons in-flight detections and returns the results completed so
+// far together with ctx.Err(); pairs without a result are nil. A pair that
+// exceeds DetectionConfig.Timeout fails on its own index like any other error.
+func (d *DriftDetector) DetectDriftBatchContext(ctx context.Context, resourcePairs []ResourcePair) ([]*interfaces.DriftResult, error) {
 	d.mu.RLock()
 	maxConcurrency := d.config.MaxConcurrency
+	deterministic := d.config.DeterministicBatch
 	d.mu.RUnlock()
 
 	if maxConcurrency <= 0 {
 		maxConcurrency = 1
 	}
 
-	// Create channels for work distribution
-	workChan := make(chan ResourcePair, len(resourcePairs))
+	if deterministic {
+		resourcePairs = d.sortPairsByResourceID(resourcePairs)
+	}
+
+	// Create channels for work distribution. Work is handed over unbuffered so
+	// nothing is queued once the context is cancelled.
+	workChan := make(chan ResourcePair)
 	resultChan := make(chan BatchResult, len(resourcePairs))
 
 	// Start workers
@@ -266,7 +902,7 @@ func (d *DriftDetector) DetectDriftBatch(resourcePairs []ResourcePair) ([]*inter
 		go func() {
 			defer wg.Done()
 			for pair := range workChan {
-				result, err := d.DetectDrift(pair.AWSResource, pair.TerraformConfig)
+				result, err := d.detectDriftContext(ctx, pair.AWSResource, pair.TerraformConfig)
 				resultChan <- BatchResult{
 					Index:  pair.Index,
 					Result: result,
@@ -277,31 +913,49 @@ func (d *DriftDetector) DetectDriftBatch(resourcePairs []ResourcePair) ([]*inter
 	}
 
 	// Send work to workers
-	go func() {
-		defer close(workChan)
-		for _, pair := range resourcePairs {
-			workChan <- pair
+dispatch:
+	for _, pair := range resourcePairs {
+		if ctx.Err() != nil {
+			break
 		}
-	}()
-
-	// Collect results
-	go func() {
-		wg.Wait()
-		close(resultChan)
-	}()
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case workChan <- pair:
+		}
+	}
+	close(workChan)
+	wg.Wait()
+	close(resultChan)
 
 	// Process results
 	results := make([]*interfaces.DriftResult, len(resourcePairs))
 	var errors []error
+	failed := make(map[int]error)
 
 	for batchResult := range resultChan {
 		if batchResult.Error != nil {
+			if deterministic {
+				failed[batchResult.Index] = batchResult.Error
+				continue
+			}
 			errors = append(errors, fmt.Errorf("index %d: %w", batchResult.Index, batchResult.Error))
 			continue
 		}
 		results[batchResult.Index] = batchResult.Result
 	}
 
+	if err := ctx.Err(); err != nil {
+		return results, err
+	}
+
+	// Report errors in dispatch order rather than completion order
+	for _, pair := range resourcePairs {
+		if err, ok := failed[pair.Index]; ok {
+			errors = append(errors, fmt.Errorf("index %d: %w", pair.Index, err))
+		}
+	}
+
 	if len(errors) > 0 {
 		return results, fmt.Errorf("batch processing errors: %v", errors)
 	}
@@ -309,6 +963,102 @@ func (d *DriftDetector) DetectDriftBatch(resourcePairs []ResourcePair) ([]*inter
 	return results, nil
 }
 
+// sortPairsByResourceID returns a copy of pairs ordered by AWS resource ID,
+// falling back to the pair index for equal or missing IDs
+func (d *DriftDetector) sortPairsByResourceID(pairs []ResourcePair) []ResourcePair {
+	d.mu.RLock()
+	ids := make(map[int]string, len(pairs))
+	for _, pair := range pairs {
+		if pair.AWSResource != nil {
+			ids[pair.Index] = d.extractResourceID(pair.AWSResource)
+		}
+	}
+	d.mu.RUnlock()
+
+	sorted := make([]ResourcePair, len(pairs))
+	copy(sorted, pairs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if ids[a.Index] != ids[b.Index] {
+			return ids[a.Index] < ids[b.Index]
+		}
+		return a.Index < b.Index
+	})
+	return sorted
+}
+
+// DetectAnyCriticalDrift reports whether any pair has critical drift. It stops
+// dispatching work and returns as soon as the first critical result is found,
+// along with the offending resource ID.
+func (d *DriftDetector) DetectAnyCriticalDrift(resourcePairs []ResourcePair) (bool, string, error) {
+	d.mu.RLock()
+	maxConcurrency := d.config.MaxConcurrency
+	d.mu.RUnlock()
+
+	if maxConcurrency <= 0 {
+		maxConcurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	workChan := make(chan ResourcePair)
+
+	var (
+		once       sync.Once
+		criticalID string
+		errMu      sync.Mutex
+		errors     []error
+		wg         sync.WaitGroup
+	)
+
+	for i := 0; i < maxConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pair := range workChan {
+				if ctx.Err() != nil {
+					continue
+				}
+				result, err := d.detectDriftContext(ctx, pair.AWSResource, pair.TerraformConfig)
+				if err != nil {
+					errMu.Lock()
+					errors = append(errors, fmt.Errorf("index %d: %w", pair.Index, err))
+					errMu.Unlock()
+					continue
+				}
+				if result.Severity == interfaces.SeverityCritical {
+					once.Do(func() {
+						criticalID = result.ResourceID
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for _, pair := range resourcePairs {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case workChan <- pair:
+		}
+	}
+	close(workChan)
+	wg.Wait()
+
+	if criticalID != "" {
+		return true, criticalID, nil
+	}
+
+	if len(errors) > 0 {
+		return false, "", fmt.Errorf("batch processing errors: %v", errors)
+	}
+
+	return false, "", nil
+}
+
 // UpdateConfig updates the detector's configuration
 func (d *DriftDetector) UpdateConfig(config DetectionConfig) {
 	d.mu.Lock()
@@ -339,14 +1089,29 @@ type BatchResult struct {
 // Helper methods
 
 func (d *DriftDetector) resourceToMap(resource interface{}) (map[string]interface{}, error) {
+	if mapper, ok := d.mappers[reflect.TypeOf(resource).String()]; ok {
+		return callMapper(mapper, resource)
+	}
+
 	switch r := resource.(type) {
 	case *aws.EC2Instance:
 		return d.ec2InstanceToMap(r), nil
+	case *aws.NATGateway:
+		return d.natGatewayToMap(r), nil
+	case *aws.InternetGateway:
+		return d.internetGatewayToMap(r), nil
+	case *aws.S3Bucket:
+		return d.s3BucketToMap(r), nil
 	case *terraform.TerraformConfig:
 		return d.terraformConfigToMap(r), nil
 	case *terraform.EC2InstanceConfig:
 		return d.ec2InstanceConfigToMap(r), nil
+	case *terraform.S3BucketConfig:
+		return d.s3BucketConfigToMap(r), nil
 	default:
+		if d.config.FailOnUnknownResourceType {
+			return nil, &UnknownResourceTypeError{Type: reflect.TypeOf(resource).String()}
+		}
 		// Use reflection as fallback
 		return d.reflectToMap(resource)
 	}
@@ -396,6 +1161,170 @@ func (d *DriftDetector) ec2InstanceToMap(instance *aws.EC2Instance) map[string]i
 	return m
 }
 
+func (d *DriftDetector) natGatewayToMap(gateway *aws.NATGateway) map[string]interface{} {
+	m := map[string]interface{}{
+		"subnet_id":         gateway.SubnetID,
+		"connectivity_type": gateway.ConnectivityType,
+		"tags":              gateway.Tags,
+	}
+
+	if gateway.AllocationID != nil {
+		m["allocation_id"] = *gateway.AllocationID
+	}
+
+	return m
+}
+
+func (d *DriftDetector) internetGatewayToMap(gateway *aws.InternetGateway) map[string]interface{} {
+	// vpc_attachment is always present so a detached gateway compares as
+	// an empty attachment rather than a missing attribute
+	return map[string]interface{}{
+		"vpc_attachment": gateway.VPCAttachment,
+		"tags":           gateway.Tags,
+	}
+}
+
+func (d *DriftDetector) s3BucketToMap(bucket *aws.S3Bucket) map[string]interface{} {
+	rules := make([]interface{}, 0, len(bucket.LifecycleRules))
+	for _, rule := range bucket.LifecycleRules {
+		rules = append(rules, lifecycleRuleMap(rule.ID, rule.Prefix, rule.Status,
+			rule.ExpirationDays, rule.TransitionDays, rule.TransitionStorageClass))
+	}
+
+	block := publicAccessBlockMap(false, false, false, false)
+	if pab := bucket.PublicAccessBlock; pab != nil {
+		block = publicAccessBlockMap(pab.BlockPublicACLs, pab.BlockPublicPolicy, pab.IgnorePublicACLs, pab.RestrictPublicBuckets)
+	}
+	var algorithm, kmsKeyID string
+	if bucket.Encryption != nil {
+		algorithm, kmsKeyID = bucket.Encryption.SSEAlgorithm, bucket.Encryption.KMSMasterKeyID
+	}
+
+	// policy, lifecycle_rules and the bucket-level settings are always present
+	// so removing them shows up as drift instead of a missing attribute
+	return map[string]interface{}{
+		"bucket":                 bucket.BucketName,
+		"versioning":             bucket.Versioning,
+		"policy":                 bucket.Policy,
+		"lifecycle_rules":        rules,
+		"public_access_block":    block,
+		"server_side_encryption": encryptionMap(algorithm, kmsKeyID),
+		"logging_target":         bucket.LoggingTarget,
+		"tags":                   bucket.Tags,
+	}
+}
+
+func (d *DriftDetector) s3BucketConfigToMap(config *terraform.S3BucketConfig) map[string]interface{} {
+	rules := make([]interface{}, 0, len(config.LifecycleRules))
+	for _, rule := range config.LifecycleRules {
+		rules = append(rules, lifecycleRuleMap(rule.ID, rule.Prefix, rule.Status,
+			rule.ExpirationDays, rule.TransitionDays, rule.TransitionStorageClass))
+	}
+
+	block := publicAccessBlockMap(false, false, false, false)
+	if pab := config.PublicAccessBlock; pab != nil {
+		block = publicAccessBlockMap(pab.BlockPublicACLs, pab.BlockPublicPolicy, pab.IgnorePublicACLs, pab.RestrictPublicBuckets)
+	}
+	var algorithm, kmsKeyID string
+	if config.ServerSideEncryption != nil {
+		algorithm, kmsKeyID = config.ServerSideEncryption.SSEAlgorithm, config.ServerSideEncryption.KMSMasterKeyID
+	}
+
+	return map[string]interface{}{
+		"bucket":                 config.Bucket,
+		"versioning":             config.Versioning,
+		"policy":                 config.Policy,
+		"lifecycle_rules":        rules,
+		"public_access_block":    block,
+		"server_side_encryption": encryptionMap(algorithm, kmsKeyID),
+		"logging_target":         config.LoggingTarget,
+		"tags":                   config.Tags,
+	}
+}
+
+// lifecycleRuleMap builds the comparable form of an S3 lifecycle rule
+func lifecycleRuleMap(id, prefix, status string, expirationDays, transitionDays *int, storageClass string) map[string]interface{} {
+	m := map[string]interface{}{
+		"id":     id,
+		"prefix": prefix,
+		"status": status,
+	}
+	if expirationDays != nil {
+		m["expiration_days"] = *expirationDays
+	}
+	if transitionDays != nil {
+		m["transition_days"] = *transitionDays
+		m["transition_storage_class"] = storageClass
+	}
+	return m
+}
+
+// publicAccessBlockMap builds the comparable form of an S3 public access
+// block; a bucket without one has every flag false
+func publicAccessBlockMap(blockPublicACLs, blockPublicPolicy, ignorePublicACLs, restrictPublicBuckets bool) map[string]interface{} {
+	return map[string]interface{}{
+		"block_public_acls":       blockPublicACLs,
+		"block_public_policy":     blockPublicPolicy,
+		"ignore_public_acls":      ignorePublicACLs,
+		"restrict_public_buckets": restrictPublicBuckets,
+	}
+}
+
+// encryptionMap builds the comparable form of a bucket's default encryption;
+// an unencrypted bucket has an empty map
+func encryptionMap(algorithm, kmsKeyID string) map[string]interface{} {
+	m := map[string]interface{}{}
+	if algorithm != "" {
+		m["sse_algorithm"] = algorithm
+	}
+	if kmsKeyID != "" {
+		m["kms_master_key_id"] = kmsKeyID
+	}
+	return m
+}
+
+// removesExpiringRule reports whether a lifecycle rule with an expiration in
+// the expected rules is missing from the actual rules
+func removesExpiringRule(actual, expected interface{}) bool {
+	actualRules, err1 := convertToSlice(actual)
+	expectedRules, err2 := convertToSlice(expected)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+
+	present := make(map[string]bool, len(actualRules))
+	for _, rule := range actualRules {
+		if m, err := convertToMap(rule); err == nil {
+			present[fmt.Sprintf("%v", m["id"])] = true
+		}
+	}
+
+	for _, rule := range expectedRules {
+		m, err := convertToMap(rule)
+		if err != nil {
+			continue
+		}
+		if _, expires := m["expiration_days"]; expires && !present[fmt.Sprintf("%v", m["id"])] {
+			return true
+		}
+	}
+	return false
+}
+
+// unknownAttributes returns the attributes a Terraform configuration could not
+// resolve statically, which are not compared
+func unknownAttributes(terraformConfig interface{}) map[string]bool {
+	config, ok := terraformConfig.(*terraform.TerraformConfig)
+	if !ok || config == nil || len(config.UnknownAttributes) == 0 {
+		return nil
+	}
+	unknown := make(map[string]bool, len(config.UnknownAttributes))
+	for _, attr := range config.UnknownAttributes {
+		unknown[attr] = true
+	}
+	return unknown
+}
+
 func (d *DriftDetector) terraformConfigToMap(config *terraform.TerraformConfig) map[string]interface{} {
 	m := map[string]interface{}{
 		"instance_id":   config.InstanceID,
@@ -502,11 +1431,24 @@ func (d *DriftDetector) extractResourceID(resource interface{}) string {
 	switch r := resource.(type) {
 	case *aws.EC2Instance:
 		return r.InstanceID
+	case *aws.NATGateway:
+		return r.NATGatewayID
+	case *aws.InternetGateway:
+		return r.InternetGatewayID
+	case *aws.S3Bucket:
+		return r.BucketName
 	case *terraform.TerraformConfig:
 		return r.ResourceID
 	case *terraform.EC2InstanceConfig:
 		return "" // EC2InstanceConfig doesn't have a resource ID
+	case *terraform.S3BucketConfig:
+		return r.Bucket
 	default:
+		// The real ARN keeps resources in different accounts and regions
+		// apart; normalization applies only to compared values
+		if arn := extractARN(resource); arn != "" {
+			return arn
+		}
 		return "unknown"
 	}
 }
@@ -515,24 +1457,71 @@ func (d *DriftDetector) extractResourceType(resource interface{}) string {
 	switch resource.(type) {
 	case *aws.EC2Instance:
 		return "aws_instance"
+	case *aws.NATGateway:
+		return "aws_nat_gateway"
+	case *aws.InternetGateway:
+		return "aws_internet_gateway"
+	case *aws.S3Bucket:
+		return "aws_s3_bucket"
 	case *terraform.TerraformConfig:
 		return "terraform_config"
 	case *terraform.EC2InstanceConfig:
 		return "ec2_instance_config"
+	case *terraform.S3BucketConfig:
+		return "aws_s3_bucket"
 	default:
 		return reflect.TypeOf(resource).String()
 	}
 }
 
+// terraformDeclaredType returns the resource type a Terraform config declares,
+// or "" when the config does not identify its type
+func terraformDeclaredType(config interface{}) string {
+	switch c := config.(type) {
+	case *terraform.EC2InstanceConfig:
+		return "aws_instance"
+	case *terraform.S3BucketConfig:
+		return "aws_s3_bucket"
+	case *terraform.TerraformConfig:
+		// Resource IDs look like "aws_instance.web" or "module.app.aws_instance.web"
+		address, err := terraform.ParseResourceAddress(c.ResourceID)
+		if err != nil {
+			return ""
+		}
+		return address.Type
+	default:
+		return ""
+	}
+}
+
+// validateResourceTypes rejects pairs whose AWS and declared Terraform types
+// differ. Pairs where either side has no known type are allowed through.
+func (d *DriftDetector) validateResourceTypes(awsResource, terraformConfig interface{}) error {
+	declared := terraformDeclaredType(terraformConfig)
+	if declared == "" || !strings.HasPrefix(declared, "aws_") {
+		return nil
+	}
+
+	actual := d.extractResourceType(awsResource)
+	if !strings.HasPrefix(actual, "aws_") {
+		return nil
+	}
+
+	if actual != declared {
+		return &ResourceTypeMismatchError{AWSType: actual, TerraformType: declared}
+	}
+	return nil
+}
+
 func (d *DriftDetector) getAllAttributeNames(awsMap, terraformMap map[string]interface{}) []string {
 	attributeSet := make(map[string]bool)
 
 	for name := range awsMap {
-		attributeSet[name] = true
+		attributeSet[d.canonicalAttributeName(name)] = true
 	}
 
 	for name := range terraformMap {
-		attributeSet[name] = true
+		attributeSet[d.canonicalAttributeName(name)] = true
 	}
 
 	attributes := make([]string, 0, len(attributeSet))
@@ -543,20 +1532,183 @@ func (d *DriftDetector) getAllAttributeNames(awsMap, terraformMap map[string]int
 	return attributes
 }
 
+// canonicalAttributeName returns the logical name for an attribute that is
+// listed as an alias, or the name itself. Canonical names are tried in sorted
+// order, so an alias listed under several names always resolves the same way.
+func (d *DriftDetector) canonicalAttributeName(name string) string {
+	if len(d.config.AttributeAliases) == 0 {
+		return name
+	}
+	canonicals := make([]string, 0, len(d.config.AttributeAliases))
+	for canonical := range d.config.AttributeAliases {
+		canonicals = append(canonicals, canonical)
+	}
+	sort.Strings(canonicals)
+
+	for _, canonical := range canonicals {
+		for _, alias := range d.config.AttributeAliases[canonical] {
+			if alias == name {
+				return canonical
+			}
+		}
+	}
+	return name
+}
+
+// lookupAttribute finds the value of a logical attribute in m, trying the
+// canonical name first and then each of its aliases
+func (d *DriftDetector) lookupAttribute(m map[string]interface{}, name string) (interface{}, bool) {
+	if value, ok := m[name]; ok {
+		return value, true
+	}
+	for _, alias := range d.config.AttributeAliases[name] {
+		if value, ok := m[alias]; ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// shouldIgnoreAttribute reports whether attrName is listed in
+// IgnoredAttributes. Entries containing '*' are matched with matchGlob.
 func (d *DriftDetector) shouldIgnoreAttribute(attrName string) bool {
 	for _, ignored := range d.config.IgnoredAttributes {
 		if attrName == ignored {
 			return true
 		}
+		if strings.Contains(ignored, "*") && matchGlob(ignored, attrName) {
+			return true
+		}
 	}
 	return false
 }
 
-func (d *DriftDetector) getAttributeConfig(attrName string) AttributeConfig {
-	if config, exists := d.config.AttributeConfigs[attrName]; exists {
-		return config
+// dropIgnoredPaths returns value without the nested map entries and array
+// elements whose path below attrName (e.g. tags.Name or
+// block_device_mappings[0].iops) is ignored. value itself is not modified.
+func (d *DriftDetector) dropIgnoredPaths(attrName string, value interface{}) interface{} {
+	for _, ignored := range d.config.IgnoredAttributes {
+		if strings.HasPrefix(ignored, "*") || strings.HasPrefix(ignored, attrName+".") || strings.HasPrefix(ignored, attrName+"[") {
+			return d.dropIgnoredPathsAt(attrName, value)
+		}
+	}
+	return value
+}
+
+func (d *DriftDetector) dropIgnoredPathsAt(path string, value interface{}) interface{} {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.IsNil() || rv.Type().Key().Kind() != reflect.String {
+			return value
+		}
+		kept := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			childPath := path + "." + iter.Key().String()
+			if d.shouldIgnoreAttribute(childPath) {
+				continue
+			}
+			kept.SetMapIndex(iter.Key(), d.keepNested(childPath, iter.Value(), rv.Type().Elem()))
+		}
+		return kept.Interface()
+	case reflect.Slice:
+		if rv.IsNil() {
+			return value
+		}
+		kept := reflect.MakeSlice(rv.Type(), 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			childPath := path + "[" + strconv.Itoa(i) + "]"
+			if d.shouldIgnoreAttribute(childPath) {
+				continue
+			}
+			kept = reflect.Append(kept, d.keepNested(childPath, rv.Index(i), rv.Type().Elem()))
+		}
+		return kept.Interface()
+	default:
+		return value
+	}
+}
+
+// keepNested strips ignored paths from a map or slice element, keeping the
+// original element when the result does not fit the container type
+func (d *DriftDetector) keepNested(path string, elem reflect.Value, elemType reflect.Type) reflect.Value {
+	if elem.Kind() == reflect.Interface && elem.IsNil() {
+		return elem
+	}
+	stripped := reflect.ValueOf(d.dropIgnoredPathsAt(path, elem.Interface()))
+	if !stripped.IsValid() || !stripped.Type().AssignableTo(elemType) {
+		return elem
+	}
+	return stripped
+}
+
+// matchGlob reports whether name matches pattern, where '*' matches any
+// sequence of characters, including '.', '[' and ']', and every other
+// character matches itself. Unlike path.Match, brackets are literal so
+// patterns such as block_device_mappings[*].iops work as written.
+func matchGlob(pattern, name string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == name
+	}
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
 	}
-	return d.config.DefaultConfig
+	name = name[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(name, part)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(part):]
+	}
+	return strings.HasSuffix(name, last)
+}
+
+// isSelectedAttribute reports whether attrName is in OnlyAttributes, or true
+// when OnlyAttributes is empty
+func (d *DriftDetector) isSelectedAttribute(attrName string) bool {
+	if len(d.config.OnlyAttributes) == 0 {
+		return true
+	}
+	for _, selected := range d.config.OnlyAttributes {
+		if attrName == selected {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *DriftDetector) getAttributeConfig(resourceType, attrName string) AttributeConfig {
+	typeConfig, hasTypeConfig := d.config.ResourceTypeConfigs[resourceType]
+	config, exists := typeConfig.AttributeConfigs[attrName]
+	if !exists {
+		config, exists = d.config.AttributeConfigs[attrName]
+	}
+	if !exists {
+		config = d.config.DefaultConfig
+		if hasTypeConfig && typeConfig.DefaultConfig != nil {
+			config = *typeConfig.DefaultConfig
+		}
+	}
+	if config.FloatPrecision == nil {
+		config.FloatPrecision = d.config.FloatPrecision
+	}
+	if d.config.EmptyEqualsAbsent {
+		config.EmptyEqualsAbsent = true
+	}
+	return config
+}
+
+// attributeSeverity returns the configured override for attrName, or the
+// built-in severity for the difference
+func (d *DriftDetector) attributeSeverity(attrName string, awsValue, terraformValue interface{}) interfaces.SeverityLevel {
+	if severity, ok := d.config.SeverityOverrides[attrName]; ok {
+		return severity
+	}
+	return toSeverityLevel(d.determineSeverity(attrName, awsValue, terraformValue))
 }
 
 func (d *DriftDetector) determineSeverity(attrName string, awsValue, terraformValue interface{}) DriftSeverity {
@@ -568,6 +1720,10 @@ func (d *DriftDetector) determineSeverity(attrName string, awsValue, terraformVa
 		"vpc_id":                  true,
 		"subnet_id":               true,
 		"disable_api_termination": true,
+		"vpc_attachment":          true,
+		"policy":                  true,
+		"public_access_block":     true,
+		"server_side_encryption":  true,
 	}
 
 	// High priority attributes
@@ -581,6 +1737,9 @@ func (d *DriftDetector) determineSeverity(attrName string, awsValue, terraformVa
 		"placement_group":                      true,
 		"root_device_type":                     true,
 		"block_device_mappings":                true,
+		"connectivity_type":                    true,
+		"allocation_id":                        true,
+		"versioning":                           true,
 	}
 
 	// Medium priority attributes
@@ -590,11 +1749,19 @@ func (d *DriftDetector) determineSeverity(attrName string, awsValue, terraformVa
 		"cpu_core_count":       true,
 		"cpu_threads_per_core": true,
 		"root_device_name":     true,
+		"logging_target":       true,
 	}
 
 	if criticalAttrs[attrName] {
 		return SeverityCritical
 	}
+	if attrName == "lifecycle_rules" {
+		// Losing an expiration rule lets objects accumulate indefinitely
+		if removesExpiringRule(awsValue, terraformValue) {
+			return SeverityHigh
+		}
+		return SeverityMedium
+	}
 	if highAttrs[attrName] {
 		return SeverityHigh
 	}
@@ -1,6 +1,13 @@
 package drift
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"testing"
 
 	"firefly-task/aws"
@@ -149,9 +156,9 @@ func TestDetectDrift_IdenticalResources(t *testing.T) {
 	}
 }
 
-func TestDetectDrift_WithDifferences(t *testing.T) {
-	detector := NewDriftDetector(DefaultDetectionConfig())
-
+// createMixedDriftPair returns an instance and configuration that drift on
+// attributes of every severity
+func createMixedDriftPair() (*aws.EC2Instance, *terraform.TerraformConfig) {
 	subnetID2 := "subnet-12345678"
 	vpcID2 := "vpc-12345678"
 	availabilityZone2 := "us-west-2a"
@@ -191,6 +198,13 @@ func TestDetectDrift_WithDifferences(t *testing.T) {
 		Monitoring: &[]bool{true}[0], // Different from AWS (false)
 	}
 
+	return awsInstance, terraformConfig
+}
+
+func TestDetectDrift_WithDifferences(t *testing.T) {
+	detector := NewDriftDetector(DefaultDetectionConfig())
+	awsInstance, terraformConfig := createMixedDriftPair()
+
 	result, err := detector.DetectDrift(awsInstance, terraformConfig)
 	if err != nil {
 		t.Fatalf("DetectDrift() error = %v", err)
@@ -634,7 +648,7 @@ func TestGetAttributeConfig(t *testing.T) {
 	detector := NewDriftDetector(config)
 
 	// Test existing attribute config
-	attrConfig := detector.getAttributeConfig("custom_attr")
+	attrConfig := detector.getAttributeConfig("aws_instance", "custom_attr")
 	if attrConfig.ComparisonType != FuzzyMatch {
 		t.Errorf("Expected FuzzyMatch, got %v", attrConfig.ComparisonType)
 	}
@@ -643,12 +657,203 @@ func TestGetAttributeConfig(t *testing.T) {
 	}
 
 	// Test non-existing attribute config (should return default)
-	defaultConfig := detector.getAttributeConfig("non_existing_attr")
+	defaultConfig := detector.getAttributeConfig("aws_instance", "non_existing_attr")
 	if defaultConfig.ComparisonType != config.DefaultConfig.ComparisonType {
 		t.Errorf("Expected default comparison type %v, got %v", config.DefaultConfig.ComparisonType, defaultConfig.ComparisonType)
 	}
 }
 
+func TestDetectDrift_EmptyEqualsAbsent(t *testing.T) {
+	newDetector := func(config DetectionConfig) *DriftDetector {
+		detector := NewDriftDetector(config)
+		detector.RegisterResourceMapper("*drift.reasonResource", func(resource interface{}) (map[string]interface{}, error) {
+			return resource.(*reasonResource).attrs, nil
+		})
+		return detector
+	}
+	emptyTags := &reasonResource{attrs: map[string]interface{}{"instance_type": "t3.micro", "tags": map[string]string{}}}
+	noTags := &reasonResource{attrs: map[string]interface{}{"instance_type": "t3.micro"}}
+	withTags := &reasonResource{attrs: map[string]interface{}{"instance_type": "t3.micro", "tags": map[string]string{"Env": "prod"}}}
+
+	result, err := newDetector(DefaultDetectionConfig()).DetectDrift(emptyTags, noTags)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if !result.IsDrifted {
+		t.Fatal("Expected empty vs absent tags to drift without EmptyEqualsAbsent")
+	}
+
+	global := DefaultDetectionConfig()
+	global.EmptyEqualsAbsent = true
+	perAttribute := DefaultDetectionConfig()
+	tagsConfig := perAttribute.AttributeConfigs["tags"]
+	tagsConfig.EmptyEqualsAbsent = true
+	perAttribute.AttributeConfigs["tags"] = tagsConfig
+
+	for name, config := range map[string]DetectionConfig{"global": global, "per-attribute": perAttribute} {
+		detector := newDetector(config)
+
+		result, err := detector.DetectDrift(emptyTags, noTags)
+		if err != nil {
+			t.Fatalf("%s: DetectDrift() unexpected error: %v", name, err)
+		}
+		if result.IsDrifted {
+			t.Errorf("%s: expected empty AWS tags to match absent Terraform tags, got %+v", name, result.DriftDetails)
+		}
+
+		result, err = detector.DetectDrift(emptyTags, withTags)
+		if err != nil {
+			t.Fatalf("%s: DetectDrift() unexpected error: %v", name, err)
+		}
+		if !result.IsDrifted || result.DriftDetails[0].Attribute != "tags" {
+			t.Errorf("%s: expected removed tags to still be reported as drift", name)
+		}
+	}
+}
+
+// otherResource is a second mapped resource type for per-type config tests
+type otherResource struct{ attrs map[string]interface{} }
+
+func TestResourceTypeDefaultConfig(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.ResourceTypeConfigs = map[string]ResourceTypeConfig{
+		"*drift.reasonResource": {DefaultConfig: &AttributeConfig{ComparisonType: FuzzyMatch}},
+	}
+	detector := NewDriftDetector(config)
+	detector.RegisterResourceMapper("*drift.reasonResource", func(resource interface{}) (map[string]interface{}, error) {
+		return resource.(*reasonResource).attrs, nil
+	})
+	detector.RegisterResourceMapper("*drift.otherResource", func(resource interface{}) (map[string]interface{}, error) {
+		return resource.(*otherResource).attrs, nil
+	})
+
+	result, err := detector.DetectDrift(
+		&reasonResource{attrs: map[string]interface{}{"owner_label": "Web-Team"}},
+		&reasonResource{attrs: map[string]interface{}{"owner_label": "web-team"}},
+	)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if result.IsDrifted {
+		t.Errorf("Expected fuzzy type default to ignore case, got %+v", result.DriftDetails)
+	}
+
+	result, err = detector.DetectDrift(
+		&otherResource{attrs: map[string]interface{}{"owner_label": "Web-Team"}},
+		&otherResource{attrs: map[string]interface{}{"owner_label": "web-team"}},
+	)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if !result.IsDrifted {
+		t.Error("Expected the global exact default to report the case difference")
+	}
+
+	file := DetectionConfigFileFromConfig(config)
+	if got := file.ResourceTypes["*drift.reasonResource"].DefaultConfig; got == nil || got.ComparisonType != "fuzzy_match" {
+		t.Fatalf("Expected type default to serialize as fuzzy_match, got %+v", got)
+	}
+	if roundTrip := file.ToDetectionConfig(); roundTrip.ResourceTypeConfigs["*drift.reasonResource"].DefaultConfig.ComparisonType != FuzzyMatch {
+		t.Error("Expected type default to survive a config file round trip")
+	}
+}
+
+func TestDetectDrift_UnknownTerraformAttributes(t *testing.T) {
+	detector := NewDriftDetector(DefaultDetectionConfig())
+	awsInstance, terraformConfig := createMixedDriftPair()
+	// instance_type and tags reference variables in the .tf source
+	terraformConfig.InstanceType = ""
+	terraformConfig.Tags = nil
+	terraformConfig.UnknownAttributes = []string{"instance_type", "tags"}
+
+	result, err := detector.DetectDrift(awsInstance, terraformConfig)
+	if err != nil {
+		t.Fatalf("DetectDrift() error = %v", err)
+	}
+	for _, detail := range result.DriftDetails {
+		if detail.Attribute == "instance_type" || detail.Attribute == "tags" {
+			t.Errorf("Expected unknown attribute %s to be skipped, got %s", detail.Attribute, detail.Description)
+		}
+	}
+	if !result.IsDrifted {
+		t.Error("Expected known attributes to still be compared")
+	}
+
+	explanation, err := detector.ExplainDrift(awsInstance, terraformConfig)
+	if err != nil {
+		t.Fatalf("ExplainDrift() error = %v", err)
+	}
+	for _, decision := range explanation.Decisions {
+		if decision.Attribute == "instance_type" && decision.Decision != DecisionIgnored {
+			t.Errorf("Expected unknown instance_type to be explained as ignored, got %+v", decision)
+		}
+	}
+}
+
+func TestResourceTypeAttributeConfigs(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.AttributeConfigs["owner_label"] = AttributeConfig{ComparisonType: ExactMatch, CaseSensitive: true}
+	config.ResourceTypeConfigs = map[string]ResourceTypeConfig{
+		"*drift.reasonResource": {AttributeConfigs: map[string]AttributeConfig{
+			"owner_label": {ComparisonType: FuzzyMatch},
+		}},
+	}
+	detector := NewDriftDetector(config)
+	detector.RegisterResourceMapper("*drift.reasonResource", func(resource interface{}) (map[string]interface{}, error) {
+		return resource.(*reasonResource).attrs, nil
+	})
+	detector.RegisterResourceMapper("*drift.otherResource", func(resource interface{}) (map[string]interface{}, error) {
+		return resource.(*otherResource).attrs, nil
+	})
+
+	result, err := detector.DetectDrift(
+		&reasonResource{attrs: map[string]interface{}{"owner_label": "Web-Team"}},
+		&reasonResource{attrs: map[string]interface{}{"owner_label": "web-team"}},
+	)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if result.IsDrifted {
+		t.Errorf("Expected the type override to ignore case, got %+v", result.DriftDetails)
+	}
+
+	result, err = detector.DetectDrift(
+		&otherResource{attrs: map[string]interface{}{"owner_label": "Web-Team"}},
+		&otherResource{attrs: map[string]interface{}{"owner_label": "web-team"}},
+	)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if !result.IsDrifted {
+		t.Error("Expected the global attribute config to apply to other resource types")
+	}
+
+	file := DetectionConfigFileFromConfig(config)
+	if got := file.ResourceTypes["*drift.reasonResource"].AttributeConfigs["owner_label"]; got.ComparisonType != "fuzzy_match" {
+		t.Fatalf("Expected type override to serialize as fuzzy_match, got %+v", got)
+	}
+	roundTrip := file.ToDetectionConfig()
+	if got := roundTrip.ResourceTypeConfigs["*drift.reasonResource"].AttributeConfigs["owner_label"].ComparisonType; got != FuzzyMatch {
+		t.Errorf("Expected type override to survive a config file round trip, got %s", got)
+	}
+
+	changes := DiffConfigs(DefaultDetectionConfig(), config)
+	var found bool
+	for _, change := range changes {
+		if change.Field == "resource_type_configs.*drift.reasonResource.attribute_configs.owner_label" && change.Kind == ConfigAdded {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected DiffConfigs to report the type override, got %v", changes)
+	}
+
+	config.ResourceTypeConfigs["*drift.reasonResource"].AttributeConfigs["owner_label"] = AttributeConfig{ComparisonType: NumericTolerance}
+	if err := NewConfigValidator().ValidateConfig(config); err == nil {
+		t.Error("Expected an invalid type override to fail validation")
+	}
+}
+
 func TestGetAllAttributeNames(t *testing.T) {
 	detector := NewDriftDetector(DefaultDetectionConfig())
 
@@ -687,3 +892,1198 @@ func TestGetAllAttributeNames(t *testing.T) {
 		}
 	}
 }
+
+func TestDetectDrift_UnknownResourceType(t *testing.T) {
+	type unsupportedResource struct {
+		BucketName string
+	}
+
+	awsResource := &unsupportedResource{BucketName: "bucket"}
+	terraformConfig := &unsupportedResource{BucketName: "bucket"}
+
+	// Reflection fallback remains the default
+	detector := NewDriftDetector(DefaultDetectionConfig())
+	if _, err := detector.DetectDrift(awsResource, terraformConfig); err != nil {
+		t.Fatalf("Expected reflection fallback without error, got %v", err)
+	}
+
+	config := DefaultDetectionConfig()
+	config.FailOnUnknownResourceType = true
+	detector = NewDriftDetector(config)
+
+	_, err := detector.DetectDrift(awsResource, terraformConfig)
+	if err == nil {
+		t.Fatal("Expected error for unsupported resource type")
+	}
+
+	var typeErr *UnknownResourceTypeError
+	if !errors.As(err, &typeErr) {
+		t.Fatalf("Expected UnknownResourceTypeError, got %T: %v", err, err)
+	}
+	if !strings.Contains(typeErr.Type, "unsupportedResource") {
+		t.Errorf("Expected error to name the unsupported type, got %q", typeErr.Type)
+	}
+}
+
+func TestApplySeverityFloors(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.SeverityFloorRules = []SeverityFloorRule{
+		{ResourcePattern: "aws_iam_*", MinSeverity: interfaces.SeverityHigh},
+	}
+	detector := NewDriftDetector(config)
+
+	iamResult := &interfaces.DriftResult{
+		ResourceID:   "admin-role",
+		ResourceType: "aws_iam_role",
+		DriftDetails: []*interfaces.DriftDetail{
+			{Attribute: "description", Severity: interfaces.SeverityLow},
+			{Attribute: "assume_role_policy", Severity: interfaces.SeverityCritical},
+		},
+	}
+	detector.applySeverityFloors(iamResult)
+
+	if iamResult.DriftDetails[0].Severity != interfaces.SeverityHigh {
+		t.Errorf("Expected low severity raised to high, got %v", iamResult.DriftDetails[0].Severity)
+	}
+	if iamResult.DriftDetails[1].Severity != interfaces.SeverityCritical {
+		t.Errorf("Expected critical severity untouched, got %v", iamResult.DriftDetails[1].Severity)
+	}
+
+	instanceResult := &interfaces.DriftResult{
+		ResourceID:   "i-123",
+		ResourceType: "aws_instance",
+		DriftDetails: []*interfaces.DriftDetail{
+			{Attribute: "tags", Severity: interfaces.SeverityMedium},
+		},
+	}
+	detector.applySeverityFloors(instanceResult)
+
+	if instanceResult.DriftDetails[0].Severity != interfaces.SeverityMedium {
+		t.Errorf("Expected non-matching resource untouched, got %v", instanceResult.DriftDetails[0].Severity)
+	}
+}
+
+func TestDetectDrift_SeverityFloor(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.SeverityFloorRules = []SeverityFloorRule{
+		{ResourcePattern: "aws_instance", MinSeverity: interfaces.SeverityHigh},
+	}
+	detector := NewDriftDetector(config)
+
+	awsInstance := &aws.EC2Instance{
+		InstanceID:   "i-123",
+		InstanceType: "t3.micro",
+		Tags:         map[string]string{"Name": "web"},
+	}
+	terraformConfig := &terraform.TerraformConfig{
+		InstanceID:   "i-123",
+		InstanceType: "t3.micro",
+		Tags:         map[string]string{"Name": "api"},
+	}
+
+	result, err := detector.DetectDrift(awsInstance, terraformConfig)
+	if err != nil {
+		t.Fatalf("DetectDrift() error = %v", err)
+	}
+
+	if result.Severity != interfaces.SeverityHigh {
+		t.Errorf("Expected tag drift raised to high severity, got %v", result.Severity)
+	}
+}
+
+func TestDetectDrift_ComparisonTrace(t *testing.T) {
+	type volume struct {
+		VolumeID   string
+		Throughput float64
+	}
+
+	config := DefaultDetectionConfig()
+	config.AttributeConfigs["throughput"] = *NewAttributeConfig("throughput", NumericTolerance).WithTolerance(5)
+	config.TraceComparisons = true
+	detector := NewDriftDetector(config)
+
+	result, err := detector.DetectDrift(&volume{VolumeID: "vol-1", Throughput: 250}, &volume{VolumeID: "vol-1", Throughput: 125})
+	if err != nil {
+		t.Fatalf("DetectDrift() error = %v", err)
+	}
+
+	if len(result.DriftDetails) != 1 {
+		t.Fatalf("Expected 1 drift detail, got %d", len(result.DriftDetails))
+	}
+
+	trace := result.DriftDetails[0].ComparisonTrace
+	if trace == nil {
+		t.Fatal("Expected comparison trace when TraceComparisons is set")
+	}
+	if trace.Comparator != NumericTolerance.String() {
+		t.Errorf("Expected comparator %q, got %q", NumericTolerance.String(), trace.Comparator)
+	}
+	if trace.Tolerance == nil || *trace.Tolerance != 5 {
+		t.Errorf("Expected tolerance 5, got %v", trace.Tolerance)
+	}
+
+	config.TraceComparisons = false
+	detector.UpdateConfig(config)
+	result, err = detector.DetectDrift(&volume{VolumeID: "vol-1", Throughput: 250}, &volume{VolumeID: "vol-1", Throughput: 125})
+	if err != nil {
+		t.Fatalf("DetectDrift() error = %v", err)
+	}
+	if result.DriftDetails[0].ComparisonTrace != nil {
+		t.Error("Expected no comparison trace when TraceComparisons is unset")
+	}
+
+	config.TraceComparisons = true
+	detector.UpdateConfig(config)
+	detector.RegisterComparator("throughput", func(actual, expected interface{}) (bool, string) {
+		return false, "throughput differs"
+	})
+	result, err = detector.DetectDrift(&volume{VolumeID: "vol-1", Throughput: 250}, &volume{VolumeID: "vol-1", Throughput: 125})
+	if err != nil {
+		t.Fatalf("DetectDrift() error = %v", err)
+	}
+	trace = result.DriftDetails[0].ComparisonTrace
+	if trace == nil || trace.Comparator != "custom" {
+		t.Errorf("Expected custom comparator in trace, got %+v", trace)
+	}
+	if want := detector.comparatorName("", "throughput"); trace != nil && trace.Comparator != want {
+		t.Errorf("Expected trace comparator to match Explain's %q, got %q", want, trace.Comparator)
+	}
+}
+
+func TestDetectAnyCriticalDrift(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.MaxConcurrency = 1
+	config.CollectStats = true
+	detector := NewDriftDetector(config)
+
+	clean := func(id string) ResourcePair {
+		return ResourcePair{
+			AWSResource:     &aws.EC2Instance{InstanceID: id, InstanceType: "t3.micro"},
+			TerraformConfig: &terraform.TerraformConfig{InstanceID: id, InstanceType: "t3.micro"},
+		}
+	}
+
+	pairs := []ResourcePair{{
+		AWSResource:     &aws.EC2Instance{InstanceID: "i-critical", InstanceType: "t3.large"},
+		TerraformConfig: &terraform.TerraformConfig{InstanceID: "i-critical", InstanceType: "t3.micro"},
+	}}
+	for i := 0; i < 50; i++ {
+		pairs = append(pairs, clean("i-clean"))
+	}
+	for i := range pairs {
+		pairs[i].Index = i
+	}
+
+	found, resourceID, err := detector.DetectAnyCriticalDrift(pairs)
+	if err != nil {
+		t.Fatalf("DetectAnyCriticalDrift() error = %v", err)
+	}
+	if !found {
+		t.Fatal("Expected critical drift to be found")
+	}
+	if resourceID != "i-critical" {
+		t.Errorf("Expected resource i-critical, got %s", resourceID)
+	}
+
+	// Each detection performs two exact comparisons (instance_id, instance_type)
+	compared := detector.DetectionStats()[ExactMatch.String()].Count / 2
+	if compared >= len(pairs) {
+		t.Errorf("Expected early exit, but %d of %d pairs were compared", compared, len(pairs))
+	}
+
+	found, resourceID, err = detector.DetectAnyCriticalDrift([]ResourcePair{clean("i-1"), clean("i-2")})
+	if err != nil {
+		t.Fatalf("DetectAnyCriticalDrift() error = %v", err)
+	}
+	if found || resourceID != "" {
+		t.Errorf("Expected no critical drift, got %v %q", found, resourceID)
+	}
+}
+
+func TestDetectDrift_InternetGatewayDetached(t *testing.T) {
+	detector := NewDriftDetector(DefaultDetectionConfig())
+
+	actual := &aws.InternetGateway{
+		InternetGatewayID: "igw-123",
+		Tags:              map[string]string{"Name": "main"},
+	}
+	expected := &aws.InternetGateway{
+		InternetGatewayID: "igw-123",
+		VPCAttachment:     "vpc-123",
+		Tags:              map[string]string{"Name": "main"},
+	}
+
+	result, err := detector.DetectDrift(actual, expected)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.ResourceType != "aws_internet_gateway" {
+		t.Errorf("Expected resource type aws_internet_gateway, got %s", result.ResourceType)
+	}
+	if result.ResourceID != "igw-123" {
+		t.Errorf("Expected resource ID igw-123, got %s", result.ResourceID)
+	}
+	if !result.IsDrifted || len(result.DriftDetails) != 1 {
+		t.Fatalf("Expected exactly one drift detail, got %d", len(result.DriftDetails))
+	}
+	if result.DriftDetails[0].Attribute != "vpc_attachment" {
+		t.Errorf("Expected vpc_attachment drift, got %s", result.DriftDetails[0].Attribute)
+	}
+	if result.Severity != interfaces.SeverityCritical {
+		t.Errorf("Expected critical severity for a detached gateway, got %s", result.Severity)
+	}
+}
+
+func TestDetectDrift_S3PolicyAndLifecycle(t *testing.T) {
+	detector := NewDriftDetector(DefaultDetectionConfig())
+
+	days := func(n int) *int { return &n }
+	bucket := func(policy string, rules ...aws.S3LifecycleRule) *aws.S3Bucket {
+		return &aws.S3Bucket{
+			BucketName:     "logs",
+			Policy:         policy,
+			LifecycleRules: rules,
+			Tags:           map[string]string{"Name": "logs"},
+		}
+	}
+	expireLogs := aws.S3LifecycleRule{ID: "expire-logs", Status: aws.LifecycleRuleEnabled, ExpirationDays: days(30)}
+	archive := aws.S3LifecycleRule{ID: "archive", Status: aws.LifecycleRuleEnabled, TransitionDays: days(90), TransitionStorageClass: "GLACIER"}
+
+	expectedPolicy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject","s3:PutObject"]},{"Effect":"Deny","Action":"s3:DeleteObject"}]}`
+	reorderedPolicy := `{
+		"Statement": [
+			{"Action": "s3:DeleteObject", "Effect": "Deny"},
+			{"Action": ["s3:PutObject", "s3:GetObject"], "Effect": "Allow"}
+		],
+		"Version": "2012-10-17"
+	}`
+
+	result, err := detector.DetectDrift(bucket(reorderedPolicy, archive, expireLogs), bucket(expectedPolicy, expireLogs, archive))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.IsDrifted {
+		t.Errorf("Expected reordered policy and rules to match, got %+v", result.DriftDetails[0])
+	}
+	if result.ResourceType != "aws_s3_bucket" || result.ResourceID != "logs" {
+		t.Errorf("Unexpected resource identity %s/%s", result.ResourceType, result.ResourceID)
+	}
+
+	changed := expireLogs
+	changed.ExpirationDays = days(90)
+	result, err = detector.DetectDrift(bucket(expectedPolicy, changed, archive), bucket(expectedPolicy, expireLogs, archive))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.DriftDetails) != 1 || result.DriftDetails[0].Attribute != "lifecycle_rules" {
+		t.Fatalf("Expected a single lifecycle_rules drift, got %+v", result.DriftDetails)
+	}
+	if desc := result.DriftDetails[0].Description; !strings.Contains(desc, "'expire-logs' changed: expiration_days") {
+		t.Errorf("Expected description to name the changed rule, got %q", desc)
+	}
+	if result.DriftDetails[0].Severity != interfaces.SeverityMedium {
+		t.Errorf("Expected medium severity for a changed rule, got %s", result.DriftDetails[0].Severity)
+	}
+
+	result, err = detector.DetectDrift(bucket(expectedPolicy, archive), bucket(expectedPolicy, expireLogs, archive))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.DriftDetails) != 1 || result.DriftDetails[0].Severity != interfaces.SeverityHigh {
+		t.Fatalf("Expected a high severity drift for a removed expiration rule, got %+v", result.DriftDetails)
+	}
+	if desc := result.DriftDetails[0].Description; !strings.Contains(desc, "'expire-logs' removed") {
+		t.Errorf("Expected description to name the removed rule, got %q", desc)
+	}
+}
+
+func TestDetectDrift_S3BucketWithDifferences(t *testing.T) {
+	detector := NewDriftDetector(DefaultDetectionConfig())
+
+	awsBucket := &aws.S3Bucket{
+		BucketName: "app-logs",
+		Versioning: false, // Suspended outside Terraform
+		Tags: map[string]string{
+			"Name":        "app-logs",
+			"Environment": "prod", // Different value
+		},
+		PublicAccessBlock: &aws.S3PublicAccessBlock{
+			BlockPublicACLs:   true,
+			BlockPublicPolicy: false, // Loosened
+			IgnorePublicACLs:  true,
+		},
+		Encryption:    &aws.S3Encryption{SSEAlgorithm: "AES256"},
+		LoggingTarget: "audit-logs",
+	}
+
+	terraformBucket := &terraform.S3BucketConfig{
+		ResourceName: "logs",
+		Bucket:       "app-logs",
+		Versioning:   true,
+		Tags: map[string]string{
+			"Name":        "app-logs",
+			"Environment": "dev",
+		},
+		PublicAccessBlock: &terraform.S3PublicAccessBlockConfig{
+			BlockPublicACLs:   true,
+			BlockPublicPolicy: true,
+			IgnorePublicACLs:  true,
+		},
+		ServerSideEncryption: &terraform.S3EncryptionConfig{SSEAlgorithm: "AES256"},
+		LoggingTarget:        "audit-logs",
+	}
+
+	result, err := detector.DetectDrift(awsBucket, terraformBucket)
+	if err != nil {
+		t.Fatalf("DetectDrift() error = %v", err)
+	}
+
+	if !result.IsDrifted {
+		t.Error("Expected drift to be detected")
+	}
+	if result.ResourceType != "aws_s3_bucket" || result.ResourceID != "app-logs" {
+		t.Errorf("Unexpected resource identity %s/%s", result.ResourceType, result.ResourceID)
+	}
+	if result.Severity != interfaces.SeverityCritical {
+		t.Errorf("Expected critical severity for a loosened public access block, got %v", result.Severity)
+	}
+
+	want := map[string]interfaces.SeverityLevel{
+		"public_access_block": interfaces.SeverityCritical,
+		"versioning":          interfaces.SeverityHigh,
+		"tags":                interfaces.SeverityMedium,
+	}
+	found := make(map[string]bool)
+	for _, diff := range result.DriftDetails {
+		severity, expected := want[diff.Attribute]
+		if !expected {
+			t.Errorf("Unexpected drift on %s: %+v", diff.Attribute, diff)
+			continue
+		}
+		found[diff.Attribute] = true
+		if diff.Severity != severity {
+			t.Errorf("Expected %s to have %s severity, got %s", diff.Attribute, severity, diff.Severity)
+		}
+	}
+	for attr := range want {
+		if !found[attr] {
+			t.Errorf("Expected %s drift to be detected", attr)
+		}
+	}
+
+	// Removing encryption and logging is also reported
+	awsBucket.Encryption = nil
+	awsBucket.LoggingTarget = ""
+	result, err = detector.DetectDrift(awsBucket, terraformBucket)
+	if err != nil {
+		t.Fatalf("DetectDrift() error = %v", err)
+	}
+	severities := make(map[string]interfaces.SeverityLevel)
+	for _, diff := range result.DriftDetails {
+		severities[diff.Attribute] = diff.Severity
+	}
+	if severities["server_side_encryption"] != interfaces.SeverityCritical {
+		t.Errorf("Expected critical drift for removed encryption, got %q", severities["server_side_encryption"])
+	}
+	if severities["logging_target"] != interfaces.SeverityMedium {
+		t.Errorf("Expected medium drift for disabled logging, got %q", severities["logging_target"])
+	}
+
+	// A bucket matching its configuration has no drift
+	matching := *terraformBucket
+	matching.Versioning = false
+	matching.Tags = awsBucket.Tags
+	matching.PublicAccessBlock = &terraform.S3PublicAccessBlockConfig{BlockPublicACLs: true, IgnorePublicACLs: true}
+	matching.ServerSideEncryption = nil
+	matching.LoggingTarget = ""
+	result, err = detector.DetectDrift(awsBucket, &matching)
+	if err != nil {
+		t.Fatalf("DetectDrift() error = %v", err)
+	}
+	if result.IsDrifted {
+		t.Errorf("Expected no drift for a matching bucket, got %+v", result.DriftDetails)
+	}
+
+	if _, err := detector.DetectDrift(createBenchmarkInstance(), terraformBucket); err == nil {
+		t.Error("Expected a resource type mismatch pairing an instance with a bucket config")
+	}
+}
+
+func TestDetectDrift_NATGatewayConnectivityType(t *testing.T) {
+	detector := NewDriftDetector(DefaultDetectionConfig())
+
+	allocationID := "eipalloc-123"
+	actual := &aws.NATGateway{
+		NATGatewayID:     "nat-123",
+		SubnetID:         "subnet-123",
+		ConnectivityType: aws.NATConnectivityPrivate,
+		Tags:             map[string]string{},
+	}
+	expected := &aws.NATGateway{
+		NATGatewayID:     "nat-123",
+		SubnetID:         "subnet-123",
+		ConnectivityType: aws.NATConnectivityPublic,
+		AllocationID:     &allocationID,
+		Tags:             map[string]string{},
+	}
+
+	result, err := detector.DetectDrift(actual, expected)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.ResourceType != "aws_nat_gateway" {
+		t.Errorf("Expected resource type aws_nat_gateway, got %s", result.ResourceType)
+	}
+
+	var connectivity *interfaces.DriftDetail
+	for _, detail := range result.DriftDetails {
+		if detail.Attribute == "connectivity_type" {
+			connectivity = detail
+		}
+	}
+	if connectivity == nil {
+		t.Fatal("Expected connectivity_type drift")
+	}
+	if connectivity.Severity != interfaces.SeverityHigh {
+		t.Errorf("Expected high severity for connectivity type change, got %s", connectivity.Severity)
+	}
+}
+
+func TestDetectDrift_AttributeAliases(t *testing.T) {
+	type awsSide struct {
+		SecurityGroups []string
+	}
+	type terraformSide struct {
+		VpcSecurityGroupIds []string
+	}
+
+	config := DefaultDetectionConfig()
+	config.AttributeAliases = map[string][]string{
+		"security_groups": {"vpc_security_group_ids"},
+	}
+	detector := NewDriftDetector(config)
+
+	result, err := detector.DetectDrift(
+		&awsSide{SecurityGroups: []string{"sg-1", "sg-2"}},
+		&terraformSide{VpcSecurityGroupIds: []string{"sg-2", "sg-3"}},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result.DriftDetails) != 1 {
+		t.Fatalf("Expected one compared drift detail, got %d", len(result.DriftDetails))
+	}
+	detail := result.DriftDetails[0]
+	if detail.Attribute != "security_groups" {
+		t.Errorf("Expected drift reported under security_groups, got %s", detail.Attribute)
+	}
+	if detail.ActualValue == nil || detail.ExpectedValue == nil {
+		t.Errorf("Expected both sides to be compared, got actual=%v expected=%v", detail.ActualValue, detail.ExpectedValue)
+	}
+}
+
+func TestCanonicalAttributeName_SharedAliasIsDeterministic(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.AttributeAliases = map[string][]string{
+		"security_groups": {"vpc_security_group_ids"},
+		"sg_ids":          {"vpc_security_group_ids"},
+	}
+	detector := NewDriftDetector(config)
+
+	for i := 0; i < 50; i++ {
+		if got := detector.canonicalAttributeName("vpc_security_group_ids"); got != "security_groups" {
+			t.Fatalf("Expected the first canonical name in sorted order, got %s", got)
+		}
+	}
+}
+
+func TestDetectDrift_ResourceTypeMismatch(t *testing.T) {
+	instance := createBenchmarkInstance()
+	bucketConfig := &terraform.TerraformConfig{ResourceID: "aws_s3_bucket.data", InstanceType: "t3.micro"}
+
+	detector := NewDriftDetector(DefaultDetectionConfig())
+	_, err := detector.DetectDrift(instance, bucketConfig)
+	var mismatch *ResourceTypeMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Expected ResourceTypeMismatchError, got %v", err)
+	}
+	if mismatch.AWSType != "aws_instance" || mismatch.TerraformType != "aws_s3_bucket" {
+		t.Errorf("Unexpected mismatch types: %+v", mismatch)
+	}
+
+	instanceConfig := &terraform.TerraformConfig{ResourceID: "module.app.aws_instance.web", InstanceType: "t3.micro"}
+	if _, err := detector.DetectDrift(instance, instanceConfig); err != nil {
+		t.Errorf("Expected matching types to pass validation, got %v", err)
+	}
+
+	config := DefaultDetectionConfig()
+	config.FailOnResourceTypeMismatch = false
+	if _, err := NewDriftDetector(config).DetectDrift(instance, bucketConfig); err != nil {
+		t.Errorf("Expected validation to be disabled, got %v", err)
+	}
+}
+
+func TestDetectDriftBatch_DeterministicErrors(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.MaxConcurrency = 4
+	config.DeterministicBatch = true
+	detector := NewDriftDetector(config)
+
+	var pairs []ResourcePair
+	for i, id := range []string{"i-e", "i-c", "i-a", "i-d", "i-b"} {
+		instance := createBenchmarkInstance()
+		instance.InstanceID = id
+		pairs = append(pairs, ResourcePair{
+			Index:           i,
+			AWSResource:     instance,
+			TerraformConfig: &terraform.TerraformConfig{ResourceID: "aws_s3_bucket." + id},
+		})
+	}
+
+	_, firstErr := detector.DetectDriftBatch(pairs)
+	if firstErr == nil {
+		t.Fatal("Expected batch errors for mismatched resource types")
+	}
+	for run := 0; run < 5; run++ {
+		_, err := detector.DetectDriftBatch(pairs)
+		if err == nil || err.Error() != firstErr.Error() {
+			t.Fatalf("Run %d produced different errors:\n%v\nvs\n%v", run, err, firstErr)
+		}
+	}
+
+	msg := firstErr.Error()
+	last := -1
+	for _, index := range []string{"index 2:", "index 4:", "index 1:", "index 3:", "index 0:"} {
+		pos := strings.Index(msg, index)
+		if pos <= last {
+			t.Fatalf("Expected errors ordered by resource ID, got: %s", msg)
+		}
+		last = pos
+	}
+}
+
+func TestDetectDriftBatchContext_Cancelled(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.MaxConcurrency = 1
+	detector := NewDriftDetector(config)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Cancel from inside the first comparison so the batch is aborted mid-way
+	var compared int32
+	detector.RegisterComparator("instance_type", func(actual, expected interface{}) (bool, string) {
+		if atomic.AddInt32(&compared, 1) == 1 {
+			cancel()
+		}
+		return actual == expected, "instance type differs"
+	})
+
+	var pairs []ResourcePair
+	for i := 0; i < 10; i++ {
+		expected := createBenchmarkInstance()
+		expected.InstanceType = "t3.large"
+		pairs = append(pairs, ResourcePair{Index: i, AWSResource: createBenchmarkInstance(), TerraformConfig: expected})
+	}
+
+	results, err := detector.DetectDriftBatchContext(ctx, pairs)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if len(results) != len(pairs) {
+		t.Fatalf("Expected %d result slots, got %d", len(pairs), len(results))
+	}
+	// The in-flight pair may or may not complete; nothing after it may run
+	for i := 1; i < len(results); i++ {
+		if results[i] != nil {
+			t.Errorf("Expected pair %d not to be processed after cancellation", i)
+		}
+	}
+	if n := atomic.LoadInt32(&compared); n != 1 {
+		t.Errorf("Expected dispatching to stop after cancellation, got %d comparisons", n)
+	}
+
+	// An already cancelled context processes nothing
+	results, err = detector.DetectDriftBatchContext(ctx, pairs)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	for i, result := range results {
+		if result != nil {
+			t.Errorf("Expected no result for pair %d", i)
+		}
+	}
+}
+
+// reasonResource exposes its attributes directly through a resource mapper
+type reasonResource struct{ attrs map[string]interface{} }
+
+func TestDetectDrift_ReasonCodes(t *testing.T) {
+	detector := NewDriftDetector(DefaultDetectionConfig())
+	detector.RegisterResourceMapper("*drift.reasonResource", func(resource interface{}) (map[string]interface{}, error) {
+		return resource.(*reasonResource).attrs, nil
+	})
+
+	actual := &reasonResource{attrs: map[string]interface{}{
+		"instance_type": "t3.large",
+		"monitoring":    true,
+		"user_data":     "echo hi",
+		"volume_size":   []string{"20"},
+	}}
+	expected := &reasonResource{attrs: map[string]interface{}{
+		"instance_type": "t3.micro",
+		"monitoring":    true,
+		"key_name":      "deploy",
+		"volume_size":   20,
+	}}
+
+	result, err := detector.DetectDrift(actual, expected)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+
+	want := map[string]interfaces.ReasonCode{
+		"instance_type": interfaces.ReasonValueChanged,
+		"key_name":      interfaces.ReasonMissingInAWS,
+		"user_data":     interfaces.ReasonMissingInTerraform,
+		"volume_size":   interfaces.ReasonTypeMismatch,
+	}
+	if len(result.DriftDetails) != len(want) {
+		t.Fatalf("Expected %d drift details, got %d", len(want), len(result.DriftDetails))
+	}
+	for _, detail := range result.DriftDetails {
+		if detail.ReasonCode != want[detail.Attribute] {
+			t.Errorf("Attribute %s: expected reason %s, got %s", detail.Attribute, want[detail.Attribute], detail.ReasonCode)
+		}
+	}
+
+	data, err := json.Marshal(result.DriftDetails[0])
+	if err != nil {
+		t.Fatalf("Failed to marshal detail: %v", err)
+	}
+	if !strings.Contains(string(data), `"reason_code":"`) {
+		t.Errorf("Expected reason_code in JSON, got %s", data)
+	}
+}
+
+func TestDetectDrift_IgnoredAttributeGlobs(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.IgnoredAttributes = []string{"tags.*", "block_device_mappings[*].iops", "user_data"}
+	detector := NewDriftDetector(config)
+	detector.RegisterResourceMapper("*drift.reasonResource", func(resource interface{}) (map[string]interface{}, error) {
+		return resource.(*reasonResource).attrs, nil
+	})
+
+	actual := &reasonResource{attrs: map[string]interface{}{
+		"tags.Name":                      "web-1",
+		"tags.aws:autoscaling:groupName": "asg",
+		"block_device_mappings[0].iops":  3000,
+		"block_device_mappings[0].size":  20,
+		"user_data":                      "echo hi",
+		"tagsets":                        "a",
+	}}
+	expected := &reasonResource{attrs: map[string]interface{}{
+		"tags.Name":                     "web",
+		"block_device_mappings[0].iops": 4000,
+		"block_device_mappings[0].size": 30,
+		"user_data":                     "echo bye",
+		"tagsets":                       "b",
+	}}
+
+	result, err := detector.DetectDrift(actual, expected)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+
+	var drifted []string
+	for _, detail := range result.DriftDetails {
+		drifted = append(drifted, detail.Attribute)
+	}
+	sort.Strings(drifted)
+	want := []string{"block_device_mappings[0].size", "tagsets"}
+	if !reflect.DeepEqual(drifted, want) {
+		t.Errorf("Expected drift only on %v, got %v", want, drifted)
+	}
+}
+
+func TestDetectDrift_IgnoredNestedPaths(t *testing.T) {
+	awsInstance, terraformConfig := createMixedDriftPair()
+
+	config := DefaultDetectionConfig()
+	detector := NewDriftDetector(config)
+	result, err := detector.DetectDrift(awsInstance, terraformConfig)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if !hasDriftOn(result, "tags") {
+		t.Fatal("Expected the fixture to drift on tags")
+	}
+
+	for _, patterns := range [][]string{{"tags.*"}, {"tags.Environment", "tags.Own*"}} {
+		config.IgnoredAttributes = patterns
+		detector.UpdateConfig(config)
+		result, err = detector.DetectDrift(awsInstance, terraformConfig)
+		if err != nil {
+			t.Fatalf("DetectDrift() unexpected error: %v", err)
+		}
+		if hasDriftOn(result, "tags") {
+			t.Errorf("Expected %v to ignore the drifted tags", patterns)
+		}
+		if !hasDriftOn(result, "instance_type") {
+			t.Errorf("Expected %v to leave other attributes compared", patterns)
+		}
+	}
+
+	config.IgnoredAttributes = []string{"tags.Environment"}
+	detector.UpdateConfig(config)
+	result, err = detector.DetectDrift(awsInstance, terraformConfig)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if !hasDriftOn(result, "tags") {
+		t.Error("Expected the extra Owner tag to still drift")
+	}
+	if awsInstance.Tags["Environment"] != "prod" {
+		t.Error("Expected the resource tags not to be modified")
+	}
+
+	config.IgnoredAttributes = []string{"block_device_mappings[*].iops"}
+	detector.UpdateConfig(config)
+	detector.RegisterResourceMapper("*drift.reasonResource", func(resource interface{}) (map[string]interface{}, error) {
+		return resource.(*reasonResource).attrs, nil
+	})
+	volume := func(iops, size int) map[string]interface{} {
+		return map[string]interface{}{"device_name": "/dev/xvda", "iops": iops, "volume_size": size}
+	}
+	actual := &reasonResource{attrs: map[string]interface{}{"block_device_mappings": []interface{}{volume(3000, 20)}}}
+	expected := &reasonResource{attrs: map[string]interface{}{"block_device_mappings": []interface{}{volume(4000, 20)}}}
+	result, err = detector.DetectDrift(actual, expected)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if result.IsDrifted {
+		t.Errorf("Expected iops differences to be ignored, got %+v", result.DriftDetails)
+	}
+
+	expected.attrs["block_device_mappings"] = []interface{}{volume(4000, 30)}
+	result, err = detector.DetectDrift(actual, expected)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if !hasDriftOn(result, "block_device_mappings") {
+		t.Error("Expected a volume size change to drift")
+	}
+}
+
+func hasDriftOn(result *interfaces.DriftResult, attribute string) bool {
+	for _, detail := range result.DriftDetails {
+		if detail.Attribute == attribute {
+			return true
+		}
+	}
+	return false
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"tags.*", "tags.Name", true},
+		{"tags.*", "tags", false},
+		{"*_id", "subnet_id", true},
+		{"block_device_mappings[*].iops", "block_device_mappings[12].iops", true},
+		{"block_device_mappings[*].iops", "block_device_mappings[0].size", false},
+		{"a*b*c", "axxbyyc", true},
+		{"a*b*c", "acb", false},
+		{"launch_time", "launch_time", true},
+	}
+	for _, tt := range tests {
+		if got := matchGlob(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDetectDrift_StrictMode(t *testing.T) {
+	mapper := func(resource interface{}) (map[string]interface{}, error) {
+		return resource.(*reasonResource).attrs, nil
+	}
+	actual := &reasonResource{attrs: map[string]interface{}{
+		"instance_type": "t3.micro",
+		"user_data":     "echo hi",
+		"launch_time":   "2024-01-01T00:00:00Z",
+	}}
+	expected := &reasonResource{attrs: map[string]interface{}{
+		"instance_type": "t3.micro",
+	}}
+
+	// Non-strict: the AWS-only attribute is ordinary low severity drift
+	detector := NewDriftDetector(DefaultDetectionConfig())
+	detector.RegisterResourceMapper("*drift.reasonResource", mapper)
+	result, err := detector.DetectDrift(actual, expected)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if len(result.DriftDetails) != 1 || result.DriftDetails[0].Attribute != "user_data" || result.DriftDetails[0].Severity != interfaces.SeverityLow {
+		t.Fatalf("Expected one low severity user_data detail, got %+v", result.DriftDetails)
+	}
+
+	// Strict: the same attribute is an error; ignored launch_time is not
+	config := DefaultDetectionConfig()
+	config.StrictMode = true
+	detector = NewDriftDetector(config)
+	detector.RegisterResourceMapper("*drift.reasonResource", mapper)
+	_, err = detector.DetectDrift(actual, expected)
+	var unexpected *UnexpectedAttributeError
+	if !errors.As(err, &unexpected) {
+		t.Fatalf("Expected UnexpectedAttributeError, got %v", err)
+	}
+	if unexpected.Attribute != "user_data" || !unexpected.InAWS {
+		t.Errorf("Unexpected error details: %+v", unexpected)
+	}
+
+	// Strict with details: recorded as high severity drift instead
+	config.StrictModeDetails = true
+	detector.UpdateConfig(config)
+	result, err = detector.DetectDrift(actual, expected)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if len(result.DriftDetails) != 1 {
+		t.Fatalf("Expected one detail, got %+v", result.DriftDetails)
+	}
+	detail := result.DriftDetails[0]
+	if detail.Severity != interfaces.SeverityHigh || detail.ReasonCode != interfaces.ReasonMissingInTerraform ||
+		!strings.Contains(detail.Description, "unexpected attribute user_data") {
+		t.Errorf("Unexpected strict detail: %+v", detail)
+	}
+	if result.Severity != interfaces.SeverityHigh {
+		t.Errorf("Expected high overall severity, got %s", result.Severity)
+	}
+}
+
+func TestResourceMapsIdentical(t *testing.T) {
+	a := map[string]interface{}{"ami": "ami-1", "tags": map[string]string{"Env": "prod", "Name": "web"}}
+	b := map[string]interface{}{"tags": map[string]string{"Name": "web", "Env": "prod"}, "ami": "ami-1"}
+	if !resourceMapsIdentical(a, b) {
+		t.Error("Expected maps with the same content to be identical regardless of key order")
+	}
+
+	b["ami"] = "ami-2"
+	if resourceMapsIdentical(a, b) {
+		t.Error("Expected maps with different values not to be identical")
+	}
+
+	c := map[string]interface{}{"security_groups": []string{"sg-1", "sg-2"}}
+	d := map[string]interface{}{"security_groups": []interface{}{"sg-1", "sg-2"}}
+	if resourceMapsIdentical(c, d) {
+		t.Error("Expected slices of different element types not to be identical")
+	}
+
+	e := map[string]interface{}{"volume_size": 8}
+	f := map[string]interface{}{"volume_size": uint(8)}
+	if resourceMapsIdentical(e, f) {
+		t.Error("Expected int and uint values not to be identical")
+	}
+}
+
+func TestDetectDrift_IdenticalRunsHooksAndComparators(t *testing.T) {
+	instance := createBenchmarkInstance()
+
+	detector := NewDriftDetector(DefaultDetectionConfig())
+	hooked := map[string]bool{}
+	detector.SetAfterCompareHook(func(attribute string, actual, expected interface{}, equal bool, description string) {
+		hooked[attribute] = true
+	})
+	if _, err := detector.DetectDrift(instance, instance); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !hooked["instance_type"] {
+		t.Errorf("Expected the after-compare hook to see instance_type, saw %v", hooked)
+	}
+
+	detector = NewDriftDetector(DefaultDetectionConfig())
+	called := false
+	detector.RegisterComparator("instance_type", func(actual, expected interface{}) (bool, string) {
+		called = true
+		return false, "instance type is pinned elsewhere"
+	})
+	result, err := detector.DetectDrift(instance, instance)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !called || !result.IsDrifted {
+		t.Errorf("Expected the custom comparator to run and report drift, called=%t drifted=%t", called, result.IsDrifted)
+	}
+}
+
+func TestDetectDrift_IdenticalRegexMismatch(t *testing.T) {
+	instance := createBenchmarkInstance()
+	config := DefaultDetectionConfig()
+	config.AttributeConfigs["instance_type"] = AttributeConfig{
+		AttributeName:  "instance_type",
+		ComparisonType: RegexMatch,
+		Pattern:        "^m5\\.",
+	}
+	detector := NewDriftDetector(config)
+
+	result, err := detector.DetectDrift(instance, instance)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.IsDrifted {
+		t.Error("Expected an AWS value failing the pattern to drift even when both sides are equal")
+	}
+}
+
+func TestDetectDrift_IdenticalShortCircuit(t *testing.T) {
+	detector := NewDriftDetector(DefaultDetectionConfig())
+	instance := createBenchmarkInstance()
+
+	result, err := detector.DetectDrift(instance, instance)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.IsDrifted || len(result.DriftDetails) != 0 {
+		t.Errorf("Expected no drift for identical resources, got %d details", len(result.DriftDetails))
+	}
+	if result.ResourceID != instance.InstanceID || result.ResourceType != "aws_instance" {
+		t.Errorf("Expected resource identity to be kept, got %s/%s", result.ResourceID, result.ResourceType)
+	}
+}
+
+func createBenchmarkInstance() *aws.EC2Instance {
+	ami := "ami-12345"
+	subnet := "subnet-12345"
+	vpc := "vpc-12345"
+	return &aws.EC2Instance{
+		InstanceID:   "i-12345",
+		InstanceType: "t3.micro",
+		ImageID:      &ami,
+		SubnetID:     &subnet,
+		VPCID:        &vpc,
+		Tags:         map[string]string{"Name": "web", "Env": "prod", "Team": "platform"},
+		SecurityGroups: []aws.SecurityGroup{
+			{GroupID: "sg-1", GroupName: "web"},
+			{GroupID: "sg-2", GroupName: "ssh"},
+		},
+		Monitoring: true,
+	}
+}
+
+func BenchmarkDetectDrift_Identical(b *testing.B) {
+	instance := createBenchmarkInstance()
+
+	b.Run("short-circuit", func(b *testing.B) {
+		detector := NewDriftDetector(DefaultDetectionConfig())
+		for i := 0; i < b.N; i++ {
+			detector.DetectDrift(instance, instance)
+		}
+	})
+
+	b.Run("full-comparison", func(b *testing.B) {
+		detector := NewDriftDetector(DefaultDetectionConfig())
+		detector.disableShortCircuit = true
+		for i := 0; i < b.N; i++ {
+			detector.DetectDrift(instance, instance)
+		}
+	})
+}
+
+func TestDetectDrift_OnlyAttributes(t *testing.T) {
+	actual := createBenchmarkInstance()
+	expected := createBenchmarkInstance()
+	otherAMI := "ami-other"
+	expected.ImageID = &otherAMI
+	expected.InstanceType = "t3.large"
+	expected.Tags = map[string]string{"Name": "api"}
+
+	config := DefaultDetectionConfig()
+	config.OnlyAttributes = []string{"ami"}
+	detector := NewDriftDetector(config)
+	var compared []string
+	detector.SetAfterCompareHook(func(attribute string, actual, expected interface{}, equal bool, description string) {
+		compared = append(compared, attribute)
+	})
+
+	result, err := detector.DetectDrift(actual, expected)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if len(compared) != 1 || compared[0] != "ami" {
+		t.Errorf("Expected only ami to be compared, got %v", compared)
+	}
+	if len(result.DriftDetails) != 1 || result.DriftDetails[0].Attribute != "ami" {
+		t.Errorf("Expected only ami drift, got %+v", result.DriftDetails)
+	}
+
+	config.IgnoredAttributes = append(config.IgnoredAttributes, "ami")
+	result, err = NewDriftDetector(config).DetectDrift(actual, expected)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if result.IsDrifted {
+		t.Errorf("Expected ignored attributes to be removed from the only-set, got %+v", result.DriftDetails)
+	}
+}
+
+func TestDetectDrift_IncludeMatches(t *testing.T) {
+	actual := createBenchmarkInstance()
+	expected := createBenchmarkInstance()
+
+	config := DefaultDetectionConfig()
+	config.IncludeMatches = true
+	detector := NewDriftDetector(config)
+
+	result, err := detector.DetectDrift(actual, expected)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if result.IsDrifted || len(result.DriftDetails) != 0 || result.Severity != interfaces.SeverityNone {
+		t.Fatalf("Expected matches not to affect drift status, got drifted=%v details=%d severity=%s",
+			result.IsDrifted, len(result.DriftDetails), result.Severity)
+	}
+	if len(result.MatchedDetails) == 0 {
+		t.Fatal("Expected matched attributes to be recorded")
+	}
+	found := false
+	for _, match := range result.MatchedDetails {
+		if !match.Matched || match.Severity != interfaces.SeverityNone {
+			t.Errorf("Expected %s to be flagged as matched with no severity, got %+v", match.Attribute, match)
+		}
+		if match.Attribute == "instance_type" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected instance_type to be recorded as matched")
+	}
+
+	expected.InstanceType = "t3.large"
+	result, err = detector.DetectDrift(actual, expected)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if !result.IsDrifted || len(result.DriftDetails) != 1 {
+		t.Fatalf("Expected only instance_type to drift, got %+v", result.DriftDetails)
+	}
+	for _, match := range result.MatchedDetails {
+		if match.Attribute == "instance_type" {
+			t.Error("Expected the drifted attribute not to be recorded as matched")
+		}
+	}
+
+	result, err = NewDriftDetector(DefaultDetectionConfig()).DetectDrift(actual, actual)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if len(result.MatchedDetails) != 0 {
+		t.Errorf("Expected no matches without IncludeMatches, got %d", len(result.MatchedDetails))
+	}
+}
+
+func TestDetectDrift_ReportSetDelta(t *testing.T) {
+	actual := createBenchmarkInstance()
+	expected := createBenchmarkInstance()
+	expected.SecurityGroups = []aws.SecurityGroup{
+		{GroupID: "sg-1", GroupName: "web"},
+		{GroupID: "sg-3", GroupName: "db"},
+		{GroupID: "sg-4", GroupName: "cache"},
+	}
+
+	config := DefaultDetectionConfig()
+	config.AttributeConfigs["security_groups"] = AttributeConfig{ComparisonType: ArrayUnordered, ReportSetDelta: true}
+	detector := NewDriftDetector(config)
+
+	result, err := detector.DetectDrift(actual, expected)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if len(result.DriftDetails) != 1 || result.DriftDetails[0].Attribute != "security_groups" {
+		t.Fatalf("Expected only security_groups to drift, got %+v", result.DriftDetails)
+	}
+
+	detail := result.DriftDetails[0]
+	if detail.SetDelta == nil {
+		t.Fatal("Expected a set delta on the security_groups detail")
+	}
+	if !reflect.DeepEqual(detail.SetDelta.Added, []interface{}{"sg-2"}) {
+		t.Errorf("Expected sg-2 to be added, got %v", detail.SetDelta.Added)
+	}
+	if !reflect.DeepEqual(detail.SetDelta.Removed, []interface{}{"sg-3", "sg-4"}) {
+		t.Errorf("Expected sg-3 and sg-4 to be removed, got %v", detail.SetDelta.Removed)
+	}
+	if detail.SetDelta.Matched != 1 {
+		t.Errorf("Expected 1 matching group, got %d", detail.SetDelta.Matched)
+	}
+	want := "array set mismatch (unordered): 1 of 3 elements match, added [sg-2], removed [sg-3 sg-4]"
+	if detail.Description != want {
+		t.Errorf("Description = %q, want %q", detail.Description, want)
+	}
+
+	config.AttributeConfigs["security_groups"] = AttributeConfig{ComparisonType: ArrayUnordered}
+	result, err = NewDriftDetector(config).DetectDrift(actual, expected)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if len(result.DriftDetails) != 1 || result.DriftDetails[0].SetDelta != nil {
+		t.Errorf("Expected no set delta without ReportSetDelta, got %+v", result.DriftDetails)
+	}
+}
+
+func TestDedupeDriftDetails(t *testing.T) {
+	details := []*interfaces.DriftDetail{
+		{Attribute: "security_groups", ActualValue: []string{"sg-1"}, ExpectedValue: []string{"sg-2"}, Severity: interfaces.SeverityMedium},
+		{Attribute: "instance_type", ActualValue: "t3.large", ExpectedValue: "t3.micro", Severity: interfaces.SeverityHigh},
+		{Attribute: "security_groups", ActualValue: []string{"sg-1"}, ExpectedValue: []string{"sg-2"}, Severity: interfaces.SeverityHigh},
+		{Attribute: "security_groups", ActualValue: []string{"sg-1"}, ExpectedValue: []string{"sg-3"}, Severity: interfaces.SeverityMedium},
+	}
+
+	deduped := DedupeDriftDetails(details)
+	if len(deduped) != 3 {
+		t.Fatalf("Expected 3 details after dedup, got %d", len(deduped))
+	}
+	if deduped[0].Attribute != "security_groups" || deduped[1].Attribute != "instance_type" {
+		t.Errorf("Expected first occurrences to keep their order, got %s, %s", deduped[0].Attribute, deduped[1].Attribute)
+	}
+	if deduped[0].Severity != interfaces.SeverityHigh {
+		t.Errorf("Expected the kept detail to take the highest duplicate severity, got %s", deduped[0].Severity)
+	}
+}
+
+func TestDetectDrift_DedupesMapperAndRuleDetails(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.CompareSecurityGroupRules = true
+	config.SecurityGroupRuleDetails = true
+	detector := NewDriftDetector(config)
+	detector.SetSecurityGroupRuleResolver(staticRuleResolver(map[string][]aws.SecurityGroupRule{
+		"sg-a": {{Direction: aws.RuleDirectionIngress, Protocol: "tcp", FromPort: 443, ToPort: 443, CIDRBlocks: []string{"10.0.0.0/8"}}},
+		"sg-b": {{Direction: aws.RuleDirectionIngress, Protocol: "tcp", FromPort: 443, ToPort: 443, CIDRBlocks: []string{"0.0.0.0/0"}}},
+	}))
+	// The mapper flattens the rules itself, under the same attribute name the
+	// rule details use
+	detector.RegisterResourceMapper("*drift.reasonResource", func(resource interface{}) (map[string]interface{}, error) {
+		return resource.(*reasonResource).attrs, nil
+	})
+
+	actual := &reasonResource{attrs: map[string]interface{}{
+		"security_groups":       []string{"sg-a"},
+		"ingress_rule[tcp/443]": []string{"10.0.0.0/8"},
+	}}
+	expected := &reasonResource{attrs: map[string]interface{}{
+		"security_groups":       []string{"sg-b"},
+		"ingress_rule[tcp/443]": []string{"0.0.0.0/0"},
+	}}
+
+	result, err := detector.DetectDrift(actual, expected)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if len(result.DriftDetails) != 1 || result.DriftDetails[0].Attribute != "ingress_rule[tcp/443]" {
+		t.Fatalf("Expected a single ingress_rule[tcp/443] detail, got %d: %+v", len(result.DriftDetails), result.DriftDetails)
+	}
+}
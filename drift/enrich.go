@@ -0,0 +1,102 @@
+package drift
+
+import "firefly-task/pkg/interfaces"
+
+// NameTag is the tag whose value becomes a result's display name
+const NameTag = "Name"
+
+// EnrichDisplayName sets result.DisplayName from the Name tag when present.
+// DetectDrift calls it for every result; results built elsewhere, such as
+// ones loaded from a saved report, can be enriched with EnrichDisplayNames.
+func EnrichDisplayName(result *interfaces.DriftResult) {
+	if result == nil {
+		return
+	}
+	if name := result.Tags[NameTag]; name != "" {
+		result.DisplayName = name
+	}
+}
+
+// EnrichDisplayNames applies EnrichDisplayName to every result
+func EnrichDisplayNames(results map[string]*interfaces.DriftResult) {
+	for _, result := range results {
+		EnrichDisplayName(result)
+	}
+}
+
+// resourceTags returns a copy of the tags attribute of a resource map, or nil
+// when the resource has no string tags
+func resourceTags(m map[string]interface{}) map[string]string {
+	tags, ok := m["tags"].(map[string]string)
+	if !ok || len(tags) == 0 {
+		return nil
+	}
+	copied := make(map[string]string, len(tags))
+	for k, v := range tags {
+		copied[k] = v
+	}
+	return copied
+}
+
+// OwnerResolver returns the owner of the resource behind result, or "" when
+// it is unknown
+type OwnerResolver func(result *interfaces.DriftResult) string
+
+// OwnerTags are the tags TagOwnerResolver reads, in order of preference
+var OwnerTags = []string{"Owner", "Team"}
+
+// TagOwnerResolver resolves the owner from the first of OwnerTags set on the
+// resource. DetectDrift uses it unless another resolver is set.
+func TagOwnerResolver(result *interfaces.DriftResult) string {
+	for _, tag := range OwnerTags {
+		if owner := result.Tags[tag]; owner != "" {
+			return owner
+		}
+	}
+	return ""
+}
+
+// MapOwnerResolver resolves owners from an external ownership map keyed by
+// resource ID, falling back to TagOwnerResolver for unlisted resources
+func MapOwnerResolver(owners map[string]string) OwnerResolver {
+	return func(result *interfaces.DriftResult) string {
+		if owner := owners[result.ResourceID]; owner != "" {
+			return owner
+		}
+		return TagOwnerResolver(result)
+	}
+}
+
+// EnrichOwner sets result.Owner using resolver, or TagOwnerResolver when
+// resolver is nil. An owner already set on the result is kept.
+func EnrichOwner(result *interfaces.DriftResult, resolver OwnerResolver) {
+	if result == nil || result.Owner != "" {
+		return
+	}
+	if resolver == nil {
+		resolver = TagOwnerResolver
+	}
+	result.Owner = resolver(result)
+}
+
+// EnrichOwners applies EnrichOwner to every result
+func EnrichOwners(results map[string]*interfaces.DriftResult, resolver OwnerResolver) {
+	for _, result := range results {
+		EnrichOwner(result, resolver)
+	}
+}
+
+// SetOwnerResolver sets the resolver DetectDrift uses to fill in result
+// owners. It is called concurrently during batch detection and must be safe
+// for concurrent use. A nil resolver restores TagOwnerResolver.
+func (d *DriftDetector) SetOwnerResolver(resolver OwnerResolver) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.ownerResolver = resolver
+}
+
+// enrichOwner fills in result.Owner with the configured resolver. Callers
+// must hold d.mu or call it on a snapshot.
+func (d *DriftDetector) enrichOwner(result *interfaces.DriftResult) {
+	EnrichOwner(result, d.ownerResolver)
+}
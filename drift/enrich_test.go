@@ -0,0 +1,87 @@
+package drift
+
+import (
+	"testing"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func TestDetectDrift_DisplayNameFromNameTag(t *testing.T) {
+	detector := NewDriftDetector(DefaultDetectionConfig())
+
+	instance := createBenchmarkInstance()
+	result, err := detector.DetectDrift(instance, instance)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if result.DisplayName != "web" {
+		t.Errorf("Expected display name 'web', got %q", result.DisplayName)
+	}
+	if result.ResourceID != "i-12345" {
+		t.Errorf("Expected resource ID to be kept, got %q", result.ResourceID)
+	}
+
+	unnamed := createBenchmarkInstance()
+	delete(unnamed.Tags, "Name")
+	changed := createBenchmarkInstance()
+	delete(changed.Tags, "Name")
+	changed.InstanceType = "t3.large"
+	result, err = detector.DetectDrift(unnamed, changed)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if result.DisplayName != "" {
+		t.Errorf("Expected no display name without a Name tag, got %q", result.DisplayName)
+	}
+}
+
+func TestEnrichDisplayNames(t *testing.T) {
+	results := map[string]*interfaces.DriftResult{
+		"a": {ResourceID: "i-a", Tags: map[string]string{"Name": "api"}},
+		"b": {ResourceID: "i-b", Tags: map[string]string{"Env": "prod"}},
+		"c": nil,
+	}
+
+	EnrichDisplayNames(results)
+
+	if results["a"].DisplayName != "api" {
+		t.Errorf("Expected display name 'api', got %q", results["a"].DisplayName)
+	}
+	if results["b"].DisplayName != "" {
+		t.Errorf("Expected no display name, got %q", results["b"].DisplayName)
+	}
+}
+
+func TestDetectDrift_OwnerResolution(t *testing.T) {
+	instance := createBenchmarkInstance()
+	instance.Tags["Team"] = "platform"
+	instance.Tags["Owner"] = "alice@example.com"
+
+	detector := NewDriftDetector(DefaultDetectionConfig())
+	result, err := detector.DetectDrift(instance, instance)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if result.Owner != "alice@example.com" {
+		t.Errorf("Expected owner from the Owner tag, got %q", result.Owner)
+	}
+
+	untagged := createBenchmarkInstance()
+	delete(untagged.Tags, "Team")
+	result, err = detector.DetectDrift(untagged, untagged)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if result.Owner != "" {
+		t.Errorf("Expected no owner without ownership tags, got %q", result.Owner)
+	}
+
+	detector.SetOwnerResolver(MapOwnerResolver(map[string]string{"i-12345": "networking"}))
+	result, err = detector.DetectDrift(untagged, untagged)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if result.Owner != "networking" {
+		t.Errorf("Expected owner from the ownership map, got %q", result.Owner)
+	}
+}
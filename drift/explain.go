@@ -0,0 +1,148 @@
+package drift
+
+import (
+	"sort"
+	"strings"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// Attribute decision outcomes
+const (
+	DecisionMatch   = "match"
+	DecisionDrift   = "drift"
+	DecisionIgnored = "ignored"
+)
+
+// AttributeDecision records how a single attribute was compared and why it
+// was or wasn't reported as drift
+type AttributeDecision struct {
+	Attribute     string                   `json:"attribute"`
+	Decision      string                   `json:"decision"`
+	Comparator    string                   `json:"comparator,omitempty"`
+	ActualValue   interface{}              `json:"actual_value"`
+	ExpectedValue interface{}              `json:"expected_value"`
+	Severity      interfaces.SeverityLevel `json:"severity,omitempty"`
+	Reason        string                   `json:"reason"`
+}
+
+// DriftExplanation is the per-attribute breakdown of a DetectDrift call
+type DriftExplanation struct {
+	ResourceID   string                   `json:"resource_id"`
+	ResourceType string                   `json:"resource_type"`
+	IsDrifted    bool                     `json:"is_drifted"`
+	Severity     interfaces.SeverityLevel `json:"severity"`
+	Decisions    []AttributeDecision      `json:"decisions"`
+}
+
+// ExplainDrift runs DetectDrift and returns a decision for every attribute
+// seen on either side, including attributes that matched or were ignored, so
+// callers can see why a resource is or isn't drifted. Decisions are sorted by
+// attribute name.
+func (d *DriftDetector) ExplainDrift(awsResource interface{}, terraformConfig interface{}) (*DriftExplanation, error) {
+	result, err := d.DetectDrift(awsResource, terraformConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	awsMap, err := d.resourceToMap(awsResource)
+	if err != nil {
+		return nil, err
+	}
+	terraformMap, err := d.resourceToMap(terraformConfig)
+	if err != nil {
+		return nil, err
+	}
+	if d.config.ARNNormalization.Enabled() {
+		awsMap = d.normalizeARNValues(awsMap)
+		terraformMap = d.normalizeARNValues(terraformMap)
+	}
+	if d.ruleDetailsEnabled() {
+		delete(awsMap, "security_groups")
+		delete(terraformMap, "security_groups")
+	}
+
+	unknown := unknownAttributes(terraformConfig)
+	details := make(map[string]*interfaces.DriftDetail, len(result.DriftDetails))
+	for _, detail := range result.DriftDetails {
+		details[detail.Attribute] = detail
+	}
+
+	explanation := &DriftExplanation{
+		ResourceID:   result.ResourceID,
+		ResourceType: result.ResourceType,
+		IsDrifted:    result.IsDrifted,
+		Severity:     result.Severity,
+		Decisions:    []AttributeDecision{},
+	}
+
+	attributeNames := d.getAllAttributeNames(awsMap, terraformMap)
+	// Rule-level security group details are not attributes of either map
+	for _, detail := range result.DriftDetails {
+		if _, inAWS := awsMap[detail.Attribute]; !inAWS {
+			if _, inTerraform := terraformMap[detail.Attribute]; !inTerraform && strings.Contains(detail.Attribute, "_rule[") {
+				attributeNames = append(attributeNames, detail.Attribute)
+			}
+		}
+	}
+	sort.Strings(attributeNames)
+	for _, attrName := range attributeNames {
+		awsValue, _ := d.lookupAttribute(awsMap, attrName)
+		terraformValue, _ := d.lookupAttribute(terraformMap, attrName)
+		decision := AttributeDecision{
+			Attribute:     attrName,
+			Comparator:    d.comparatorName(result.ResourceType, attrName),
+			ActualValue:   awsValue,
+			ExpectedValue: terraformValue,
+		}
+
+		switch detail, drifted := details[attrName]; {
+		case d.shouldIgnoreAttribute(attrName):
+			decision.Decision = DecisionIgnored
+			decision.Comparator = ""
+			decision.Reason = "attribute is in ignored_attributes"
+		case !d.isSelectedAttribute(attrName):
+			decision.Decision = DecisionIgnored
+			decision.Comparator = ""
+			decision.Reason = "attribute is not in only_attributes"
+		case unknown[attrName]:
+			decision.Decision = DecisionIgnored
+			decision.Comparator = ""
+			decision.Reason = "terraform value is unknown until apply"
+		case drifted:
+			decision.Decision = DecisionDrift
+			decision.ActualValue = detail.ActualValue
+			decision.ExpectedValue = detail.ExpectedValue
+			decision.Severity = detail.Severity
+			decision.Reason = detail.Description
+		default:
+			decision.Decision = DecisionMatch
+			decision.Reason = "values are equal under " + decision.Comparator + " comparison"
+		}
+		explanation.Decisions = append(explanation.Decisions, decision)
+	}
+
+	return explanation, nil
+}
+
+// comparatorName names the comparison DetectDrift uses for attrName on a
+// resource of resourceType
+func (d *DriftDetector) comparatorName(resourceType, attrName string) string {
+	if _, ok := d.comparators[attrName]; ok {
+		return "custom"
+	}
+	if d.ruleDetailsEnabled() && strings.Contains(attrName, "_rule[") {
+		return "security_group_rule"
+	}
+	if attrName == "security_groups" && d.config.CompareSecurityGroupRules && d.sgRuleResolver != nil {
+		return ArrayUnordered.String()
+	}
+	config := d.getAttributeConfig(resourceType, attrName)
+	if len(config.ComparisonChain) > 0 {
+		return "chain(" + comparisonChainName(config.ComparisonChain) + ")"
+	}
+	return config.ComparisonType.String()
+}
@@ -0,0 +1,41 @@
+package drift
+
+import (
+	"testing"
+)
+
+func TestExplainDrift(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.IgnoredAttributes = append(config.IgnoredAttributes, "monitoring")
+	detector := NewDriftDetector(config)
+
+	actual := createBenchmarkInstance()
+	expected := createBenchmarkInstance()
+	expected.InstanceType = "t3.large"
+
+	explanation, err := detector.ExplainDrift(actual, expected)
+	if err != nil {
+		t.Fatalf("ExplainDrift() unexpected error: %v", err)
+	}
+	if !explanation.IsDrifted {
+		t.Error("Expected explanation to report drift")
+	}
+
+	decisions := make(map[string]AttributeDecision)
+	for i, d := range explanation.Decisions {
+		if i > 0 && explanation.Decisions[i-1].Attribute > d.Attribute {
+			t.Errorf("Decisions not sorted: %s before %s", explanation.Decisions[i-1].Attribute, d.Attribute)
+		}
+		decisions[d.Attribute] = d
+	}
+
+	if d := decisions["instance_type"]; d.Decision != DecisionDrift || d.Severity == "" || d.Reason == "" {
+		t.Errorf("Expected instance_type drift with severity and reason, got %+v", d)
+	}
+	if d := decisions["tags"]; d.Decision != DecisionMatch || d.Comparator == "" {
+		t.Errorf("Expected tags to match with a comparator, got %+v", d)
+	}
+	if d := decisions["monitoring"]; d.Decision != DecisionIgnored {
+		t.Errorf("Expected monitoring to be ignored, got %+v", d)
+	}
+}
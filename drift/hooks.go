@@ -0,0 +1,27 @@
+package drift
+
+// BeforeCompareHook is called before an attribute's values are compared. It
+// returns the values to compare, which lets it normalize or otherwise rewrite
+// them; returning the inputs unchanged only observes the comparison.
+type BeforeCompareHook func(attribute string, actual, expected interface{}) (interface{}, interface{})
+
+// AfterCompareHook is called with the outcome of each attribute comparison
+type AfterCompareHook func(attribute string, actual, expected interface{}, equal bool, description string)
+
+// SetBeforeCompareHook sets the hook run before each attribute comparison.
+// Hooks are called concurrently during batch detection and must be safe for
+// concurrent use. A nil hook disables it.
+func (d *DriftDetector) SetBeforeCompareHook(hook BeforeCompareHook) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.beforeCompare = hook
+}
+
+// SetAfterCompareHook sets the hook run after each attribute comparison.
+// Hooks are called concurrently during batch detection and must be safe for
+// concurrent use. A nil hook disables it.
+func (d *DriftDetector) SetAfterCompareHook(hook AfterCompareHook) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.afterCompare = hook
+}
@@ -0,0 +1,94 @@
+package drift
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDetectDrift_AfterCompareHook(t *testing.T) {
+	detector := NewDriftDetector(DefaultDetectionConfig())
+
+	var mu sync.Mutex
+	compared := make(map[string]bool)
+	detector.SetAfterCompareHook(func(attribute string, actual, expected interface{}, equal bool, description string) {
+		mu.Lock()
+		defer mu.Unlock()
+		compared[attribute] = equal
+	})
+
+	actual := createBenchmarkInstance()
+	expected := createBenchmarkInstance()
+	expected.InstanceType = "t3.large"
+
+	if _, err := detector.DetectDrift(actual, expected); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var attributes []string
+	for attr := range compared {
+		attributes = append(attributes, attr)
+	}
+	sort.Strings(attributes)
+
+	for _, attr := range []string{"ami", "instance_type", "security_groups", "tags"} {
+		if _, ok := compared[attr]; !ok {
+			t.Errorf("Expected hook to record %s, got %v", attr, attributes)
+		}
+	}
+	if compared["instance_type"] {
+		t.Error("Expected instance_type comparison to be reported as unequal")
+	}
+	if !compared["ami"] {
+		t.Error("Expected ami comparison to be reported as equal")
+	}
+}
+
+func TestDetectDrift_BeforeCompareHookNormalizes(t *testing.T) {
+	detector := NewDriftDetector(DefaultDetectionConfig())
+	detector.SetBeforeCompareHook(func(attribute string, actual, expected interface{}) (interface{}, interface{}) {
+		if attribute != "instance_type" {
+			return actual, expected
+		}
+		return strings.ToLower(actual.(string)), strings.ToLower(expected.(string))
+	})
+
+	actual := createBenchmarkInstance()
+	expected := createBenchmarkInstance()
+	expected.InstanceType = "T3.MICRO"
+
+	result, err := detector.DetectDrift(actual, expected)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.IsDrifted {
+		t.Errorf("Expected normalized instance types to match, got %d details", len(result.DriftDetails))
+	}
+}
+
+func TestDetectDriftBatch_HooksConcurrent(t *testing.T) {
+	detector := NewDriftDetector(DefaultDetectionConfig())
+
+	var mu sync.Mutex
+	calls := 0
+	detector.SetAfterCompareHook(func(attribute string, actual, expected interface{}, equal bool, description string) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+	})
+
+	var pairs []ResourcePair
+	for i := 0; i < 10; i++ {
+		expected := createBenchmarkInstance()
+		expected.Tags = map[string]string{"Name": "changed"}
+		pairs = append(pairs, ResourcePair{Index: i, AWSResource: createBenchmarkInstance(), TerraformConfig: expected})
+	}
+
+	if _, err := detector.DetectDriftBatch(pairs); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls == 0 {
+		t.Error("Expected hooks to be called during batch detection")
+	}
+}
@@ -24,6 +24,26 @@ const (
 	MapComparison
 	// NestedObject compares nested objects recursively
 	NestedObject
+	// JSONSemanticMatch parses both values as JSON documents and compares
+	// them structurally, ignoring key order, whitespace and array order
+	JSONSemanticMatch
+	// KeyedObjectArray matches objects in two arrays by AttributeConfig.KeyField
+	// and reports which object was added, removed or changed
+	KeyedObjectArray
+	// IPMatch compares IP addresses by value, so IPv4 octets with leading zeros
+	// and differently cased IPv6 addresses are equal. Values that are not IP
+	// addresses fall back to string comparison.
+	IPMatch
+	// SemverMatch compares semantic versions, ignoring a leading "v", build
+	// metadata and missing minor or patch components ("v1.2" equals "1.2.0").
+	// Values that are not versions fall back to string comparison. With
+	// AttributeConfig.VersionConstraint set, the AWS version must satisfy the
+	// constraint instead.
+	SemverMatch
+	// RegexMatch treats the AWS value as matching when it satisfies
+	// AttributeConfig.Pattern, so generated suffixes (e.g. random bucket name
+	// suffixes) can be ignored. The expected value is not compared.
+	RegexMatch
 )
 
 // String returns the string representation of ComparisonType
@@ -43,11 +63,39 @@ func (ct ComparisonType) String() string {
 		return "map"
 	case NestedObject:
 		return "nested_object"
+	case JSONSemanticMatch:
+		return "json_semantic"
+	case KeyedObjectArray:
+		return "keyed_object_array"
+	case IPMatch:
+		return "ip"
+	case SemverMatch:
+		return "semver"
+	case RegexMatch:
+		return "regex"
 	default:
 		return "unknown"
 	}
 }
 
+// ComparisonTypes returns every built-in comparison type in declaration order
+func ComparisonTypes() []ComparisonType {
+	return []ComparisonType{
+		ExactMatch,
+		FuzzyMatch,
+		NumericTolerance,
+		ArrayUnordered,
+		ArrayOrdered,
+		MapComparison,
+		NestedObject,
+		JSONSemanticMatch,
+		KeyedObjectArray,
+		IPMatch,
+		SemverMatch,
+		RegexMatch,
+	}
+}
+
 // AttributeConfig defines how to compare a specific attribute
 type AttributeConfig struct {
 	// AttributeName is the name of the attribute to compare
@@ -67,6 +115,60 @@ type AttributeConfig struct {
 
 	// Description provides a human-readable description of what this attribute represents
 	Description string `json:"description,omitempty"`
+
+	// FloatPrecision rounds floats to this many decimals when they are
+	// stringified for comparison (optional)
+	FloatPrecision *int `json:"float_precision,omitempty"`
+
+	// CaseInsensitiveKeys matches map keys regardless of case for
+	// MapComparison (e.g. "Name" and "name"). Values are compared normally.
+	CaseInsensitiveKeys bool `json:"case_insensitive_keys,omitempty"`
+
+	// KeyField names the field that identifies objects for KeyedObjectArray
+	KeyField string `json:"key_field,omitempty"`
+
+	// EmptyEqualsAbsent treats a missing or nil value as equal to an empty
+	// slice or map (e.g. Terraform omitting tags while AWS returns {}). A
+	// non-empty value on either side is still compared normally.
+	EmptyEqualsAbsent bool `json:"empty_equals_absent,omitempty"`
+
+	// ComparisonChain lists comparison types tried in order instead of
+	// ComparisonType; the values are equal if any of them matches
+	ComparisonChain []ComparisonType `json:"comparison_chain,omitempty"`
+
+	// MaxDepth limits how deeply nested and map comparisons descend before
+	// reporting a difference (DefaultMaxCompareDepth when zero)
+	MaxDepth int `json:"max_depth,omitempty"`
+
+	// ReportSetDelta makes ArrayUnordered comparisons describe which elements
+	// were added or removed, and records them on the drift detail
+	ReportSetDelta bool `json:"report_set_delta,omitempty"`
+
+	// Pattern is the regular expression the AWS value must match for
+	// RegexMatch. It is unanchored; use ^ and $ to match the whole value.
+	Pattern string `json:"pattern,omitempty"`
+
+	// VersionConstraint is a comma-separated list of clauses such as
+	// ">=14.0, <15" that the AWS version must satisfy for SemverMatch, in
+	// place of matching the expected version
+	VersionConstraint string `json:"version_constraint,omitempty"`
+
+	// IgnoreKeys lists map keys MapComparison drops from both sides before
+	// comparing, such as tags AWS manages itself. Entries containing '*' are
+	// glob patterns (e.g. "aws:*").
+	IgnoreKeys []string `json:"ignore_keys,omitempty"`
+}
+
+// DefaultMaxCompareDepth is the nesting depth at which nested and map
+// comparisons stop when AttributeConfig.MaxDepth is unset
+const DefaultMaxCompareDepth = 32
+
+// maxDepth returns the effective comparison depth limit
+func (ac AttributeConfig) maxDepth() int {
+	if ac.MaxDepth > 0 {
+		return ac.MaxDepth
+	}
+	return DefaultMaxCompareDepth
 }
 
 // String returns a string representation of the AttributeConfig
@@ -272,6 +374,12 @@ func (ac *AttributeConfig) WithTolerance(tolerance float64) *AttributeConfig {
 	return ac
 }
 
+// WithFloatPrecision sets the number of decimals used when stringifying floats
+func (ac *AttributeConfig) WithFloatPrecision(precision int) *AttributeConfig {
+	ac.FloatPrecision = &precision
+	return ac
+}
+
 // WithDescription sets the description for the attribute
 func (ac *AttributeConfig) WithDescription(description string) *AttributeConfig {
 	ac.Description = description
@@ -0,0 +1,135 @@
+package drift
+
+import (
+	"fmt"
+	"sort"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// minNoisyFlaps is the number of drift/no-drift transitions after which an
+// attribute is considered to be flapping. One transition is an ordinary fix
+// or a new change; two or more means the drift comes and goes on its own.
+const minNoisyFlaps = 2
+
+// NoisyAttribute describes an attribute whose drift appears and disappears
+// across runs without a consistent pattern
+type NoisyAttribute struct {
+	// Attribute is the attribute name
+	Attribute string `json:"attribute"`
+
+	// Flaps is the number of times the attribute switched between drifted
+	// and not drifted across consecutive runs, summed over resources
+	Flaps int `json:"flaps"`
+
+	// FlapRate is Flaps divided by the number of consecutive run pairs in
+	// which the attribute could have flapped
+	FlapRate float64 `json:"flap_rate"`
+
+	// Resources lists the resources on which the attribute flapped
+	Resources []string `json:"resources"`
+
+	// Recommendation suggests how to handle the attribute
+	Recommendation string `json:"recommendation"`
+}
+
+// DetectNoisyAttributes analyzes several past runs, oldest first, and returns
+// attributes that drift inconsistently, ranked by flap rate. Runs in which a
+// resource is absent are skipped for that resource.
+func DetectNoisyAttributes(history []map[string]*interfaces.DriftResult) []NoisyAttribute {
+	type attributeKey struct {
+		resourceID string
+		attribute  string
+	}
+
+	// Collect every resource/attribute pair that drifted at least once
+	tracked := make(map[attributeKey]bool)
+	for _, run := range history {
+		for id, result := range run {
+			if result == nil {
+				continue
+			}
+			for _, detail := range result.DriftDetails {
+				if detail != nil {
+					tracked[attributeKey{resourceID: id, attribute: detail.Attribute}] = true
+				}
+			}
+		}
+	}
+
+	type attributeStats struct {
+		flaps       int
+		transitions int
+		resources   map[string]bool
+	}
+	stats := make(map[string]*attributeStats)
+
+	for key := range tracked {
+		attrStats := stats[key.attribute]
+		if attrStats == nil {
+			attrStats = &attributeStats{resources: make(map[string]bool)}
+			stats[key.attribute] = attrStats
+		}
+
+		var previous *bool
+		for _, run := range history {
+			result, ok := run[key.resourceID]
+			if !ok || result == nil {
+				continue
+			}
+			drifted := hasDriftedAttribute(result, key.attribute)
+			if previous != nil {
+				attrStats.transitions++
+				if *previous != drifted {
+					attrStats.flaps++
+					attrStats.resources[key.resourceID] = true
+				}
+			}
+			previous = &drifted
+		}
+	}
+
+	var noisy []NoisyAttribute
+	for attribute, attrStats := range stats {
+		if attrStats.flaps < minNoisyFlaps {
+			continue
+		}
+
+		resources := make([]string, 0, len(attrStats.resources))
+		for id := range attrStats.resources {
+			resources = append(resources, id)
+		}
+		sort.Strings(resources)
+
+		noisy = append(noisy, NoisyAttribute{
+			Attribute: attribute,
+			Flaps:     attrStats.flaps,
+			FlapRate:  float64(attrStats.flaps) / float64(attrStats.transitions),
+			Resources: resources,
+			Recommendation: fmt.Sprintf("Attribute '%s' flapped %d times across %d runs; consider adding it to ignored_attributes",
+				attribute, attrStats.flaps, len(history)),
+		})
+	}
+
+	sort.Slice(noisy, func(i, j int) bool {
+		if noisy[i].FlapRate != noisy[j].FlapRate {
+			return noisy[i].FlapRate > noisy[j].FlapRate
+		}
+		if noisy[i].Flaps != noisy[j].Flaps {
+			return noisy[i].Flaps > noisy[j].Flaps
+		}
+		return noisy[i].Attribute < noisy[j].Attribute
+	})
+
+	return noisy
+}
+
+// hasDriftedAttribute reports whether result has a drift detail for attribute
+func hasDriftedAttribute(result *interfaces.DriftResult, attribute string) bool {
+	for _, detail := range result.DriftDetails {
+		if detail != nil && detail.Attribute == attribute {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package drift
+
+import (
+	"testing"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func driftRun(attributes ...string) map[string]*interfaces.DriftResult {
+	result := &interfaces.DriftResult{ResourceID: "i-123", ResourceType: "aws_instance"}
+	for _, attr := range attributes {
+		result.DriftDetails = append(result.DriftDetails, &interfaces.DriftDetail{Attribute: attr})
+	}
+	result.IsDrifted = len(result.DriftDetails) > 0
+	return map[string]*interfaces.DriftResult{"i-123": result}
+}
+
+func TestDetectNoisyAttributes(t *testing.T) {
+	// tags flaps on and off, instance_type drifts consistently and
+	// ami is fixed once
+	history := []map[string]*interfaces.DriftResult{
+		driftRun("tags", "instance_type", "ami"),
+		driftRun("instance_type"),
+		driftRun("tags", "instance_type"),
+		driftRun("instance_type"),
+		driftRun("tags", "instance_type"),
+	}
+
+	noisy := DetectNoisyAttributes(history)
+	if len(noisy) != 1 {
+		t.Fatalf("Expected exactly one noisy attribute, got %+v", noisy)
+	}
+
+	if noisy[0].Attribute != "tags" {
+		t.Errorf("Expected tags to be flagged noisy, got %s", noisy[0].Attribute)
+	}
+	if noisy[0].Flaps != 4 {
+		t.Errorf("Expected 4 flaps, got %d", noisy[0].Flaps)
+	}
+	if noisy[0].FlapRate != 1 {
+		t.Errorf("Expected flap rate 1, got %f", noisy[0].FlapRate)
+	}
+	if len(noisy[0].Resources) != 1 || noisy[0].Resources[0] != "i-123" {
+		t.Errorf("Expected flapping resource i-123, got %v", noisy[0].Resources)
+	}
+}
+
+func TestDetectNoisyAttributes_Empty(t *testing.T) {
+	if noisy := DetectNoisyAttributes(nil); len(noisy) != 0 {
+		t.Errorf("Expected no noisy attributes for empty history, got %+v", noisy)
+	}
+}
@@ -0,0 +1,108 @@
+package drift
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// missingResourceAttribute is the attribute reported on results for resources
+// found on only one side
+const missingResourceAttribute = "resource"
+
+// PairingCoverage records how resource IDs were matched across the AWS and
+// Terraform sets. Unmatched ID lists are sorted.
+type PairingCoverage struct {
+	Matched       int      `json:"matched"`
+	AWSOnly       []string `json:"aws_only"`
+	TerraformOnly []string `json:"terraform_only"`
+}
+
+// DetectDriftByID pairs AWS resources and Terraform configs by ID and detects
+// drift on every pair. Resources found on only one side are reported as
+// missing resource drift, or only listed in the returned coverage when
+// IntersectionOnly is set.
+func (d *DriftDetector) DetectDriftByID(awsResources, terraformResources map[string]interface{}) (map[string]*interfaces.DriftResult, *PairingCoverage, error) {
+	d.mu.RLock()
+	intersectionOnly := d.config.IntersectionOnly
+	d.mu.RUnlock()
+
+	coverage := &PairingCoverage{AWSOnly: []string{}, TerraformOnly: []string{}}
+	var matched []string
+	for id := range awsResources {
+		if _, ok := terraformResources[id]; ok {
+			matched = append(matched, id)
+		} else {
+			coverage.AWSOnly = append(coverage.AWSOnly, id)
+		}
+	}
+	for id := range terraformResources {
+		if _, ok := awsResources[id]; !ok {
+			coverage.TerraformOnly = append(coverage.TerraformOnly, id)
+		}
+	}
+	sort.Strings(matched)
+	sort.Strings(coverage.AWSOnly)
+	sort.Strings(coverage.TerraformOnly)
+	coverage.Matched = len(matched)
+
+	pairs := make([]ResourcePair, len(matched))
+	for i, id := range matched {
+		pairs[i] = ResourcePair{Index: i, AWSResource: awsResources[id], TerraformConfig: terraformResources[id]}
+	}
+	batch, err := d.DetectDriftBatch(pairs)
+
+	results := make(map[string]*interfaces.DriftResult, len(awsResources)+len(coverage.TerraformOnly))
+	for i, id := range matched {
+		if batch[i] != nil {
+			results[id] = batch[i]
+		}
+	}
+	if err != nil {
+		return results, coverage, err
+	}
+
+	if !intersectionOnly {
+		for _, id := range coverage.AWSOnly {
+			results[id] = d.missingResourceResult(id, awsResources[id], interfaces.ReasonMissingInTerraform,
+				"Resource present in AWS but missing in Terraform configuration")
+		}
+		for _, id := range coverage.TerraformOnly {
+			results[id] = d.missingResourceResult(id, terraformResources[id], interfaces.ReasonMissingInAWS,
+				"Resource present in Terraform configuration but missing in AWS")
+		}
+	}
+
+	return results, coverage, nil
+}
+
+// missingResourceResult builds the drift result for a resource found on only
+// one side
+func (d *DriftDetector) missingResourceResult(id string, resource interface{}, reason interfaces.ReasonCode, description string) *interfaces.DriftResult {
+	d.mu.RLock()
+	resourceType := d.extractResourceType(resource)
+	d.mu.RUnlock()
+
+	detail := &interfaces.DriftDetail{
+		Attribute:   missingResourceAttribute,
+		Severity:    interfaces.SeverityHigh,
+		Description: fmt.Sprintf("%s: %s", description, id),
+		ReasonCode:  reason,
+	}
+	if reason == interfaces.ReasonMissingInAWS {
+		detail.ExpectedValue = id
+	} else {
+		detail.ActualValue = id
+	}
+
+	return &interfaces.DriftResult{
+		ResourceID:    id,
+		ResourceType:  resourceType,
+		IsDrifted:     true,
+		DetectionTime: time.Now(),
+		DriftDetails:  []*interfaces.DriftDetail{detail},
+		Severity:      interfaces.SeverityHigh,
+	}
+}
@@ -0,0 +1,62 @@
+package drift
+
+import (
+	"reflect"
+	"testing"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func TestDetectDriftByID(t *testing.T) {
+	instance := func(id, instanceType string) interface{} {
+		i := createBenchmarkInstance()
+		i.InstanceID = id
+		i.InstanceType = instanceType
+		return i
+	}
+	awsResources := map[string]interface{}{
+		"i-1": instance("i-1", "t3.micro"),
+		"i-2": instance("i-2", "t3.micro"),
+		"i-3": instance("i-3", "t3.micro"),
+	}
+	terraformResources := map[string]interface{}{
+		"i-2": instance("i-2", "t3.micro"),
+		"i-3": instance("i-3", "t3.large"),
+		"i-4": instance("i-4", "t3.micro"),
+	}
+
+	config := DefaultDetectionConfig()
+	config.IntersectionOnly = true
+	results, coverage, err := NewDriftDetector(config).DetectDriftByID(awsResources, terraformResources)
+	if err != nil {
+		t.Fatalf("DetectDriftByID() unexpected error: %v", err)
+	}
+
+	if len(results) != 2 || results["i-2"] == nil || results["i-3"] == nil {
+		t.Fatalf("Expected results for the intersection only, got %v", results)
+	}
+	if results["i-2"].IsDrifted || !results["i-3"].IsDrifted {
+		t.Errorf("Expected only i-3 to drift, got i-2=%v i-3=%v", results["i-2"].IsDrifted, results["i-3"].IsDrifted)
+	}
+	if coverage.Matched != 2 {
+		t.Errorf("Expected 2 matched resources, got %d", coverage.Matched)
+	}
+	if !reflect.DeepEqual(coverage.AWSOnly, []string{"i-1"}) || !reflect.DeepEqual(coverage.TerraformOnly, []string{"i-4"}) {
+		t.Errorf("Unexpected unmatched IDs: aws=%v terraform=%v", coverage.AWSOnly, coverage.TerraformOnly)
+	}
+
+	config.IntersectionOnly = false
+	results, _, err = NewDriftDetector(config).DetectDriftByID(awsResources, terraformResources)
+	if err != nil {
+		t.Fatalf("DetectDriftByID() unexpected error: %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("Expected results for all 4 IDs, got %d", len(results))
+	}
+	if got := results["i-1"].DriftDetails[0].ReasonCode; got != interfaces.ReasonMissingInTerraform {
+		t.Errorf("Expected i-1 to be missing in Terraform, got %s", got)
+	}
+	if got := results["i-4"].DriftDetails[0].ReasonCode; got != interfaces.ReasonMissingInAWS {
+		t.Errorf("Expected i-4 to be missing in AWS, got %s", got)
+	}
+}
@@ -0,0 +1,119 @@
+package drift
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"firefly-task/pkg/logging"
+)
+
+// CustomComparator compares an attribute's AWS and Terraform values and
+// returns whether they match along with a description of any difference
+type CustomComparator func(actual, expected interface{}) (bool, string)
+
+// ResourceMapper converts a resource into the attribute map used for comparison
+type ResourceMapper func(resource interface{}) (map[string]interface{}, error)
+
+// PluginPanicError reports a panic recovered from a custom comparator or
+// resource mapper
+type PluginPanicError struct {
+	// Plugin is either "comparator" or "mapper"
+	Plugin string
+	// Attribute is the attribute being compared, empty for mappers
+	Attribute string
+	// Resource is the resource ID for comparators and the resource type for mappers
+	Resource string
+	// Value is the value passed to panic
+	Value interface{}
+}
+
+func (e *PluginPanicError) Error() string {
+	if e.Attribute != "" {
+		return fmt.Sprintf("custom %s panicked on attribute %s of %s: %v", e.Plugin, e.Attribute, e.Resource, e.Value)
+	}
+	return fmt.Sprintf("custom %s panicked on %s: %v", e.Plugin, e.Resource, e.Value)
+}
+
+// RegisterComparator sets a custom comparator for an attribute, replacing the
+// configured comparison type. A nil comparator removes the registration.
+func (d *DriftDetector) RegisterComparator(attribute string, comparator CustomComparator) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	// Copy rather than modify: in-flight detections share the current map
+	comparators := make(map[string]CustomComparator, len(d.comparators)+1)
+	for name, existing := range d.comparators {
+		comparators[name] = existing
+	}
+	if comparator == nil {
+		delete(comparators, attribute)
+	} else {
+		comparators[attribute] = comparator
+	}
+	d.comparators = comparators
+}
+
+// RegisterResourceMapper sets a custom mapper for resources whose Go type
+// name (e.g. "*aws.EC2Instance") equals resourceType. It takes precedence over
+// the built-in converters. A nil mapper removes the registration.
+func (d *DriftDetector) RegisterResourceMapper(resourceType string, mapper ResourceMapper) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	// Copy rather than modify: in-flight detections share the current map
+	mappers := make(map[string]ResourceMapper, len(d.mappers)+1)
+	for name, existing := range d.mappers {
+		mappers[name] = existing
+	}
+	if mapper == nil {
+		delete(mappers, resourceType)
+	} else {
+		mappers[resourceType] = mapper
+	}
+	d.mappers = mappers
+}
+
+// builtinResourceTypes are the resource types DetectDrift converts without a
+// registered mapper
+var builtinResourceTypes = []string{"aws_instance", "aws_internet_gateway", "aws_nat_gateway", "aws_s3_bucket"}
+
+// ResourceTypes returns the built-in resource types followed by the Go type
+// names of registered resource mappers, sorted
+func (d *DriftDetector) ResourceTypes() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	mapped := make([]string, 0, len(d.mappers))
+	for resourceType := range d.mappers {
+		mapped = append(mapped, resourceType)
+	}
+	sort.Strings(mapped)
+	return append(append([]string{}, builtinResourceTypes...), mapped...)
+}
+
+// callComparator runs a custom comparator, converting a panic into an error
+func callComparator(comparator CustomComparator, attribute, resourceID string, actual, expected interface{}) (equal bool, description string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logging.Error("Recovered panic in custom comparator",
+				"attribute", attribute,
+				"resource_id", resourceID,
+				"panic", r)
+			err = &PluginPanicError{Plugin: "comparator", Attribute: attribute, Resource: resourceID, Value: r}
+		}
+	}()
+	equal, description = comparator(actual, expected)
+	return equal, description, nil
+}
+
+// callMapper runs a custom resource mapper, converting a panic into an error
+func callMapper(mapper ResourceMapper, resource interface{}) (m map[string]interface{}, err error) {
+	resourceType := reflect.TypeOf(resource).String()
+	defer func() {
+		if r := recover(); r != nil {
+			logging.Error("Recovered panic in custom resource mapper",
+				"resource_type", resourceType,
+				"panic", r)
+			err = &PluginPanicError{Plugin: "mapper", Resource: resourceType, Value: r}
+		}
+	}()
+	return mapper(resource)
+}
@@ -0,0 +1,63 @@
+package drift
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDetectDriftBatch_PanickingComparator(t *testing.T) {
+	detector := NewDriftDetector(DefaultDetectionConfig())
+	detector.RegisterComparator("instance_type", func(actual, expected interface{}) (bool, string) {
+		if actual == "t3.boom" {
+			panic("comparator exploded")
+		}
+		return actual == expected, "instance type differs"
+	})
+
+	healthy := createBenchmarkInstance()
+	healthyExpected := createBenchmarkInstance()
+	healthyExpected.InstanceType = "t3.large"
+
+	broken := createBenchmarkInstance()
+	broken.InstanceType = "t3.boom"
+
+	pairs := []ResourcePair{
+		{Index: 0, AWSResource: healthy, TerraformConfig: healthyExpected},
+		{Index: 1, AWSResource: broken, TerraformConfig: createBenchmarkInstance()},
+	}
+
+	results, err := detector.DetectDriftBatch(pairs)
+	if err == nil {
+		t.Fatal("Expected batch error for the panicking pair")
+	}
+	if !strings.Contains(err.Error(), "index 1") || !strings.Contains(err.Error(), "comparator exploded") {
+		t.Errorf("Expected error to identify the panicking pair, got %v", err)
+	}
+
+	if results[0] == nil || !results[0].IsDrifted {
+		t.Error("Expected the healthy pair to complete with drift")
+	}
+	if results[1] != nil {
+		t.Error("Expected the panicking pair to have no result")
+	}
+}
+
+func TestDetectDrift_PanickingMapper(t *testing.T) {
+	type customResource struct{ Name string }
+
+	detector := NewDriftDetector(DefaultDetectionConfig())
+	detector.RegisterResourceMapper("*drift.customResource", func(resource interface{}) (map[string]interface{}, error) {
+		panic("mapper exploded")
+	})
+
+	_, err := detector.DetectDrift(&customResource{Name: "a"}, &customResource{Name: "b"})
+
+	var panicErr *PluginPanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("Expected PluginPanicError, got %v", err)
+	}
+	if panicErr.Plugin != "mapper" || panicErr.Resource != "*drift.customResource" {
+		t.Errorf("Unexpected panic context: %+v", panicErr)
+	}
+}
@@ -0,0 +1,97 @@
+package drift
+
+import (
+	"math"
+	"strings"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// defaultSeverityWeights are the per-detail weights used by the drift and
+// health scores when no severity weights are given
+var defaultSeverityWeights = map[interfaces.SeverityLevel]int{
+	interfaces.SeverityCritical: 10,
+	interfaces.SeverityHigh:     5,
+	interfaces.SeverityMedium:   2,
+	interfaces.SeverityLow:      1,
+}
+
+// DefaultSeverityWeights returns a copy of the default per-detail severity
+// weights (critical=10, high=5, medium=2, low=1), for callers that want to
+// adjust some of them
+func DefaultSeverityWeights() map[interfaces.SeverityLevel]int {
+	weights := make(map[interfaces.SeverityLevel]int, len(defaultSeverityWeights))
+	for severity, weight := range defaultSeverityWeights {
+		weights[severity] = weight
+	}
+	return weights
+}
+
+// MaxHealthScore is the health score of a resource without drift
+const MaxHealthScore = 100
+
+// ComputeHealthScore rates result from MaxHealthScore (no drift) down to 0.
+// Each drift detail lowers it by its weight: its severity weight from weights
+// (the defaults when weights is nil) multiplied by its attribute weight from
+// attributeWeights, so drift on a heavily weighted attribute lowers it more
+// than an equally severe diff elsewhere. Attribute weights are looked up by
+// attribute name, then by the top-level attribute for nested names like
+// "tags.Owner"; unlisted attributes and non-positive weights count as 1.
+func ComputeHealthScore(result *interfaces.DriftResult, weights map[interfaces.SeverityLevel]int, attributeWeights map[string]float64) int {
+	health := MaxHealthScore - int(math.Round(weightedDrift(result, weights, attributeWeights)))
+	if health < 0 {
+		return 0
+	}
+	return health
+}
+
+// ComputeDriftScore is the total weight of result's drift details, weighted as
+// in ComputeHealthScore; a higher score means more, or more serious, drift. It
+// is rounded to the nearest integer.
+func ComputeDriftScore(result *interfaces.DriftResult, weights map[interfaces.SeverityLevel]int, attributeWeights map[string]float64) int {
+	return int(math.Round(weightedDrift(result, weights, attributeWeights)))
+}
+
+// ComputeBatchDriftScore is the drift score of every result together, so CI
+// can fail a run whose total drift score exceeds a threshold. The total is
+// rounded once, after summing.
+func ComputeBatchDriftScore(results map[string]*interfaces.DriftResult, weights map[interfaces.SeverityLevel]int, attributeWeights map[string]float64) int {
+	total := 0.0
+	for _, result := range results {
+		total += weightedDrift(result, weights, attributeWeights)
+	}
+	return int(math.Round(total))
+}
+
+// weightedDrift sums the severity and attribute weight of each drift detail
+func weightedDrift(result *interfaces.DriftResult, weights map[interfaces.SeverityLevel]int, attributeWeights map[string]float64) float64 {
+	if result == nil {
+		return 0
+	}
+	if weights == nil {
+		weights = defaultSeverityWeights
+	}
+
+	total := 0.0
+	for _, detail := range result.DriftDetails {
+		if detail == nil {
+			continue
+		}
+		total += float64(weights[detail.Severity]) * attributeWeight(attributeWeights, detail.Attribute)
+	}
+	return total
+}
+
+// attributeWeight returns the multiplier for attribute, falling back to its
+// top-level attribute and then to 1
+func attributeWeight(attributeWeights map[string]float64, attribute string) float64 {
+	if w, ok := attributeWeights[attribute]; ok && w > 0 {
+		return w
+	}
+	if top, _, nested := strings.Cut(attribute, "."); nested {
+		if w, ok := attributeWeights[top]; ok && w > 0 {
+			return w
+		}
+	}
+	return 1
+}
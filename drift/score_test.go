@@ -0,0 +1,114 @@
+package drift
+
+import (
+	"testing"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func TestComputeHealthScore_AttributeWeights(t *testing.T) {
+	encryptionDrift := &interfaces.DriftResult{
+		ResourceID: "vol-1",
+		IsDrifted:  true,
+		Severity:   interfaces.SeverityHigh,
+		DriftDetails: []*interfaces.DriftDetail{
+			{Attribute: "encrypted", Severity: interfaces.SeverityHigh},
+		},
+	}
+	tagDrift := &interfaces.DriftResult{
+		ResourceID: "vol-2",
+		IsDrifted:  true,
+		Severity:   interfaces.SeverityHigh,
+		DriftDetails: []*interfaces.DriftDetail{
+			{Attribute: "tags.Owner", Severity: interfaces.SeverityHigh},
+		},
+	}
+	attributeWeights := map[string]float64{"encrypted": 3}
+
+	if got := ComputeHealthScore(&interfaces.DriftResult{ResourceID: "vol-3"}, nil, attributeWeights); got != MaxHealthScore {
+		t.Errorf("Expected a resource without drift to score %d, got %d", MaxHealthScore, got)
+	}
+	if got, want := ComputeHealthScore(encryptionDrift, nil, nil), ComputeHealthScore(tagDrift, nil, nil); got != want {
+		t.Fatalf("Expected equally severe drift to score the same without attribute weights, got %d and %d", got, want)
+	}
+
+	weighted := ComputeHealthScore(encryptionDrift, nil, attributeWeights)
+	normal := ComputeHealthScore(tagDrift, nil, attributeWeights)
+	if weighted != 85 || normal != 95 {
+		t.Errorf("Expected weighted and normal health 85 and 95, got %d and %d", weighted, normal)
+	}
+	if weighted >= normal {
+		t.Errorf("Expected drift on a heavily weighted attribute to lower the score more, got %d vs %d", weighted, normal)
+	}
+
+	// Nested attributes fall back to the weight of their top-level attribute
+	if got := ComputeHealthScore(tagDrift, nil, map[string]float64{"tags": 2}); got != 90 {
+		t.Errorf("Expected tags weight to apply to tags.Owner, got %d", got)
+	}
+
+	severityWeights := map[interfaces.SeverityLevel]int{interfaces.SeverityHigh: 4}
+	if got := ComputeHealthScore(encryptionDrift, severityWeights, map[string]float64{"encrypted": 1.5}); got != 94 {
+		t.Errorf("Expected severity and attribute weights to multiply, got %d", got)
+	}
+	if got := ComputeHealthScore(encryptionDrift, nil, map[string]float64{"encrypted": -2}); got != 95 {
+		t.Errorf("Expected non-positive attribute weights to be ignored, got %d", got)
+	}
+	if got := ComputeHealthScore(encryptionDrift, nil, map[string]float64{"encrypted": 50}); got != 0 {
+		t.Errorf("Expected the health score to stop at 0, got %d", got)
+	}
+}
+
+func TestComputeBatchDriftScore_RoundsTotal(t *testing.T) {
+	result := &interfaces.DriftResult{
+		ResourceID:   "vol-1",
+		IsDrifted:    true,
+		DriftDetails: []*interfaces.DriftDetail{{Attribute: "encrypted", Severity: interfaces.SeverityLow}},
+	}
+	results := map[string]*interfaces.DriftResult{"a": result, "b": result, "c": result}
+	attributeWeights := map[string]float64{"encrypted": 1.4}
+
+	// Each result scores 1.4, which alone rounds to 1
+	if got := ComputeDriftScore(result, nil, attributeWeights); got != 1 {
+		t.Errorf("Expected a single result to score 1, got %d", got)
+	}
+	if got := ComputeBatchDriftScore(results, nil, attributeWeights); got != 4 {
+		t.Errorf("Expected the 4.2 total to round to 4, got %d", got)
+	}
+}
+
+func TestComputeDriftScore_MixedSeverities(t *testing.T) {
+	detector := NewDriftDetector(DefaultDetectionConfig())
+	result, err := detector.DetectDrift(createMixedDriftPair())
+	if err != nil {
+		t.Fatalf("DetectDrift() error = %v", err)
+	}
+
+	// instance_type and security_groups are critical, monitoring is high,
+	// tags is medium, and subnet_id, vpc_id, availability_zone and
+	// ebs_optimized are low
+	if got := ComputeDriftScore(result, nil, nil); got != 31 {
+		t.Errorf("Expected default score 31, got %d", got)
+	}
+	if got := ComputeDriftScore(result, DefaultSeverityWeights(), nil); got != 31 {
+		t.Errorf("Expected DefaultSeverityWeights to match the defaults, got %d", got)
+	}
+
+	weights := DefaultSeverityWeights()
+	weights[interfaces.SeverityLow] = 0
+	if got := ComputeDriftScore(result, weights, nil); got != 27 {
+		t.Errorf("Expected score 27 with low severity drift ignored, got %d", got)
+	}
+	if defaultSeverityWeights[interfaces.SeverityLow] != 1 {
+		t.Error("Expected changes to DefaultSeverityWeights not to affect the defaults")
+	}
+
+	clean := &interfaces.DriftResult{ResourceID: "i-clean", Severity: interfaces.SeverityNone}
+	results := map[string]*interfaces.DriftResult{"mixed": result, "clean": clean, "missing": nil}
+	if got := ComputeBatchDriftScore(results, nil, nil); got != 31 {
+		t.Errorf("Expected batch score 31, got %d", got)
+	}
+	results["mixed-again"] = result
+	if got := ComputeBatchDriftScore(results, nil, nil); got != 62 {
+		t.Errorf("Expected batch score to sum results, got %d", got)
+	}
+}
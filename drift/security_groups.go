@@ -0,0 +1,180 @@
+package drift
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"firefly-task/aws"
+	"firefly-task/pkg/interfaces"
+)
+
+// SecurityGroupRuleResolver resolves a security group ID to its rules
+type SecurityGroupRuleResolver func(groupID string) ([]aws.SecurityGroupRule, error)
+
+// SetSecurityGroupRuleResolver sets the resolver used when
+// DetectionConfig.CompareSecurityGroupRules is enabled
+func (d *DriftDetector) SetSecurityGroupRuleResolver(resolver SecurityGroupRuleResolver) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sgRuleResolver = resolver
+}
+
+// ruleDetailsEnabled reports whether security group drift is reported per rule
+func (d *DriftDetector) ruleDetailsEnabled() bool {
+	return d.config.CompareSecurityGroupRules && d.config.SecurityGroupRuleDetails && d.sgRuleResolver != nil
+}
+
+// resolveSecurityGroupRules replaces a list of security group IDs with the
+// normalized, sorted set of rules those groups grant
+func (d *DriftDetector) resolveSecurityGroupRules(value interface{}) ([]string, error) {
+	groupIDs, err := convertToSlice(value)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var rules []string
+	for _, id := range groupIDs {
+		groupRules, err := d.sgRuleResolver(fmt.Sprintf("%v", id))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve rules for security group %v: %w", id, err)
+		}
+		for _, rule := range groupRules {
+			for _, normalized := range normalizeSecurityGroupRule(rule) {
+				if !seen[normalized] {
+					seen[normalized] = true
+					rules = append(rules, normalized)
+				}
+			}
+		}
+	}
+
+	sort.Strings(rules)
+	return rules, nil
+}
+
+// normalizeSecurityGroupRule expands a rule into one canonical string per CIDR block
+func normalizeSecurityGroupRule(rule aws.SecurityGroupRule) []string {
+	protocol := strings.ToLower(rule.Protocol)
+	if protocol == "all" {
+		protocol = "-1"
+	}
+	prefix := fmt.Sprintf("%s %s %d-%d", strings.ToLower(rule.Direction), protocol, rule.FromPort, rule.ToPort)
+
+	if len(rule.CIDRBlocks) == 0 {
+		return []string{prefix}
+	}
+
+	normalized := make([]string, 0, len(rule.CIDRBlocks))
+	for _, cidr := range rule.CIDRBlocks {
+		normalized = append(normalized, prefix+" "+strings.TrimSpace(cidr))
+	}
+	return normalized
+}
+
+// resolveSecurityGroupRuleSet resolves a list of security group IDs to the
+// CIDR blocks granted per rule, keyed by rule attribute name (e.g.
+// ingress_rule[tcp/443]). CIDR blocks are deduplicated and sorted, so rule
+// and CIDR order do not matter.
+func (d *DriftDetector) resolveSecurityGroupRuleSet(value interface{}) (map[string][]string, error) {
+	groupIDs, err := convertToSlice(value)
+	if err != nil {
+		return nil, err
+	}
+
+	cidrs := make(map[string]map[string]bool)
+	for _, id := range groupIDs {
+		groupRules, err := d.sgRuleResolver(fmt.Sprintf("%v", id))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve rules for security group %v: %w", id, err)
+		}
+		for _, rule := range groupRules {
+			key := securityGroupRuleAttribute(rule)
+			if cidrs[key] == nil {
+				cidrs[key] = make(map[string]bool)
+			}
+			for _, cidr := range rule.CIDRBlocks {
+				cidrs[key][strings.TrimSpace(cidr)] = true
+			}
+		}
+	}
+
+	ruleSet := make(map[string][]string, len(cidrs))
+	for key, blocks := range cidrs {
+		sorted := make([]string, 0, len(blocks))
+		for cidr := range blocks {
+			sorted = append(sorted, cidr)
+		}
+		sort.Strings(sorted)
+		ruleSet[key] = sorted
+	}
+	return ruleSet, nil
+}
+
+// securityGroupRuleAttribute names the drift attribute for a rule, e.g.
+// ingress_rule[tcp/443] or egress_rule[all/0-65535]
+func securityGroupRuleAttribute(rule aws.SecurityGroupRule) string {
+	protocol := strings.ToLower(rule.Protocol)
+	if protocol == "-1" {
+		protocol = "all"
+	}
+	ports := fmt.Sprintf("%d", rule.FromPort)
+	if rule.ToPort != rule.FromPort {
+		ports = fmt.Sprintf("%d-%d", rule.FromPort, rule.ToPort)
+	}
+	return fmt.Sprintf("%s_rule[%s/%s]", strings.ToLower(rule.Direction), protocol, ports)
+}
+
+// securityGroupRuleDrift compares the rules granted by the security groups on
+// each side and returns one detail per added, removed or modified rule,
+// sorted by attribute. Rules are compared as unordered sets.
+func (d *DriftDetector) securityGroupRuleDrift(awsGroups, terraformGroups interface{}) ([]*interfaces.DriftDetail, error) {
+	awsRules, err := d.resolveSecurityGroupRuleSet(awsGroups)
+	if err != nil {
+		return nil, err
+	}
+	terraformRules, err := d.resolveSecurityGroupRuleSet(terraformGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(awsRules)+len(terraformRules))
+	for key := range awsRules {
+		keys = append(keys, key)
+	}
+	for key := range terraformRules {
+		if _, ok := awsRules[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	severity := d.attributeSeverity("security_groups", awsGroups, terraformGroups)
+	var details []*interfaces.DriftDetail
+	for _, key := range keys {
+		actual, inAWS := awsRules[key]
+		expected, inTerraform := terraformRules[key]
+		detail := &interfaces.DriftDetail{Attribute: key, Severity: severity}
+		switch {
+		case !inTerraform:
+			detail.ActualValue = actual
+			detail.Description = fmt.Sprintf("Security group rule %s added outside Terraform: %v", key, actual)
+			detail.ReasonCode = interfaces.ReasonMissingInTerraform
+		case !inAWS:
+			detail.ExpectedValue = expected
+			detail.Description = fmt.Sprintf("Security group rule %s removed from AWS: %v", key, expected)
+			detail.ReasonCode = interfaces.ReasonMissingInAWS
+		case !reflect.DeepEqual(actual, expected):
+			detail.ActualValue = actual
+			detail.ExpectedValue = expected
+			detail.Description = fmt.Sprintf("Security group rule %s modified: CIDR blocks %v, expected %v", key, actual, expected)
+			detail.ReasonCode = interfaces.ReasonValueChanged
+		default:
+			continue
+		}
+		details = append(details, detail)
+	}
+	return details, nil
+}
@@ -0,0 +1,275 @@
+package drift
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"firefly-task/aws"
+	"firefly-task/pkg/interfaces"
+	"firefly-task/terraform"
+)
+
+func staticRuleResolver(rules map[string][]aws.SecurityGroupRule) SecurityGroupRuleResolver {
+	return func(groupID string) ([]aws.SecurityGroupRule, error) {
+		groupRules, ok := rules[groupID]
+		if !ok {
+			return nil, fmt.Errorf("unknown security group %s", groupID)
+		}
+		return groupRules, nil
+	}
+}
+
+func TestDetectDrift_SecurityGroupRules(t *testing.T) {
+	https := aws.SecurityGroupRule{Direction: aws.RuleDirectionIngress, Protocol: "tcp", FromPort: 443, ToPort: 443, CIDRBlocks: []string{"10.0.0.0/8"}}
+	ssh := aws.SecurityGroupRule{Direction: aws.RuleDirectionIngress, Protocol: "TCP", FromPort: 22, ToPort: 22, CIDRBlocks: []string{"10.1.0.0/16"}}
+	openSSH := aws.SecurityGroupRule{Direction: aws.RuleDirectionIngress, Protocol: "tcp", FromPort: 22, ToPort: 22, CIDRBlocks: []string{"0.0.0.0/0"}}
+
+	resolver := staticRuleResolver(map[string][]aws.SecurityGroupRule{
+		"sg-prod":    {https, ssh},
+		"sg-staging": {ssh, https},
+		"sg-open":    {https, openSSH},
+	})
+
+	config := DefaultDetectionConfig()
+	config.CompareSecurityGroupRules = true
+
+	tests := []struct {
+		name        string
+		awsGroup    string
+		tfGroup     string
+		expectDrift bool
+	}{
+		{"different groups with same rules", "sg-staging", "sg-prod", false},
+		{"different groups with different rules", "sg-open", "sg-prod", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := NewDriftDetector(config)
+			detector.SetSecurityGroupRuleResolver(resolver)
+
+			awsInstance := &aws.EC2Instance{
+				InstanceID:     "i-123",
+				InstanceType:   "t3.micro",
+				SecurityGroups: []aws.SecurityGroup{{GroupID: tt.awsGroup}},
+			}
+			terraformConfig := &terraform.TerraformConfig{
+				InstanceID:        "i-123",
+				InstanceType:      "t3.micro",
+				SecurityGroupRefs: []string{tt.tfGroup},
+			}
+
+			result, err := detector.DetectDrift(awsInstance, terraformConfig)
+			if err != nil {
+				t.Fatalf("DetectDrift() error = %v", err)
+			}
+
+			var sgDetail *interfaces.DriftDetail
+			for _, detail := range result.DriftDetails {
+				if detail.Attribute == "security_groups" {
+					sgDetail = detail
+				}
+			}
+
+			if tt.expectDrift && sgDetail == nil {
+				t.Fatal("Expected security group rule drift")
+			}
+			if !tt.expectDrift && sgDetail != nil {
+				t.Fatalf("Expected no security group drift, got %s", sgDetail.Description)
+			}
+			if sgDetail != nil && sgDetail.Severity != interfaces.SeverityCritical {
+				t.Errorf("Expected critical severity, got %v", sgDetail.Severity)
+			}
+		})
+	}
+}
+
+func TestDetectDrift_SecurityGroupRulesResolverError(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.CompareSecurityGroupRules = true
+	detector := NewDriftDetector(config)
+	detector.SetSecurityGroupRuleResolver(staticRuleResolver(nil))
+
+	_, err := detector.DetectDrift(
+		&aws.EC2Instance{InstanceID: "i-123", SecurityGroups: []aws.SecurityGroup{{GroupID: "sg-missing"}}},
+		&terraform.TerraformConfig{InstanceID: "i-123"},
+	)
+	if err == nil {
+		t.Fatal("Expected resolver error to be returned")
+	}
+}
+
+func TestNormalizeSecurityGroupRule(t *testing.T) {
+	rule := aws.SecurityGroupRule{Direction: "INGRESS", Protocol: "all", FromPort: 0, ToPort: 0, CIDRBlocks: []string{"10.0.0.0/8", "192.168.0.0/16"}}
+	got := normalizeSecurityGroupRule(rule)
+	want := []string{"ingress -1 0-0 10.0.0.0/8", "ingress -1 0-0 192.168.0.0/16"}
+
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d rules, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected %q, got %q", want[i], got[i])
+		}
+	}
+}
+
+func TestDetectDrift_SecurityGroupRuleDetails(t *testing.T) {
+	https := aws.SecurityGroupRule{Direction: aws.RuleDirectionIngress, Protocol: "tcp", FromPort: 443, ToPort: 443, CIDRBlocks: []string{"10.0.0.0/8", "172.16.0.0/12"}}
+	httpsReordered := aws.SecurityGroupRule{Direction: aws.RuleDirectionIngress, Protocol: "tcp", FromPort: 443, ToPort: 443, CIDRBlocks: []string{"172.16.0.0/12", "10.0.0.0/8"}}
+	httpsWide := aws.SecurityGroupRule{Direction: aws.RuleDirectionIngress, Protocol: "tcp", FromPort: 443, ToPort: 443, CIDRBlocks: []string{"0.0.0.0/0"}}
+	ssh := aws.SecurityGroupRule{Direction: aws.RuleDirectionIngress, Protocol: "tcp", FromPort: 22, ToPort: 22, CIDRBlocks: []string{"10.1.0.0/16"}}
+	egress := aws.SecurityGroupRule{Direction: aws.RuleDirectionEgress, Protocol: "-1", FromPort: 0, ToPort: 65535, CIDRBlocks: []string{"0.0.0.0/0"}}
+
+	resolver := staticRuleResolver(map[string][]aws.SecurityGroupRule{
+		"sg-expected":  {https, ssh, egress},
+		"sg-reordered": {egress, ssh, httpsReordered},
+		"sg-widened":   {httpsWide, ssh, egress},
+		"sg-changed":   {https, egress, {Direction: aws.RuleDirectionIngress, Protocol: "tcp", FromPort: 8080, ToPort: 8090, CIDRBlocks: []string{"10.2.0.0/16"}}},
+	})
+
+	config := DefaultDetectionConfig()
+	config.CompareSecurityGroupRules = true
+	config.SecurityGroupRuleDetails = true
+
+	tests := []struct {
+		name     string
+		awsGroup string
+		want     map[string]interfaces.ReasonCode
+	}{
+		{"reordered rules and CIDR blocks", "sg-reordered", map[string]interfaces.ReasonCode{}},
+		{"widened CIDR", "sg-widened", map[string]interfaces.ReasonCode{
+			"ingress_rule[tcp/443]": interfaces.ReasonValueChanged,
+		}},
+		{"added and removed rules", "sg-changed", map[string]interfaces.ReasonCode{
+			"ingress_rule[tcp/22]":        interfaces.ReasonMissingInAWS,
+			"ingress_rule[tcp/8080-8090]": interfaces.ReasonMissingInTerraform,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := NewDriftDetector(config)
+			detector.SetSecurityGroupRuleResolver(resolver)
+
+			result, err := detector.DetectDrift(
+				&aws.EC2Instance{InstanceID: "i-123", InstanceType: "t3.micro", SecurityGroups: []aws.SecurityGroup{{GroupID: tt.awsGroup}}},
+				&terraform.TerraformConfig{InstanceID: "i-123", InstanceType: "t3.micro", SecurityGroupRefs: []string{"sg-expected"}},
+			)
+			if err != nil {
+				t.Fatalf("DetectDrift() error = %v", err)
+			}
+
+			var ruleDetails []*interfaces.DriftDetail
+			for _, detail := range result.DriftDetails {
+				if strings.Contains(detail.Attribute, "_rule[") || detail.Attribute == "security_groups" {
+					ruleDetails = append(ruleDetails, detail)
+				}
+			}
+			if len(ruleDetails) != len(tt.want) {
+				t.Fatalf("Expected %d security group drift details, got %d", len(tt.want), len(ruleDetails))
+			}
+			for _, detail := range ruleDetails {
+				reason, ok := tt.want[detail.Attribute]
+				if !ok {
+					t.Errorf("Unexpected drift on %s: %s", detail.Attribute, detail.Description)
+					continue
+				}
+				if detail.ReasonCode != reason {
+					t.Errorf("Expected %s reason for %s, got %s", reason, detail.Attribute, detail.ReasonCode)
+				}
+				if detail.Severity != interfaces.SeverityCritical {
+					t.Errorf("Expected critical severity for %s, got %v", detail.Attribute, detail.Severity)
+				}
+			}
+		})
+	}
+}
+
+func TestExplainDrift_SecurityGroupRuleDetails(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.CompareSecurityGroupRules = true
+	config.SecurityGroupRuleDetails = true
+	detector := NewDriftDetector(config)
+	detector.SetSecurityGroupRuleResolver(staticRuleResolver(map[string][]aws.SecurityGroupRule{
+		"sg-a": {{Direction: aws.RuleDirectionIngress, Protocol: "tcp", FromPort: 443, ToPort: 443, CIDRBlocks: []string{"0.0.0.0/0"}}},
+		"sg-b": {{Direction: aws.RuleDirectionIngress, Protocol: "tcp", FromPort: 443, ToPort: 443, CIDRBlocks: []string{"10.0.0.0/8"}}},
+	}))
+
+	explanation, err := detector.ExplainDrift(
+		&aws.EC2Instance{InstanceID: "i-123", SecurityGroups: []aws.SecurityGroup{{GroupID: "sg-a"}}},
+		&terraform.TerraformConfig{InstanceID: "i-123", SecurityGroupRefs: []string{"sg-b"}},
+	)
+	if err != nil {
+		t.Fatalf("ExplainDrift() error = %v", err)
+	}
+
+	var found bool
+	for _, decision := range explanation.Decisions {
+		if decision.Attribute == "security_groups" {
+			t.Errorf("Expected group-level decision to be replaced by rule decisions")
+		}
+		if decision.Attribute == "ingress_rule[tcp/443]" {
+			found = true
+			if decision.Decision != DecisionDrift || decision.Comparator != "security_group_rule" {
+				t.Errorf("Unexpected rule decision: %+v", decision)
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected a decision for ingress_rule[tcp/443]")
+	}
+}
+
+func TestSecurityGroupRuleAttribute(t *testing.T) {
+	tests := []struct {
+		rule aws.SecurityGroupRule
+		want string
+	}{
+		{aws.SecurityGroupRule{Direction: "INGRESS", Protocol: "TCP", FromPort: 443, ToPort: 443}, "ingress_rule[tcp/443]"},
+		{aws.SecurityGroupRule{Direction: aws.RuleDirectionEgress, Protocol: "-1", FromPort: 0, ToPort: 65535}, "egress_rule[all/0-65535]"},
+	}
+	for _, tt := range tests {
+		if got := securityGroupRuleAttribute(tt.rule); got != tt.want {
+			t.Errorf("securityGroupRuleAttribute() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestDetectDrift_UnknownSecurityGroupRefsSkipRules(t *testing.T) {
+	configs, err := terraform.ParseHCLDir("../terraform/testdata/hcl_instances")
+	if err != nil {
+		t.Fatalf("ParseHCLDir() error = %v", err)
+	}
+	// vpc_security_group_ids = [aws_security_group.app.id]
+	terraformConfig := configs["aws_instance.app"]
+	if terraformConfig == nil {
+		t.Fatal("Expected aws_instance.app in the fixture")
+	}
+	resolver := staticRuleResolver(map[string][]aws.SecurityGroupRule{"sg-app": nil})
+
+	for _, details := range []bool{false, true} {
+		config := DefaultDetectionConfig()
+		config.CompareSecurityGroupRules = true
+		config.SecurityGroupRuleDetails = details
+		detector := NewDriftDetector(config)
+		detector.SetSecurityGroupRuleResolver(resolver)
+
+		awsInstance := &aws.EC2Instance{
+			InstanceID:     terraformConfig.InstanceID,
+			InstanceType:   "t3.micro",
+			SecurityGroups: []aws.SecurityGroup{{GroupID: "sg-app"}},
+		}
+
+		result, err := detector.DetectDrift(awsInstance, terraformConfig)
+		if err != nil {
+			t.Fatalf("DetectDrift() with rule details %t error = %v", details, err)
+		}
+		for _, detail := range result.DriftDetails {
+			if detail.Attribute == "security_groups" || strings.HasPrefix(detail.Attribute, "security_groups.") {
+				t.Errorf("Expected unresolved security groups to be skipped, got %s", detail.Description)
+			}
+		}
+	}
+}
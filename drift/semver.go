@@ -0,0 +1,160 @@
+package drift
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// semver is a parsed semantic version
+type semver struct {
+	major, minor, patch uint64
+	prerelease          string
+}
+
+// String returns the version as MAJOR.MINOR.PATCH[-PRERELEASE]
+func (v semver) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+	if v.prerelease != "" {
+		s += "-" + v.prerelease
+	}
+	return s
+}
+
+// compare returns -1, 0 or 1 as v is lower than, equal to or higher than
+// other. A prerelease is lower than its release; prereleases of the same
+// version compare lexically.
+func (v semver) compare(other semver) int {
+	for _, pair := range [][2]uint64{{v.major, other.major}, {v.minor, other.minor}, {v.patch, other.patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case v.prerelease == other.prerelease:
+		return 0
+	case v.prerelease == "":
+		return 1
+	case other.prerelease == "":
+		return -1
+	case v.prerelease < other.prerelease:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// parseSemverVersion parses a semantic version, dropping a leading "v" and
+// build metadata and filling in missing minor and patch components with 0.
+// Components are numeric, so "14.07" equals "14.7".
+func parseSemverVersion(s string) (semver, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	var v semver
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		s, v.prerelease = s[:i], s[i+1:]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return semver{}, false
+	}
+	numbers := []*uint64{&v.major, &v.minor, &v.patch}
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return semver{}, false
+		}
+		*numbers[i] = n
+	}
+	return v, true
+}
+
+// parseSemver normalizes a semantic version to MAJOR.MINOR.PATCH[-PRERELEASE]
+func parseSemver(s string) (string, bool) {
+	v, ok := parseSemverVersion(s)
+	if !ok {
+		return "", false
+	}
+	return v.String(), true
+}
+
+// versionClause is one clause of a version constraint, e.g. ">=14.0"
+type versionClause struct {
+	operator string
+	version  semver
+}
+
+// versionOperators lists the supported operators, longest first so that
+// ">=" is not read as ">"
+var versionOperators = []string{">=", "<=", "!=", ">", "<", "="}
+
+// parseVersionConstraint parses a comma-separated list of clauses. A clause
+// without an operator requires an equal version.
+func parseVersionConstraint(expr string) ([]versionClause, error) {
+	var clauses []versionClause
+	for _, raw := range strings.Split(expr, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			return nil, fmt.Errorf("invalid version constraint %q: empty clause", expr)
+		}
+		clause := versionClause{operator: "="}
+		for _, op := range versionOperators {
+			if strings.HasPrefix(raw, op) {
+				clause.operator = op
+				raw = strings.TrimSpace(raw[len(op):])
+				break
+			}
+		}
+		version, ok := parseSemverVersion(raw)
+		if !ok {
+			return nil, fmt.Errorf("invalid version constraint %q: %q is not a version", expr, raw)
+		}
+		clause.version = version
+		clauses = append(clauses, clause)
+	}
+	return clauses, nil
+}
+
+// satisfies reports whether v satisfies the clause
+func (c versionClause) satisfies(v semver) bool {
+	cmp := v.compare(c.version)
+	switch c.operator {
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	case "!=":
+		return cmp != 0
+	default:
+		return cmp == 0
+	}
+}
+
+// compareVersionConstraint reports whether actual satisfies every clause of
+// constraint
+func compareVersionConstraint(actual, constraint string) (bool, string) {
+	clauses, err := parseVersionConstraint(constraint)
+	if err != nil {
+		return false, err.Error()
+	}
+	version, ok := parseSemverVersion(actual)
+	if !ok {
+		return false, fmt.Sprintf("version constraint %q: '%s' is not a valid version", constraint, actual)
+	}
+	for _, clause := range clauses {
+		if !clause.satisfies(version) {
+			return false, fmt.Sprintf("version %s does not satisfy %s%s", version, clause.operator, clause.version)
+		}
+	}
+	return true, fmt.Sprintf("version %s satisfies %q", version, constraint)
+}
@@ -0,0 +1,84 @@
+package drift
+
+import (
+	"sort"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// SeverityChange is a resource whose overall severity differs under a
+// simulated configuration
+type SeverityChange struct {
+	ResourceID string                   `json:"resource_id"`
+	From       interfaces.SeverityLevel `json:"from"`
+	To         interfaces.SeverityLevel `json:"to"`
+}
+
+// SimulateSeverity recomputes the overall severity of each result under the
+// proposed config's severity overrides and floor rules, reusing the recorded
+// drift details instead of re-running detection. Attributes missing on one
+// side keep their recorded severity, as overrides only apply to value
+// differences. Results are keyed as in results; clean results map to
+// SeverityNone.
+func SimulateSeverity(results map[string]*interfaces.DriftResult, proposed DetectionConfig) map[string]interfaces.SeverityLevel {
+	detector := NewDriftDetector(proposed)
+	simulated := make(map[string]interfaces.SeverityLevel, len(results))
+
+	for key, result := range results {
+		if result == nil {
+			continue
+		}
+		if !result.IsDrifted || len(result.DriftDetails) == 0 {
+			simulated[key] = interfaces.SeverityNone
+			continue
+		}
+
+		// Work on copies so floors don't modify the caller's details
+		preview := &interfaces.DriftResult{
+			ResourceID:   result.ResourceID,
+			ResourceType: result.ResourceType,
+		}
+		for _, detail := range result.DriftDetails {
+			if detail == nil {
+				continue
+			}
+			copied := *detail
+			switch detail.ReasonCode {
+			case interfaces.ReasonMissingInAWS, interfaces.ReasonMissingInTerraform:
+			default:
+				copied.Severity = detector.attributeSeverity(detector.toSnakeCase(detail.Attribute), detail.ActualValue, detail.ExpectedValue)
+			}
+			preview.DriftDetails = append(preview.DriftDetails, &copied)
+		}
+		detector.applySeverityFloors(preview)
+		simulated[key] = preview.GetHighestSeverity()
+	}
+
+	return simulated
+}
+
+// SeverityChanges lists the results whose severity in simulated differs from
+// their recorded severity, sorted by key
+func SeverityChanges(results map[string]*interfaces.DriftResult, simulated map[string]interfaces.SeverityLevel) []SeverityChange {
+	keys := make([]string, 0, len(simulated))
+	for key := range simulated {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var changes []SeverityChange
+	for _, key := range keys {
+		result := results[key]
+		if result == nil {
+			continue
+		}
+		from := result.Severity
+		if from == "" {
+			from = interfaces.SeverityNone
+		}
+		if to := simulated[key]; to != from {
+			changes = append(changes, SeverityChange{ResourceID: key, From: from, To: to})
+		}
+	}
+	return changes
+}
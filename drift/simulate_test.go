@@ -0,0 +1,94 @@
+package drift
+
+import (
+	"testing"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func TestSimulateSeverity(t *testing.T) {
+	detector := NewDriftDetector(DefaultDetectionConfig())
+
+	tagged := createBenchmarkInstance()
+	taggedExpected := createBenchmarkInstance()
+	taggedExpected.Tags = map[string]string{"Name": "web", "Env": "staging", "Team": "platform"}
+
+	resized := createBenchmarkInstance()
+	resizedExpected := createBenchmarkInstance()
+	resizedExpected.InstanceType = "t3.large"
+	resizedExpected.Tags = map[string]string{"Name": "web"}
+
+	results := make(map[string]*interfaces.DriftResult)
+	for key, pair := range map[string][2]interface{}{
+		"tagged":  {tagged, taggedExpected},
+		"resized": {resized, resizedExpected},
+		"clean":   {createBenchmarkInstance(), createBenchmarkInstance()},
+	} {
+		result, err := detector.DetectDrift(pair[0], pair[1])
+		if err != nil {
+			t.Fatalf("DetectDrift(%s) unexpected error: %v", key, err)
+		}
+		results[key] = result
+	}
+	if results["tagged"].Severity != interfaces.SeverityMedium {
+		t.Fatalf("Expected tag drift to be medium before simulation, got %s", results["tagged"].Severity)
+	}
+
+	proposed := DefaultDetectionConfig()
+	proposed.SeverityOverrides = map[string]interfaces.SeverityLevel{"tags": interfaces.SeverityHigh}
+
+	simulated := SimulateSeverity(results, proposed)
+
+	want := map[string]interfaces.SeverityLevel{
+		"tagged":  interfaces.SeverityHigh,
+		"resized": interfaces.SeverityCritical,
+		"clean":   interfaces.SeverityNone,
+	}
+	for key, severity := range want {
+		if simulated[key] != severity {
+			t.Errorf("Simulated severity for %s = %s, want %s", key, simulated[key], severity)
+		}
+	}
+	for _, detail := range results["tagged"].DriftDetails {
+		if detail.Severity != interfaces.SeverityMedium {
+			t.Errorf("SimulateSeverity modified recorded detail severity: %s", detail.Severity)
+		}
+	}
+
+	changes := SeverityChanges(results, simulated)
+	if len(changes) != 1 || changes[0].ResourceID != "tagged" ||
+		changes[0].From != interfaces.SeverityMedium || changes[0].To != interfaces.SeverityHigh {
+		t.Errorf("Expected only tagged to change medium -> high, got %+v", changes)
+	}
+}
+
+func TestSeverityOverridesConfig(t *testing.T) {
+	file := DetectionConfigFile{
+		MaxConcurrency: 1,
+		TimeoutSeconds: 30,
+		Extensions:     ExtensionConfig{SeverityRules: map[string]string{"tags": "HIGH"}},
+	}
+	config := file.ToDetectionConfig()
+	if config.SeverityOverrides["tags"] != interfaces.SeverityHigh {
+		t.Fatalf("Expected tags override high, got %v", config.SeverityOverrides)
+	}
+	if DetectionConfigFileFromConfig(config).Extensions.SeverityRules["tags"] != "high" {
+		t.Error("Expected severity override to round-trip to severity_rules")
+	}
+
+	detector := NewDriftDetector(config)
+	expected := createBenchmarkInstance()
+	expected.Tags = map[string]string{"Name": "api"}
+	result, err := detector.DetectDrift(createBenchmarkInstance(), expected)
+	if err != nil {
+		t.Fatalf("DetectDrift() unexpected error: %v", err)
+	}
+	if result.Severity != interfaces.SeverityHigh {
+		t.Errorf("Expected overridden tag drift to be high, got %s", result.Severity)
+	}
+
+	config.SeverityOverrides["tags"] = "urgent"
+	if err := NewConfigValidator().ValidateConfig(config); err == nil {
+		t.Error("Expected an invalid severity override to fail validation")
+	}
+}
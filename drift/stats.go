@@ -0,0 +1,71 @@
+package drift
+
+import (
+	"sync"
+	"time"
+)
+
+// ComparisonStats aggregates timing for a single comparison type
+type ComparisonStats struct {
+	Count int           `json:"count"`
+	Total time.Duration `json:"total"`
+	Max   time.Duration `json:"max"`
+}
+
+// Average returns the mean duration per comparison
+func (cs ComparisonStats) Average() time.Duration {
+	if cs.Count == 0 {
+		return 0
+	}
+	return cs.Total / time.Duration(cs.Count)
+}
+
+// detectionStats collects comparison timings across detections
+type detectionStats struct {
+	mu    sync.Mutex
+	stats map[string]ComparisonStats
+}
+
+func (s *detectionStats) record(comparisonType string, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stats == nil {
+		s.stats = make(map[string]ComparisonStats)
+	}
+	entry := s.stats[comparisonType]
+	entry.Count++
+	entry.Total += elapsed
+	if elapsed > entry.Max {
+		entry.Max = elapsed
+	}
+	s.stats[comparisonType] = entry
+}
+
+func (s *detectionStats) snapshot() map[string]ComparisonStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := make(map[string]ComparisonStats, len(s.stats))
+	for k, v := range s.stats {
+		snapshot[k] = v
+	}
+	return snapshot
+}
+
+func (s *detectionStats) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats = nil
+}
+
+// DetectionStats returns per-comparison-type timings collected while
+// DetectionConfig.CollectStats is enabled, keyed by comparison type name
+func (d *DriftDetector) DetectionStats() map[string]ComparisonStats {
+	return d.stats.snapshot()
+}
+
+// ResetDetectionStats clears collected comparison timings
+func (d *DriftDetector) ResetDetectionStats() {
+	d.stats.reset()
+}
@@ -0,0 +1,75 @@
+package drift
+
+import (
+	"testing"
+
+	"firefly-task/aws"
+	"firefly-task/terraform"
+)
+
+func TestDetectionStats(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.CollectStats = true
+	detector := NewDriftDetector(config)
+
+	awsInstance := &aws.EC2Instance{
+		InstanceID:   "i-123",
+		InstanceType: "t3.micro",
+		Tags:         map[string]string{"Name": "web"},
+	}
+	terraformConfig := &terraform.TerraformConfig{
+		InstanceID:   "i-123",
+		InstanceType: "t3.micro",
+		Tags:         map[string]string{"Name": "web"},
+	}
+
+	pairs := []ResourcePair{
+		{Index: 0, AWSResource: awsInstance, TerraformConfig: terraformConfig},
+		{Index: 1, AWSResource: awsInstance, TerraformConfig: terraformConfig},
+	}
+	if _, err := detector.DetectDriftBatch(pairs); err != nil {
+		t.Fatalf("DetectDriftBatch() error = %v", err)
+	}
+
+	stats := detector.DetectionStats()
+	mapStats, ok := stats[MapComparison.String()]
+	if !ok {
+		t.Fatal("Expected stats for map comparisons")
+	}
+	if mapStats.Count != 2 {
+		t.Errorf("Expected 2 map comparisons, got %d", mapStats.Count)
+	}
+
+	exactStats, ok := stats[ExactMatch.String()]
+	if !ok {
+		t.Fatal("Expected stats for exact comparisons")
+	}
+	// instance_id and instance_type for each of the two pairs
+	if exactStats.Count != 4 {
+		t.Errorf("Expected 4 exact comparisons, got %d", exactStats.Count)
+	}
+	if exactStats.Average() > exactStats.Max {
+		t.Errorf("Average %v should not exceed max %v", exactStats.Average(), exactStats.Max)
+	}
+
+	detector.ResetDetectionStats()
+	if len(detector.DetectionStats()) != 0 {
+		t.Error("Expected stats to be cleared after reset")
+	}
+}
+
+func TestDetectionStats_Disabled(t *testing.T) {
+	detector := NewDriftDetector(DefaultDetectionConfig())
+
+	_, err := detector.DetectDrift(
+		&aws.EC2Instance{InstanceID: "i-123", InstanceType: "t3.micro"},
+		&terraform.TerraformConfig{InstanceID: "i-123", InstanceType: "t3.micro"},
+	)
+	if err != nil {
+		t.Fatalf("DetectDrift() error = %v", err)
+	}
+
+	if len(detector.DetectionStats()) != 0 {
+		t.Error("Expected no stats when CollectStats is disabled")
+	}
+}
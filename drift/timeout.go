@@ -0,0 +1,99 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// DetectionTimeoutError reports a single-resource detection that exceeded
+// DetectionConfig.Timeout
+type DetectionTimeoutError struct {
+	// ResourceID is the ID of the AWS resource being compared
+	ResourceID string
+	// Timeout is the limit that was exceeded
+	Timeout time.Duration
+}
+
+func (e *DetectionTimeoutError) Error() string {
+	return fmt.Sprintf("drift detection for %s timed out after %v", e.ResourceID, e.Timeout)
+}
+
+// Unwrap lets errors.Is match context.DeadlineExceeded
+func (e *DetectionTimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// detectDriftContext runs detectDrift on a snapshot of the detector, so no
+// lock is held while comparing. When DetectionConfig.Timeout is set or ctx can
+// be cancelled, the comparison runs on its own goroutine so the caller stops
+// waiting on timeout or cancellation even if a comparison never returns; the
+// abandoned detection finishes in the background and its result is
+// discarded. The timeout is also checked between attributes so a detection
+// that is merely slow stops early.
+func (d *DriftDetector) detectDriftContext(ctx context.Context, awsResource, terraformConfig interface{}) (*interfaces.DriftResult, error) {
+	snap := d.snapshot()
+	var deadline time.Time
+	if snap.config.Timeout > 0 {
+		deadline = time.Now().Add(snap.config.Timeout)
+	}
+
+	if ctx.Done() == nil && deadline.IsZero() {
+		return snap.detectDrift(awsResource, terraformConfig, deadline)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	type outcome struct {
+		result   *interfaces.DriftResult
+		err      error
+		panicked bool
+		panicVal interface{}
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		defer func() {
+			// Re-raised on the caller's goroutine below
+			if r := recover(); r != nil {
+				done <- outcome{panicked: true, panicVal: r}
+			}
+		}()
+		result, err := snap.detectDrift(awsResource, terraformConfig, deadline)
+		done <- outcome{result: result, err: err}
+	}()
+
+	var expired <-chan time.Time
+	if !deadline.IsZero() {
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case out := <-done:
+		if out.panicked {
+			panic(out.panicVal)
+		}
+		return out.result, out.err
+	case <-expired:
+		return nil, snap.timeoutError(awsResource)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// checkDeadline returns a *DetectionTimeoutError once deadline has passed. A
+// zero deadline never expires.
+func (d *DriftDetector) checkDeadline(deadline time.Time, awsResource interface{}) error {
+	if deadline.IsZero() || time.Now().Before(deadline) {
+		return nil
+	}
+	return d.timeoutError(awsResource)
+}
+
+func (d *DriftDetector) timeoutError(awsResource interface{}) error {
+	return &DetectionTimeoutError{ResourceID: d.extractResourceID(awsResource), Timeout: d.config.Timeout}
+}
@@ -0,0 +1,142 @@
+package drift
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDetectDrift_Timeout(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.Timeout = 20 * time.Millisecond
+	config.MaxConcurrency = 2
+	detector := NewDriftDetector(config)
+
+	detector.RegisterComparator("instance_type", func(actual, expected interface{}) (bool, string) {
+		if actual == "t3.slow" {
+			time.Sleep(60 * time.Millisecond)
+		}
+		return actual == expected, "instance type differs"
+	})
+
+	slow := createBenchmarkInstance()
+	slow.InstanceID = "i-slow"
+	slow.InstanceType = "t3.slow"
+
+	_, err := detector.DetectDrift(slow, createBenchmarkInstance())
+	var timeoutErr *DetectionTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("Expected DetectionTimeoutError, got %v", err)
+	}
+	if timeoutErr.ResourceID != "i-slow" || timeoutErr.Timeout != config.Timeout {
+		t.Errorf("Unexpected timeout error: %+v", timeoutErr)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Expected timeout error to match context.DeadlineExceeded")
+	}
+
+	fastExpected := createBenchmarkInstance()
+	fastExpected.InstanceType = "t3.large"
+	pairs := []ResourcePair{
+		{Index: 0, AWSResource: createBenchmarkInstance(), TerraformConfig: fastExpected},
+		{Index: 1, AWSResource: slow, TerraformConfig: createBenchmarkInstance()},
+	}
+	results, err := detector.DetectDriftBatch(pairs)
+	if err == nil || !strings.Contains(err.Error(), "index 1") || !strings.Contains(err.Error(), "i-slow timed out") {
+		t.Fatalf("Expected the slow pair to fail on its own index, got %v", err)
+	}
+	if results[0] == nil || !results[0].IsDrifted {
+		t.Error("Expected the fast pair to complete")
+	}
+	if results[1] != nil {
+		t.Error("Expected no result for the timed out pair")
+	}
+}
+
+func TestDetectDriftContext_HungComparatorReleasesDetector(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.Timeout = 20 * time.Millisecond
+	detector := NewDriftDetector(config)
+
+	release := make(chan struct{})
+	defer close(release)
+	detector.RegisterComparator("instance_type", func(actual, expected interface{}) (bool, string) {
+		<-release
+		return actual == expected, "instance type differs"
+	})
+
+	expected := createBenchmarkInstance()
+	expected.InstanceType = "t3.large"
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := detector.detectDriftContext(ctx, createBenchmarkInstance(), expected)
+	var timeoutErr *DetectionTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("Expected DetectionTimeoutError, got %v", err)
+	}
+
+	// The abandoned comparison must not keep the detector locked
+	updated := make(chan struct{})
+	go func() {
+		detector.UpdateConfig(config)
+		detector.RegisterComparator("instance_type", nil)
+		close(updated)
+	}()
+	select {
+	case <-updated:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the detector to accept updates while a timed out comparison is still running")
+	}
+}
+
+func TestDetectDrift_HungComparatorTimesOut(t *testing.T) {
+	config := DefaultDetectionConfig()
+	config.Timeout = 20 * time.Millisecond
+	detector := NewDriftDetector(config)
+
+	release := make(chan struct{})
+	defer close(release)
+	detector.RegisterComparator("instance_type", func(actual, expected interface{}) (bool, string) {
+		<-release
+		return actual == expected, "instance type differs"
+	})
+
+	hung := createBenchmarkInstance()
+	hung.InstanceID = "i-hung"
+	_, err := detector.DetectDrift(hung, createBenchmarkInstance())
+	var timeoutErr *DetectionTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("Expected DetectionTimeoutError, got %v", err)
+	}
+	if timeoutErr.ResourceID != "i-hung" {
+		t.Errorf("Unexpected timeout error: %+v", timeoutErr)
+	}
+
+	_, err = detector.DetectDriftBatch([]ResourcePair{{Index: 0, AWSResource: hung, TerraformConfig: createBenchmarkInstance()}})
+	if err == nil || !strings.Contains(err.Error(), "i-hung timed out") {
+		t.Fatalf("Expected the batch to report the hung pair as timed out, got %v", err)
+	}
+}
+
+func TestDetectDriftContext_PropagatesPanics(t *testing.T) {
+	detector := NewDriftDetector(DefaultDetectionConfig())
+	detector.SetBeforeCompareHook(func(attribute string, actual, expected interface{}) (interface{}, interface{}) {
+		panic("hook exploded")
+	})
+
+	expected := createBenchmarkInstance()
+	expected.InstanceType = "t3.large"
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != "hook exploded" {
+			t.Errorf("Expected the hook panic to reach the caller, got %v", r)
+		}
+	}()
+	detector.detectDriftContext(ctx, createBenchmarkInstance(), expected)
+	t.Error("Expected detectDriftContext to panic")
+}
@@ -0,0 +1,70 @@
+package drift
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// ResultValidationError describes an internally inconsistent drift result
+type ResultValidationError struct {
+	ResourceID string
+	Problem    string
+}
+
+func (e *ResultValidationError) Error() string {
+	return fmt.Sprintf("invalid drift result for %s: %s", e.ResourceID, e.Problem)
+}
+
+// ValidateResult checks that a drift result is internally consistent: a
+// drifted result has details, a clean result has none, no detail is nil, and
+// the overall severity is the highest detail severity (none when clean)
+func ValidateResult(r *interfaces.DriftResult) error {
+	if r == nil {
+		return &ResultValidationError{ResourceID: "<nil>", Problem: "result is nil"}
+	}
+
+	invalid := func(format string, args ...interface{}) error {
+		return &ResultValidationError{ResourceID: r.ResourceID, Problem: fmt.Sprintf(format, args...)}
+	}
+
+	for i, detail := range r.DriftDetails {
+		if detail == nil {
+			return invalid("drift detail %d is nil", i)
+		}
+	}
+
+	if r.IsDrifted && len(r.DriftDetails) == 0 {
+		return invalid("marked as drifted but has no drift details")
+	}
+	if !r.IsDrifted && len(r.DriftDetails) > 0 {
+		return invalid("not marked as drifted but has %d drift details", len(r.DriftDetails))
+	}
+
+	expected := r.GetHighestSeverity()
+	if severityValue(r.Severity) != severityValue(expected) {
+		return invalid("severity %s does not match highest detail severity %s", r.Severity, expected)
+	}
+
+	return nil
+}
+
+// ValidateResults validates every result and joins the errors, ordered by
+// result key
+func ValidateResults(results map[string]*interfaces.DriftResult) error {
+	keys := make([]string, 0, len(results))
+	for key := range results {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		if err := ValidateResult(results[key]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,138 @@
+package drift
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func TestValidateResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  *interfaces.DriftResult
+		wantErr string
+	}{
+		{
+			name: "valid drifted result",
+			result: &interfaces.DriftResult{
+				ResourceID: "i-1",
+				IsDrifted:  true,
+				Severity:   interfaces.SeverityHigh,
+				DriftDetails: []*interfaces.DriftDetail{
+					{Attribute: "instance_type", Severity: interfaces.SeverityMedium},
+					{Attribute: "security_groups", Severity: interfaces.SeverityHigh},
+				},
+			},
+		},
+		{
+			name: "valid clean result",
+			result: &interfaces.DriftResult{
+				ResourceID: "i-2",
+				Severity:   interfaces.SeverityNone,
+			},
+		},
+		{
+			name:    "nil result",
+			wantErr: "result is nil",
+		},
+		{
+			name: "drifted but empty",
+			result: &interfaces.DriftResult{
+				ResourceID: "i-3",
+				IsDrifted:  true,
+				Severity:   interfaces.SeverityHigh,
+			},
+			wantErr: "marked as drifted but has no drift details",
+		},
+		{
+			name: "clean with details",
+			result: &interfaces.DriftResult{
+				ResourceID: "i-4",
+				DriftDetails: []*interfaces.DriftDetail{
+					{Attribute: "tags", Severity: interfaces.SeverityLow},
+				},
+			},
+			wantErr: "not marked as drifted but has 1 drift details",
+		},
+		{
+			name: "severity mismatch",
+			result: &interfaces.DriftResult{
+				ResourceID: "i-5",
+				IsDrifted:  true,
+				Severity:   interfaces.SeverityLow,
+				DriftDetails: []*interfaces.DriftDetail{
+					{Attribute: "instance_type", Severity: interfaces.SeverityCritical},
+				},
+			},
+			wantErr: "severity low does not match highest detail severity critical",
+		},
+		{
+			name: "clean with severity",
+			result: &interfaces.DriftResult{
+				ResourceID: "i-6",
+				Severity:   interfaces.SeverityMedium,
+			},
+			wantErr: "severity medium does not match highest detail severity none",
+		},
+		{
+			name: "nil detail",
+			result: &interfaces.DriftResult{
+				ResourceID:   "i-7",
+				IsDrifted:    true,
+				Severity:     interfaces.SeverityNone,
+				DriftDetails: []*interfaces.DriftDetail{nil},
+			},
+			wantErr: "drift detail 0 is nil",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateResult(tt.result)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateResult() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("ValidateResult() expected error containing %q", tt.wantErr)
+			}
+			var validationErr *ResultValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("ValidateResult() error type = %T, want *ResultValidationError", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateResult() error = %q, want it to contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateResults(t *testing.T) {
+	results := map[string]*interfaces.DriftResult{
+		"b": {ResourceID: "b", IsDrifted: true, Severity: interfaces.SeverityHigh},
+		"a": {ResourceID: "a", Severity: interfaces.SeverityNone},
+		"c": {ResourceID: "c", Severity: interfaces.SeverityLow},
+	}
+
+	err := ValidateResults(results)
+	if err == nil {
+		t.Fatal("ValidateResults() expected error")
+	}
+	lines := strings.Split(err.Error(), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("ValidateResults() returned %d errors, want 2: %v", len(lines), err)
+	}
+	if !strings.Contains(lines[0], "for b:") || !strings.Contains(lines[1], "for c:") {
+		t.Errorf("ValidateResults() errors not ordered by key: %v", err)
+	}
+
+	delete(results, "b")
+	delete(results, "c")
+	if err := ValidateResults(results); err != nil {
+		t.Errorf("ValidateResults() unexpected error: %v", err)
+	}
+}
@@ -3,8 +3,11 @@ package app
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 
@@ -12,12 +15,21 @@ import (
 	"firefly-task/pkg/container"
 	"firefly-task/pkg/interfaces"
 	"firefly-task/pkg/logging"
+	"firefly-task/report"
 	"go.uber.org/zap"
 )
 
 // Default attributes to check for drift detection
 var DefaultAttributes = []string{"instance_type", "state", "subnet_id", "vpc_id", "security_groups", "tags"}
 
+// ErrAttributeDrift is returned together with the report when an attribute
+// listed in Config.FailOnAttributes has drifted
+var ErrAttributeDrift = errors.New("drift detected in a fail-on attribute")
+
+// ErrTooFewResources is returned when a batch run evaluates fewer resources
+// than Config.MinExpectedResources
+var ErrTooFewResources = errors.New("too few resources evaluated")
+
 // Application represents the main application with all its dependencies
 type Application struct {
 	// Dependencies
@@ -27,16 +39,26 @@ type Application struct {
 	reportGenerator interfaces.ReportGenerator
 	logger          *zap.SugaredLogger
 
+	// recommender overrides recommendation generation; nil uses
+	// report.AnalyzeDriftPatterns with the default thresholds
+	recommender func(ctx context.Context, results map[string]*interfaces.DriftResult) (*report.DriftAnalysis, error)
+
 	// Configuration
 	config *config.Config
 
 	// Lifecycle management
-	ctx          context.Context
-	cancelFunc   context.CancelFunc
-	wg           sync.WaitGroup
-	running      bool
-	shuttingDown bool
-	mu           sync.Mutex
+	ctx             context.Context
+	cancelFunc      context.CancelFunc
+	wg              sync.WaitGroup
+	running         bool
+	shuttingDown    bool
+	mu              sync.Mutex
+	sinks           []Sink
+	webhook         *webhookSink
+	status          *StatusServer
+	statusListener  net.Listener
+	signalChan      chan os.Signal
+	interruptSignal os.Signal
 }
 
 // New creates a new application instance with the provided dependencies
@@ -179,6 +201,11 @@ func (a *Application) RunSingleCheck(ctx context.Context, instanceID, terraformP
 		attributes = DefaultAttributes
 	}
 
+	if status := a.statusServer(); status != nil {
+		status.RunStarted()
+		defer status.RunEnded()
+	}
+
 	// Run single instance check
 	driftResult, err := a.RunSingleInstanceCheck(ctx, instanceID, terraformPath, attributes)
 	if err != nil {
@@ -190,15 +217,15 @@ func (a *Application) RunSingleCheck(ctx context.Context, instanceID, terraformP
 	}
 
 	// Generate report
-	reportData, err := a.GenerateReport(
-		map[string]*interfaces.DriftResult{instanceID: driftResult},
-		a.config.Output,
-	)
+	results := map[string]*interfaces.DriftResult{instanceID: driftResult}
+	results = a.filterByDetectionTime(results)
+	a.recordRun(results)
+	reportData, err := a.GenerateReport(results, a.config.Output)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate report: %w", err)
 	}
 
-	return reportData, nil
+	return reportData, a.checkFailOnAttributes(results)
 }
 
 // RunBatchCheck performs a complete batch instance drift check workflow
@@ -213,6 +240,11 @@ func (a *Application) RunBatchCheck(ctx context.Context, inputFile, terraformPat
 		attributes = DefaultAttributes
 	}
 
+	if status := a.statusServer(); status != nil {
+		status.RunStarted()
+		defer status.RunEnded()
+	}
+
 	// Read instance IDs from input file
 	instanceIDs, err := a.ReadInstanceIDsFromFile(inputFile)
 	if err != nil {
@@ -224,14 +256,28 @@ func (a *Application) RunBatchCheck(ctx context.Context, inputFile, terraformPat
 	if err != nil {
 		return nil, fmt.Errorf("failed to check batch instance drift: %w", err)
 	}
+	if err := a.checkMinExpectedResources(len(driftResults)); err != nil {
+		return nil, err
+	}
 
 	// Generate report
+	driftResults = a.filterByDetectionTime(driftResults)
+	a.recordRun(driftResults)
 	reportData, err := a.GenerateReport(driftResults, a.config.Output)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate report: %w", err)
 	}
 
-	return reportData, nil
+	return reportData, a.checkFailOnAttributes(driftResults)
+}
+
+// recordRun hands the results of a finished run to the status server and the
+// webhook, when they are configured
+func (a *Application) recordRun(results map[string]*interfaces.DriftResult) {
+	if status := a.statusServer(); status != nil {
+		status.RunFinished(results)
+	}
+	a.deliverWebhook(results)
 }
 
 // RunAttributeCheck performs a complete attribute-specific drift check workflow
@@ -263,6 +309,42 @@ func (a *Application) RunAttributeCheck(ctx context.Context, instanceID, terrafo
 	return reportData, nil
 }
 
+// checkFailOnAttributes returns ErrAttributeDrift if any configured fail-on
+// attribute drifted in results
+func (a *Application) checkFailOnAttributes(results map[string]*interfaces.DriftResult) error {
+	if a.config == nil || !report.HasAttributeDrift(results, a.config.FailOnAttributes) {
+		return nil
+	}
+	return ErrAttributeDrift
+}
+
+// checkMinExpectedResources returns ErrTooFewResources if evaluated is below
+// the configured minimum
+func (a *Application) checkMinExpectedResources(evaluated int) error {
+	if a.config == nil || evaluated >= a.config.MinExpectedResources {
+		return nil
+	}
+	return fmt.Errorf("%w: expected at least %d resources, evaluated %d",
+		ErrTooFewResources, a.config.MinExpectedResources, evaluated)
+}
+
+// filterByDetectionTime drops results detected outside the configured
+// Since/Until window
+func (a *Application) filterByDetectionTime(results map[string]*interfaces.DriftResult) map[string]*interfaces.DriftResult {
+	if a.config == nil || (a.config.Since == nil && a.config.Until == nil) {
+		return results
+	}
+
+	filter := report.NewResultFilter().WithTimeRange(a.config.Since, a.config.Until)
+	filtered := make(map[string]*interfaces.DriftResult, len(results))
+	for id, result := range results {
+		if kept := filter.Apply(map[string]*interfaces.DriftResult{id: result}); len(kept) > 0 {
+			filtered[id] = kept[0]
+		}
+	}
+	return filtered
+}
+
 // RunSingleInstanceCheck performs drift detection on a single EC2 instance
 func (a *Application) IsShuttingDown() bool {
 	a.mu.Lock()
@@ -340,6 +422,90 @@ func (a *Application) RunBatchInstanceCheck(ctx context.Context, instanceIDs []s
 	return driftResults, nil
 }
 
+// ResourceListing is a Terraform resource found by ListResources and whether
+// a matching AWS instance exists
+type ResourceListing struct {
+	Address       string `json:"address"`
+	ResourceType  string `json:"resource_type"`
+	ResourceID    string `json:"resource_id"`
+	AWSMatch      bool   `json:"aws_match"`
+	AWSInstanceID string `json:"aws_instance_id,omitempty"`
+}
+
+// ListResources parses the Terraform configuration and lists the resources
+// that would be evaluated, sorted by address, marking those with a matching
+// AWS instance. No drift comparison is performed.
+func (a *Application) ListResources(ctx context.Context, terraformPath string) ([]ResourceListing, error) {
+	a.wg.Add(1)
+	defer a.wg.Done()
+
+	configs, err := a.terraformParser.ParseTerraformHCL(terraformPath)
+	if err != nil {
+		return nil, err
+	}
+
+	instances, err := a.awsClient.ListEC2Instances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	available := make(map[string]bool, len(instances))
+	for _, inst := range instances {
+		if inst != nil {
+			available[inst.InstanceID] = true
+		}
+	}
+
+	listings := make([]ResourceListing, 0, len(configs))
+	for key, cfg := range configs {
+		if cfg == nil {
+			continue
+		}
+		listing := ResourceListing{
+			Address:      resourceAddress(cfg),
+			ResourceType: cfg.ResourceType,
+			ResourceID:   cfg.ResourceID,
+		}
+		for _, id := range terraformInstanceIDs(key, cfg) {
+			if available[id] {
+				listing.AWSMatch = true
+				listing.AWSInstanceID = id
+				break
+			}
+		}
+		listings = append(listings, listing)
+	}
+	sort.Slice(listings, func(i, j int) bool {
+		return listings[i].Address < listings[j].Address
+	})
+
+	return listings, nil
+}
+
+// resourceAddress builds the Terraform address of cfg, including its module
+// path, falling back to the resource ID when the name is unknown
+func resourceAddress(cfg *interfaces.TerraformConfig) string {
+	if cfg.ResourceType == "" || cfg.ResourceName == "" {
+		return cfg.ResourceID
+	}
+	address := cfg.ResourceType + "." + cfg.ResourceName
+	if cfg.Module != "" {
+		address = "module." + strings.TrimPrefix(cfg.Module, "module.") + "." + address
+	}
+	return address
+}
+
+// terraformInstanceIDs returns the candidate AWS instance IDs for a parsed
+// resource: its map key, resource ID and any id or instance_id attribute
+func terraformInstanceIDs(key string, cfg *interfaces.TerraformConfig) []string {
+	ids := []string{key, cfg.ResourceID}
+	for _, attr := range []string{"id", "instance_id"} {
+		if id, ok := cfg.Attributes[attr].(string); ok && id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 // GenerateReport generates a report from drift results
 func (a *Application) GenerateReport(driftResults map[string]*interfaces.DriftResult, format string) ([]byte, error) {
 	switch format {
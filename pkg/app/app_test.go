@@ -3,6 +3,8 @@ package app
 import (
 	"context"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -323,6 +325,45 @@ func TestApplication_RunBatchInstanceCheck(t *testing.T) {
 	mockDrift.AssertExpectations(t)
 }
 
+func TestApplication_RunBatchCheck_MinExpectedResources(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.SetDefaults()
+	cfg.MinExpectedResources = 5
+	mockEC2 := &MockEC2Client{}
+	mockTF := &MockTerraformParser{}
+	mockDrift := &MockDriftDetector{}
+	mockReport := &MockReportGenerator{}
+
+	logging.InitLogger("debug", false)
+	app := New(cfg, mockEC2, mockTF, mockDrift, mockReport, logging.GetLogger())
+
+	inputFile := filepath.Join(t.TempDir(), "instances.txt")
+	if err := os.WriteFile(inputFile, []byte("i-1\ni-2\n"), 0644); err != nil {
+		t.Fatalf("Failed to write input file: %v", err)
+	}
+
+	ctx := context.Background()
+	instances := map[string]*interfaces.EC2Instance{
+		"i-1": {InstanceID: "i-1"},
+		"i-2": {InstanceID: "i-2"},
+	}
+	tfConfigs := map[string]*interfaces.TerraformConfig{}
+	driftResults := map[string]*interfaces.DriftResult{
+		"i-1": {ResourceID: "i-1", DetectionTime: time.Now()},
+		"i-2": {ResourceID: "i-2", DetectionTime: time.Now()},
+	}
+	mockEC2.On("GetMultipleEC2Instances", ctx, []string{"i-1", "i-2"}).Return(instances, nil)
+	mockTF.On("ParseTerraformHCL", "/path/to/terraform").Return(tfConfigs, nil)
+	mockDrift.On("DetectMultipleDrift", instances, tfConfigs, DefaultAttributes).Return(driftResults, nil)
+
+	reportData, err := app.RunBatchCheck(ctx, inputFile, "/path/to/terraform", nil)
+
+	assert.ErrorIs(t, err, ErrTooFewResources)
+	assert.EqualError(t, err, "too few resources evaluated: expected at least 5 resources, evaluated 2")
+	assert.Nil(t, reportData)
+	mockReport.AssertNotCalled(t, "GenerateTableReport", mock.Anything)
+}
+
 func TestApplication_GenerateReport(t *testing.T) {
 	cfg := &config.Config{}
 	cfg.SetDefaults()
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"firefly-task/drift"
+	"firefly-task/report"
+)
+
+// ComparisonTypeInfo names a comparison type and its numeric value
+type ComparisonTypeInfo struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// CapabilityInfo describes what this build supports, for tools that wrap the CLI
+type CapabilityInfo struct {
+	ResourceTypes    []string             `json:"resource_types"`
+	ComparisonTypes  []ComparisonTypeInfo `json:"comparison_types"`
+	ReportFormats    []string             `json:"report_formats"`
+	CIPlatforms      []string             `json:"ci_platforms"`
+	DetectedPlatform string               `json:"detected_platform"`
+}
+
+// Capabilities reports the supported resource types, comparison types,
+// report formats and CI platforms, and the platform detected from the
+// environment
+func Capabilities() CapabilityInfo {
+	return capabilitiesFor(drift.NewDriftDetector(drift.DefaultDetectionConfig()))
+}
+
+// capabilitiesFor builds CapabilityInfo using detector's registered mappers
+func capabilitiesFor(detector *drift.DriftDetector) CapabilityInfo {
+	info := CapabilityInfo{
+		ResourceTypes:    detector.ResourceTypes(),
+		ReportFormats:    report.ListSupportedFormats(),
+		DetectedPlatform: string(report.DetectPlatform()),
+	}
+	for _, ct := range drift.ComparisonTypes() {
+		info.ComparisonTypes = append(info.ComparisonTypes, ComparisonTypeInfo{ID: int(ct), Name: ct.String()})
+	}
+	for _, platform := range report.SupportedPlatforms() {
+		info.CIPlatforms = append(info.CIPlatforms, string(platform))
+	}
+	return info
+}
@@ -2,12 +2,23 @@ package app
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"firefly-task/aws"
+	"firefly-task/drift"
 	"firefly-task/pkg/logging"
+	"firefly-task/report"
+	"firefly-task/terraform"
 )
 
 // CommandHandler handles all CLI commands for the application
@@ -50,24 +61,45 @@ between actual EC2 instances and their Terraform configurations.`,
 	rootCmd.PersistentFlags().String("log-level", "info", "Set log level (debug, info, warn, error)")
 	rootCmd.PersistentFlags().Bool("log-json", false, "Output logs in JSON format")
 
+	// Print the effective drift detection configuration and exit
+	rootCmd.Flags().Bool("print-config", false, "Print the effective drift configuration and exit")
+	rootCmd.Flags().String("config-format", "json", "Format for --print-config (json, yaml)")
+	rootCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		printConfig, _ := cmd.Flags().GetBool("print-config")
+		if !printConfig {
+			return cmd.Help()
+		}
+		format, _ := cmd.Flags().GetString("config-format")
+		return h.printEffectiveConfig(cmd.OutOrStdout(), drift.GetConfigPathFromEnv(), format)
+	}
+
 	// Add subcommands
 	rootCmd.AddCommand(h.CreateCheckCommand())
 	rootCmd.AddCommand(h.CreateBatchCommand())
 	rootCmd.AddCommand(h.CreateAttributeCommand())
+	rootCmd.AddCommand(h.CreateExplainCommand())
+	rootCmd.AddCommand(h.CreateCapabilitiesCommand())
+	rootCmd.AddCommand(h.CreateListResourcesCommand())
 
 	return rootCmd
 }
 
 // CreateCheckCommand creates the check command for single instance drift detection
 func (h *CommandHandler) CreateCheckCommand() *cobra.Command {
-	var instanceID, terraformPath, outputFile string
-	var attributes []string
+	var instanceID, terraformPath, outputFile, since, until, webhookURL, statusAddr string
+	var attributes, failOnAttributes []string
 
 	checkCmd := &cobra.Command{
 		Use:   "check",
 		Short: "Check drift for a single EC2 instance",
 		Long:  `Check configuration drift for a single EC2 instance against its Terraform configuration.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			h.app.config.FailOnAttributes = failOnAttributes
+			h.app.config.WebhookURL = webhookURL
+			h.app.config.StatusAddr = statusAddr
+			if err := h.applyTimeWindow(since, until); err != nil {
+				return err
+			}
 			return h.handleCheckCommand(cmd.Context(), instanceID, terraformPath, outputFile, attributes)
 		},
 	}
@@ -77,6 +109,11 @@ func (h *CommandHandler) CreateCheckCommand() *cobra.Command {
 	checkCmd.Flags().StringVarP(&terraformPath, "tf-path", "t", "", "Path to Terraform configuration file (required)")
 	checkCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file path (optional, prints to stdout if not specified)")
 	checkCmd.Flags().StringSliceVarP(&attributes, "attributes", "a", DefaultAttributes, "Attributes to check for drift")
+	checkCmd.Flags().StringSliceVar(&failOnAttributes, "fail-on-attributes", nil, "Fail if any of these attributes drift, regardless of severity")
+	checkCmd.Flags().StringVar(&since, "since", "", "Only report results detected after this time (duration like 1h or RFC3339)")
+	checkCmd.Flags().StringVar(&until, "until", "", "Only report results detected before this time (duration like 1h or RFC3339)")
+	checkCmd.Flags().StringVar(&webhookURL, "webhook-url", "", "Also post the drift results to this webhook URL")
+	checkCmd.Flags().StringVar(&statusAddr, "status-addr", "", "Serve /healthz and /status on this address during the run (e.g. 127.0.0.1:8080)")
 
 	// Mark required flags
 	checkCmd.MarkFlagRequired("instance-id")
@@ -87,14 +124,25 @@ func (h *CommandHandler) CreateCheckCommand() *cobra.Command {
 
 // CreateBatchCommand creates the batch command for multiple instance drift detection
 func (h *CommandHandler) CreateBatchCommand() *cobra.Command {
-	var inputFile, terraformPath, outputFile string
-	var attributes []string
+	var inputFile, terraformPath, outputFile, since, until, webhookURL, statusAddr string
+	var attributes, failOnAttributes []string
+	var minResources int
 
 	batchCmd := &cobra.Command{
 		Use:   "batch",
 		Short: "Check drift for multiple EC2 instances",
 		Long:  `Check configuration drift for multiple EC2 instances listed in a file against their Terraform configurations.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			h.app.config.FailOnAttributes = failOnAttributes
+			h.app.config.WebhookURL = webhookURL
+			h.app.config.StatusAddr = statusAddr
+			if minResources < 0 {
+				return fmt.Errorf("--min-resources cannot be negative, got %d", minResources)
+			}
+			h.app.config.MinExpectedResources = minResources
+			if err := h.applyTimeWindow(since, until); err != nil {
+				return err
+			}
 			return h.handleBatchCommand(cmd.Context(), inputFile, terraformPath, outputFile, attributes)
 		},
 	}
@@ -104,6 +152,12 @@ func (h *CommandHandler) CreateBatchCommand() *cobra.Command {
 	batchCmd.Flags().StringVarP(&terraformPath, "tf-path", "t", "", "Path to Terraform configuration file (required)")
 	batchCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file path (optional, prints to stdout if not specified)")
 	batchCmd.Flags().StringSliceVarP(&attributes, "attributes", "a", DefaultAttributes, "Attributes to check for drift")
+	batchCmd.Flags().StringSliceVar(&failOnAttributes, "fail-on-attributes", nil, "Fail if any of these attributes drift, regardless of severity")
+	batchCmd.Flags().StringVar(&since, "since", "", "Only report results detected after this time (duration like 1h or RFC3339)")
+	batchCmd.Flags().StringVar(&until, "until", "", "Only report results detected before this time (duration like 1h or RFC3339)")
+	batchCmd.Flags().StringVar(&webhookURL, "webhook-url", "", "Also post the drift results to this webhook URL")
+	batchCmd.Flags().StringVar(&statusAddr, "status-addr", "", "Serve /healthz and /status on this address during the run (e.g. 127.0.0.1:8080)")
+	batchCmd.Flags().IntVar(&minResources, "min-resources", 0, "Fail if fewer than this many resources are evaluated (0 disables the check)")
 
 	// Mark required flags
 	batchCmd.MarkFlagRequired("input-file")
@@ -139,6 +193,76 @@ func (h *CommandHandler) CreateAttributeCommand() *cobra.Command {
 	return attributeCmd
 }
 
+// CreateExplainCommand creates the explain command, which shows the
+// per-attribute comparison decisions for a single resource read from files
+func (h *CommandHandler) CreateExplainCommand() *cobra.Command {
+	var awsFile, terraformFile, format string
+
+	explainCmd := &cobra.Command{
+		Use:   "explain",
+		Short: "Explain drift decisions for a single EC2 instance",
+		Long: `Compare an EC2 instance and its Terraform configuration, both read from JSON
+files, and print how each attribute was compared and why it is or isn't drifted.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return h.handleExplainCommand(cmd.OutOrStdout(), awsFile, terraformFile, format)
+		},
+	}
+
+	// Add flags
+	explainCmd.Flags().StringVar(&awsFile, "aws-file", "", "JSON file containing the AWS EC2 instance (required)")
+	explainCmd.Flags().StringVar(&terraformFile, "tf-file", "", "JSON file containing the Terraform EC2 instance configuration (required)")
+	explainCmd.Flags().StringVar(&format, "format", "table", "Output format (table, json)")
+
+	// Mark required flags
+	explainCmd.MarkFlagRequired("aws-file")
+	explainCmd.MarkFlagRequired("tf-file")
+
+	return explainCmd
+}
+
+// CreateCapabilitiesCommand creates the capabilities command, which prints
+// the supported resource types, comparisons, formats and platforms as JSON
+func (h *CommandHandler) CreateCapabilitiesCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "capabilities",
+		Short: "Print supported resource types, comparisons, formats and CI platforms",
+		Long:  `Print the resource types, comparison types, report formats and CI platforms this build supports as JSON.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := json.MarshalIndent(Capabilities(), "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal capabilities: %w", err)
+			}
+			_, err = cmd.OutOrStdout().Write(append(data, '\n'))
+			return err
+		},
+	}
+}
+
+// CreateListResourcesCommand creates the list-resources command, which shows
+// the Terraform resources that would be evaluated without comparing them
+func (h *CommandHandler) CreateListResourcesCommand() *cobra.Command {
+	var terraformPath, format string
+
+	listCmd := &cobra.Command{
+		Use:   "list-resources",
+		Short: "List the Terraform resources that would be evaluated",
+		Long: `Parse the Terraform configuration and list the resource addresses and types
+found, and whether a matching AWS instance is available, without checking drift.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return h.handleListResourcesCommand(cmd.Context(), cmd.OutOrStdout(), terraformPath, format)
+		},
+	}
+
+	// Add flags
+	listCmd.Flags().StringVarP(&terraformPath, "tf-path", "t", "", "Path to Terraform configuration file (required)")
+	listCmd.Flags().StringVar(&format, "format", "table", "Output format (table, json)")
+
+	// Mark required flags
+	listCmd.MarkFlagRequired("tf-path")
+
+	return listCmd
+}
+
 // handleCheckCommand handles the check command execution
 func (h *CommandHandler) handleCheckCommand(ctx context.Context, instanceID, terraformPath, outputFile string, attributes []string) error {
 	logger := logging.GetLogger()
@@ -159,7 +283,8 @@ func (h *CommandHandler) handleCheckCommand(ctx context.Context, instanceID, ter
 
 	// Run single check
 	reportData, err := h.app.RunSingleCheck(ctx, instanceID, terraformPath, attributes)
-	if err != nil {
+	attributeDrift := errors.Is(err, ErrAttributeDrift)
+	if err != nil && !attributeDrift {
 		logger.Errorw("Drift detection failed",
 			"instance_id", instanceID,
 			"error", err.Error())
@@ -176,6 +301,10 @@ func (h *CommandHandler) handleCheckCommand(ctx context.Context, instanceID, ter
 		return fmt.Errorf("failed to output result for instance %s: %w", instanceID, err)
 	}
 
+	if attributeDrift {
+		return fmt.Errorf("instance %s: %w", instanceID, ErrAttributeDrift)
+	}
+
 	return nil
 }
 
@@ -199,7 +328,8 @@ func (h *CommandHandler) handleBatchCommand(ctx context.Context, inputFile, terr
 
 	// Run batch check
 	reportData, err := h.app.RunBatchCheck(ctx, inputFile, terraformPath, attributes)
-	if err != nil {
+	attributeDrift := errors.Is(err, ErrAttributeDrift)
+	if err != nil && !attributeDrift {
 		logger.Errorw("Batch drift detection failed",
 			"input_file", inputFile,
 			"error", err.Error())
@@ -216,6 +346,10 @@ func (h *CommandHandler) handleBatchCommand(ctx context.Context, inputFile, terr
 		return fmt.Errorf("failed to output batch result: %w", err)
 	}
 
+	if attributeDrift {
+		return ErrAttributeDrift
+	}
+
 	return nil
 }
 
@@ -261,6 +395,160 @@ func (h *CommandHandler) handleAttributeCommand(ctx context.Context, instanceID,
 	return nil
 }
 
+// handleExplainCommand handles the explain command execution
+func (h *CommandHandler) handleExplainCommand(w io.Writer, awsFile, terraformFile, format string) error {
+	format = strings.ToLower(format)
+	if format != "table" && format != "json" {
+		return fmt.Errorf("unsupported explain format '%s'. Valid formats: table, json", format)
+	}
+
+	var instance aws.EC2Instance
+	if err := readJSONFile(awsFile, &instance); err != nil {
+		return fmt.Errorf("failed to read AWS resource: %w", err)
+	}
+	var instanceConfig terraform.EC2InstanceConfig
+	if err := readJSONFile(terraformFile, &instanceConfig); err != nil {
+		return fmt.Errorf("failed to read Terraform configuration: %w", err)
+	}
+
+	detectionConfig, err := drift.NewConfigManager(drift.GetConfigPathFromEnv()).LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load drift config: %w", err)
+	}
+
+	explanation, err := drift.NewDriftDetector(detectionConfig).ExplainDrift(&instance, &instanceConfig)
+	if err != nil {
+		return fmt.Errorf("failed to explain drift: %w", err)
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(explanation, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal explanation: %w", err)
+		}
+		_, err = w.Write(append(data, '\n'))
+		return err
+	}
+
+	status := "not drifted"
+	if explanation.IsDrifted {
+		status = fmt.Sprintf("drifted (severity: %s)", explanation.Severity)
+	}
+	fmt.Fprintf(w, "Resource %s (%s): %s\n\n", explanation.ResourceID, explanation.ResourceType, status)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ATTRIBUTE\tDECISION\tCOMPARATOR\tACTUAL\tEXPECTED\tREASON")
+	for _, d := range explanation.Decisions {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%v\t%v\t%s\n",
+			d.Attribute, d.Decision, d.Comparator, d.ActualValue, d.ExpectedValue, d.Reason)
+	}
+	return tw.Flush()
+}
+
+// handleListResourcesCommand handles the list-resources command execution
+func (h *CommandHandler) handleListResourcesCommand(ctx context.Context, w io.Writer, terraformPath, format string) error {
+	format = strings.ToLower(format)
+	if format != "table" && format != "json" {
+		return fmt.Errorf("unsupported list-resources format '%s'. Valid formats: table, json", format)
+	}
+	if ctx == nil {
+		ctx = h.app.Context()
+	}
+
+	listings, err := h.app.ListResources(ctx, terraformPath)
+	if err != nil {
+		return fmt.Errorf("failed to list resources: %w", err)
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(listings, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal resources: %w", err)
+		}
+		_, err = w.Write(append(data, '\n'))
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ADDRESS\tTYPE\tAWS MATCH\tAWS INSTANCE")
+	for _, l := range listings {
+		match := "no"
+		if l.AWSMatch {
+			match = "yes"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", l.Address, l.ResourceType, match, l.AWSInstanceID)
+	}
+	return tw.Flush()
+}
+
+// readJSONFile decodes the JSON file at path into v
+func readJSONFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("invalid JSON in %s: %w", path, err)
+	}
+	return nil
+}
+
+// printEffectiveConfig writes the merged drift configuration and the source of
+// each setting in the requested format
+func (h *CommandHandler) printEffectiveConfig(w io.Writer, configPath, format string) error {
+	effective, err := drift.NewConfigManager(configPath).EffectiveConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load effective config: %w", err)
+	}
+
+	data, err := json.MarshalIndent(effective, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal effective config: %w", err)
+	}
+
+	switch strings.ToLower(format) {
+	case "json":
+		data = append(data, '\n')
+	case "yaml", "yml":
+		// Round-trip through a generic value so YAML keys match the JSON names
+		var generic interface{}
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return fmt.Errorf("failed to convert effective config: %w", err)
+		}
+		data, err = yaml.Marshal(generic)
+		if err != nil {
+			return fmt.Errorf("failed to marshal effective config: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported config format '%s'. Valid formats: json, yaml", format)
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// applyTimeWindow parses the --since and --until flags into the app config
+func (h *CommandHandler) applyTimeWindow(since, until string) error {
+	now := time.Now()
+	h.app.config.Since, h.app.config.Until = nil, nil
+
+	if since != "" {
+		t, err := report.ParseTimeBound(since, now)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		h.app.config.Since = &t
+	}
+	if until != "" {
+		t, err := report.ParseTimeBound(until, now)
+		if err != nil {
+			return fmt.Errorf("invalid --until: %w", err)
+		}
+		h.app.config.Until = &t
+	}
+	return nil
+}
+
 // outputResult outputs the result to file or stdout based on the output parameter
 func (h *CommandHandler) outputResult(data []byte, outputFile string) error {
 	logger := logging.GetLogger()
@@ -292,11 +580,11 @@ func (h *CommandHandler) outputResult(data []byte, outputFile string) error {
 func (h *CommandHandler) ExecuteCommand(args []string) error {
 	rootCmd := h.CreateRootCommand()
 	rootCmd.SetArgs(args)
-	return rootCmd.Execute()
+	return rootCmd.ExecuteContext(h.app.Context())
 }
 
 // ExecuteRootCommand executes the root command (used by main.go)
 func (h *CommandHandler) ExecuteRootCommand() error {
 	rootCmd := h.CreateRootCommand()
-	return rootCmd.Execute()
+	return rootCmd.ExecuteContext(h.app.Context())
 }
\ No newline at end of file
@@ -2,11 +2,18 @@ package app
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
 
 	"firefly-task/config"
+	"firefly-task/drift"
+	"firefly-task/pkg/interfaces"
 	"firefly-task/pkg/logging"
 )
 
@@ -70,7 +77,7 @@ func TestCreateRootCommand(t *testing.T) {
 
 	// Check that subcommands are added
 	subcommands := rootCmd.Commands()
-	expectedCommands := []string{"check", "batch", "attribute"}
+	expectedCommands := []string{"check", "batch", "attribute", "explain", "capabilities", "list-resources"}
 
 	if len(subcommands) != len(expectedCommands) {
 		t.Errorf("Expected %d subcommands, got %d", len(expectedCommands), len(subcommands))
@@ -326,4 +333,299 @@ func TestExecuteCommand(t *testing.T) {
 			t.Error("Expected error for invalid command, got nil")
 		}
 	})
-}
\ No newline at end of file
+}
+func TestPrintConfigFlag(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.SetDefaults()
+
+	logging.InitLogger("debug", false)
+	logger := logging.GetLogger()
+
+	app := New(cfg, &MockEC2Client{}, &MockTerraformParser{}, &MockDriftDetector{}, &MockReportGenerator{}, logger)
+	handler := NewCommandHandler(app)
+
+	t.Setenv("FIREFLY_DRIFT_CONFIG", filepath.Join(t.TempDir(), "missing.json"))
+	t.Setenv(drift.EnvMaxConcurrency, "42")
+
+	rootCmd := handler.CreateRootCommand()
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"--print-config"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Expected no error printing config, got: %v", err)
+	}
+
+	printed := out.String()
+	if !strings.Contains(printed, `"max_concurrency": 42`) {
+		t.Errorf("Expected env-overridden max_concurrency in output, got:\n%s", printed)
+	}
+	if !strings.Contains(printed, `"max_concurrency": "env"`) {
+		t.Errorf("Expected max_concurrency to be attributed to env, got:\n%s", printed)
+	}
+
+	rootCmd = handler.CreateRootCommand()
+	out.Reset()
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"--print-config", "--config-format", "yaml"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Expected no error printing config as yaml, got: %v", err)
+	}
+	if !strings.Contains(out.String(), "max_concurrency: 42") {
+		t.Errorf("Expected yaml output, got:\n%s", out.String())
+	}
+}
+
+func TestSinceFlagFiltersOlderResults(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.SetDefaults()
+
+	logging.InitLogger("debug", false)
+	logger := logging.GetLogger()
+
+	app := New(cfg, &MockEC2Client{}, &MockTerraformParser{}, &MockDriftDetector{}, &MockReportGenerator{}, logger)
+	handler := NewCommandHandler(app)
+
+	checkCmd := handler.CreateCheckCommand()
+	if err := checkCmd.ParseFlags([]string{"--since", "1h"}); err != nil {
+		t.Fatalf("Expected --since to parse, got: %v", err)
+	}
+	since, _ := checkCmd.Flags().GetString("since")
+	if err := handler.applyTimeWindow(since, ""); err != nil {
+		t.Fatalf("Expected 1h to be a valid window, got: %v", err)
+	}
+	if cfg.Since == nil || time.Since(*cfg.Since) < 59*time.Minute {
+		t.Fatalf("Expected since to be about an hour ago, got %v", cfg.Since)
+	}
+
+	results := map[string]*interfaces.DriftResult{
+		"i-recent": {ResourceID: "i-recent", DetectionTime: time.Now().Add(-10 * time.Minute)},
+		"i-old":    {ResourceID: "i-old", DetectionTime: time.Now().Add(-3 * time.Hour)},
+	}
+	filtered := app.filterByDetectionTime(results)
+	if _, ok := filtered["i-recent"]; !ok {
+		t.Error("Expected recent result to be kept")
+	}
+	if _, ok := filtered["i-old"]; ok {
+		t.Error("Expected result older than the window to be excluded")
+	}
+
+	if err := handler.applyTimeWindow("yesterday", ""); err == nil {
+		t.Error("Expected an invalid --since value to be rejected")
+	}
+}
+
+func TestExplainCommand(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.SetDefaults()
+
+	logging.InitLogger("debug", false)
+	logger := logging.GetLogger()
+
+	app := New(cfg, &MockEC2Client{}, &MockTerraformParser{}, &MockDriftDetector{}, &MockReportGenerator{}, logger)
+	handler := NewCommandHandler(app)
+
+	dir := t.TempDir()
+	t.Setenv("FIREFLY_DRIFT_CONFIG", filepath.Join(dir, "missing.json"))
+
+	awsFile := filepath.Join(dir, "instance.json")
+	tfFile := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(awsFile, []byte(`{"instance_id": "i-123", "instance_type": "t3.large", "tags": {"Name": "web"}}`), 0644); err != nil {
+		t.Fatalf("Failed to write AWS fixture: %v", err)
+	}
+	if err := os.WriteFile(tfFile, []byte(`{"instance_type": "t3.micro", "tags": {"Name": "web"}, "resource_name": "web"}`), 0644); err != nil {
+		t.Fatalf("Failed to write Terraform fixture: %v", err)
+	}
+
+	run := func(args ...string) string {
+		rootCmd := handler.CreateRootCommand()
+		var out bytes.Buffer
+		rootCmd.SetOut(&out)
+		rootCmd.SetArgs(append([]string{"explain", "--aws-file", awsFile, "--tf-file", tfFile}, args...))
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("Expected no error running explain, got: %v", err)
+		}
+		return out.String()
+	}
+
+	table := run()
+	if !strings.Contains(table, "Resource i-123 (aws_instance): drifted") {
+		t.Errorf("Expected drifted status line, got:\n%s", table)
+	}
+	for _, line := range strings.Split(table, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "instance_type":
+			if fields[1] != drift.DecisionDrift {
+				t.Errorf("Expected instance_type to be drift, got line: %s", line)
+			}
+		case "tags":
+			if fields[1] != drift.DecisionMatch {
+				t.Errorf("Expected tags to match, got line: %s", line)
+			}
+		}
+	}
+
+	var explanation drift.DriftExplanation
+	if err := json.Unmarshal([]byte(run("--format", "json")), &explanation); err != nil {
+		t.Fatalf("Expected JSON output, got error: %v", err)
+	}
+	if !explanation.IsDrifted || explanation.ResourceID != "i-123" {
+		t.Errorf("Expected drifted explanation for i-123, got %+v", explanation)
+	}
+	found := false
+	for _, d := range explanation.Decisions {
+		if d.Attribute == "instance_type" {
+			found = true
+			if d.Decision != drift.DecisionDrift || d.ActualValue != "t3.large" || d.ExpectedValue != "t3.micro" {
+				t.Errorf("Unexpected instance_type decision: %+v", d)
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected a decision for instance_type")
+	}
+}
+
+func TestCapabilitiesCommand(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.SetDefaults()
+
+	logging.InitLogger("debug", false)
+	logger := logging.GetLogger()
+
+	app := New(cfg, &MockEC2Client{}, &MockTerraformParser{}, &MockDriftDetector{}, &MockReportGenerator{}, logger)
+	handler := NewCommandHandler(app)
+
+	rootCmd := handler.CreateRootCommand()
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"capabilities"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Expected no error running capabilities, got: %v", err)
+	}
+
+	var info CapabilityInfo
+	if err := json.Unmarshal(out.Bytes(), &info); err != nil {
+		t.Fatalf("Expected JSON output, got error: %v", err)
+	}
+
+	contains := func(list []string, want string) bool {
+		for _, item := range list {
+			if item == want {
+				return true
+			}
+		}
+		return false
+	}
+	if !contains(info.ResourceTypes, "aws_instance") {
+		t.Errorf("Expected aws_instance in resource types, got %v", info.ResourceTypes)
+	}
+	for _, format := range []string{"json", "yaml", "sarif", "csv"} {
+		if !contains(info.ReportFormats, format) {
+			t.Errorf("Expected %s in report formats, got %v", format, info.ReportFormats)
+		}
+	}
+	var comparators []string
+	for _, ct := range info.ComparisonTypes {
+		comparators = append(comparators, ct.Name)
+	}
+	for _, name := range []string{"exact", "fuzzy", "numeric_tolerance", "array_unordered", "map"} {
+		if !contains(comparators, name) {
+			t.Errorf("Expected comparator %s, got %v", name, comparators)
+		}
+	}
+	if !contains(info.CIPlatforms, "github-actions") || info.DetectedPlatform == "" {
+		t.Errorf("Expected CI platforms and a detected platform, got %v / %q", info.CIPlatforms, info.DetectedPlatform)
+	}
+}
+
+func TestCapabilitiesIncludesRegisteredMappers(t *testing.T) {
+	detector := drift.NewDriftDetector(drift.DefaultDetectionConfig())
+	detector.RegisterResourceMapper("*custom.Queue", func(resource interface{}) (map[string]interface{}, error) {
+		return nil, nil
+	})
+
+	info := capabilitiesFor(detector)
+	last := info.ResourceTypes[len(info.ResourceTypes)-1]
+	if last != "*custom.Queue" {
+		t.Errorf("Expected registered mapper in resource types, got %v", info.ResourceTypes)
+	}
+}
+
+func TestListResourcesCommand(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.SetDefaults()
+	logging.InitLogger("debug", false)
+	logger := logging.GetLogger()
+
+	mockEC2 := &MockEC2Client{}
+	mockTF := &MockTerraformParser{}
+	app := New(cfg, mockEC2, mockTF, &MockDriftDetector{}, &MockReportGenerator{}, logger)
+	handler := NewCommandHandler(app)
+
+	tfConfigs := map[string]*interfaces.TerraformConfig{
+		"i-web": {
+			ResourceID:   "i-web",
+			ResourceType: "aws_instance",
+			ResourceName: "web",
+		},
+		"aws_instance.worker": {
+			ResourceID:   "aws_instance.worker",
+			ResourceType: "aws_instance",
+			ResourceName: "worker",
+			Module:       "compute",
+			Attributes:   map[string]interface{}{"id": "i-worker"},
+		},
+		"aws_instance.spare": {
+			ResourceID:   "aws_instance.spare",
+			ResourceType: "aws_instance",
+			ResourceName: "spare",
+		},
+	}
+	mockTF.On("ParseTerraformHCL", "/path/to/terraform").Return(tfConfigs, nil)
+	mockEC2.On("ListEC2Instances", mock.Anything).Return([]*interfaces.EC2Instance{
+		{InstanceID: "i-web"},
+		{InstanceID: "i-worker"},
+		{InstanceID: "i-unmanaged"},
+	}, nil)
+
+	rootCmd := handler.CreateRootCommand()
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"list-resources", "--tf-path", "/path/to/terraform", "--format", "json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Expected no error running list-resources, got: %v", err)
+	}
+
+	var listings []ResourceListing
+	if err := json.Unmarshal(out.Bytes(), &listings); err != nil {
+		t.Fatalf("Expected JSON output, got error: %v", err)
+	}
+
+	expected := []ResourceListing{
+		{Address: "aws_instance.spare", ResourceType: "aws_instance", ResourceID: "aws_instance.spare"},
+		{Address: "aws_instance.web", ResourceType: "aws_instance", ResourceID: "i-web", AWSMatch: true, AWSInstanceID: "i-web"},
+		{Address: "module.compute.aws_instance.worker", ResourceType: "aws_instance", ResourceID: "aws_instance.worker", AWSMatch: true, AWSInstanceID: "i-worker"},
+	}
+	if len(listings) != len(expected) {
+		t.Fatalf("Expected %d resources, got %d: %+v", len(expected), len(listings), listings)
+	}
+	for i, want := range expected {
+		if listings[i] != want {
+			t.Errorf("Resource %d: expected %+v, got %+v", i, want, listings[i])
+		}
+	}
+
+	out.Reset()
+	rootCmd.SetArgs([]string{"list-resources", "--tf-path", "/path/to/terraform", "--format", "table"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Expected no error running list-resources, got: %v", err)
+	}
+	if !strings.Contains(out.String(), "module.compute.aws_instance.worker") || !strings.Contains(out.String(), "AWS MATCH") {
+		t.Errorf("Expected table output listing resources, got:\n%s", out.String())
+	}
+}
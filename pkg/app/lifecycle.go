@@ -27,15 +27,25 @@ func (a *Application) Start() error {
 		return err
 	}
 
-	// Set up signal handling for graceful shutdown
-	signalChan := make(chan os.Signal, 1)
-	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+	// Deliver results to the configured webhook, flushed on shutdown
+	if a.config.WebhookURL != "" {
+		a.mu.Lock()
+		a.webhook = newWebhookSink(a.config.WebhookURL)
+		a.mu.Unlock()
+		a.RegisterSink(a.webhook)
+	}
 
-	go func() {
-		<-signalChan
-		log.Println("Shutdown signal received")
-		a.Shutdown()
-	}()
+	// Serve run status until shutdown
+	if a.config.StatusAddr != "" {
+		if err := a.startStatusServer(); err != nil {
+			return err
+		}
+	}
+
+	// Set up signal handling for graceful shutdown
+	a.signalChan = make(chan os.Signal, 1)
+	signal.Notify(a.signalChan, os.Interrupt, syscall.SIGTERM)
+	go a.handleSignals(a.signalChan)
 
 	// Initialize components that need startup
 	// This could include health checks, connection validation, etc.
@@ -60,6 +70,9 @@ func (a *Application) Shutdown() {
 	// Signal shutdown via context
 	a.cancelFunc()
 
+	// Give integrations a bounded window to deliver what is in flight
+	a.flushSinks(a.shutdownGracePeriod())
+
 	// Set a timeout for graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -81,6 +94,118 @@ func (a *Application) Shutdown() {
 	log.Println("Application shutdown complete")
 }
 
+// handleSignals shuts the application down on the first SIGINT or SIGTERM,
+// which cancels in-flight detection and gives sinks their flush window. The
+// signal is recorded for InterruptSignal so main can choose the exit code. It
+// stops listening once the application is shut down by other means.
+func (a *Application) handleSignals(signals chan os.Signal) {
+	defer signal.Stop(signals)
+
+	select {
+	case sig := <-signals:
+		if a.IsShuttingDown() {
+			return
+		}
+		log.Printf("Shutdown signal received: %s", sig)
+		a.mu.Lock()
+		a.interruptSignal = sig
+		a.mu.Unlock()
+		a.Shutdown()
+	case <-a.ctx.Done():
+	}
+}
+
+// InterruptSignal returns the signal that shut the application down, or nil
+// if it was not interrupted
+func (a *Application) InterruptSignal() os.Signal {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.interruptSignal
+}
+
+// DefaultShutdownGracePeriod is how long sinks may flush on shutdown when
+// Config.ShutdownGracePeriod is unset
+const DefaultShutdownGracePeriod = 5 * time.Second
+
+// Sink is an integration that may have deliveries in flight, such as a
+// webhook, chat notification or metrics exporter
+type Sink interface {
+	// Name identifies the sink in shutdown logs
+	Name() string
+	// Flush delivers anything pending, giving up when ctx is done
+	Flush(ctx context.Context) error
+}
+
+// RegisterSink adds a sink to flush when the application shuts down
+func (a *Application) RegisterSink(sink Sink) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.sinks = append(a.sinks, sink)
+}
+
+// shutdownGracePeriod returns the configured sink flush window
+func (a *Application) shutdownGracePeriod() time.Duration {
+	if a.config != nil && a.config.ShutdownGracePeriod > 0 {
+		return a.config.ShutdownGracePeriod
+	}
+	return DefaultShutdownGracePeriod
+}
+
+// flushSinks flushes all registered sinks concurrently, waiting at most
+// grace. It logs and returns the names of the sinks that finished and of
+// those whose pending deliveries were dropped, by failing or by not finishing
+// in time, both in registration order.
+func (a *Application) flushSinks(grace time.Duration) (flushed, dropped []string) {
+	a.mu.Lock()
+	sinks := append([]Sink(nil), a.sinks...)
+	a.mu.Unlock()
+	if len(sinks) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), grace)
+	defer cancel()
+
+	type flushResult struct {
+		index int
+		err   error
+	}
+	results := make(chan flushResult, len(sinks))
+	for i, sink := range sinks {
+		go func(i int, sink Sink) {
+			results <- flushResult{index: i, err: sink.Flush(ctx)}
+		}(i, sink)
+	}
+
+	errs := make([]error, len(sinks))
+	finished := make([]bool, len(sinks))
+collect:
+	for received := 0; received < len(sinks); received++ {
+		select {
+		case r := <-results:
+			finished[r.index] = true
+			errs[r.index] = r.err
+		case <-ctx.Done():
+			break collect
+		}
+	}
+
+	for i, sink := range sinks {
+		switch {
+		case !finished[i]:
+			log.Printf("Dropped pending deliveries for sink %s: grace period of %s expired", sink.Name(), grace)
+			dropped = append(dropped, sink.Name())
+		case errs[i] != nil:
+			log.Printf("Dropped pending deliveries for sink %s: %v", sink.Name(), errs[i])
+			dropped = append(dropped, sink.Name())
+		default:
+			log.Printf("Flushed sink %s", sink.Name())
+			flushed = append(flushed, sink.Name())
+		}
+	}
+	return flushed, dropped
+}
+
 // Wait blocks until the application context is cancelled
 func (a *Application) Wait() {
 	<-a.ctx.Done()
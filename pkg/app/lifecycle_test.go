@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"sync"
+	"syscall"
 	"testing"
 	"time"
 
@@ -309,4 +310,86 @@ func TestApplication_ContextCancellation(t *testing.T) {
 	}
 
 	assert.Equal(t, context.Canceled, ctx.Err())
-}
\ No newline at end of file
+}
+// testSink is a Sink whose Flush runs flushFunc and records when it was called
+type testSink struct {
+	name      string
+	flushFunc func(ctx context.Context) error
+	called    chan time.Time
+}
+
+func newTestSink(name string, flushFunc func(ctx context.Context) error) *testSink {
+	return &testSink{name: name, flushFunc: flushFunc, called: make(chan time.Time, 1)}
+}
+
+func (s *testSink) Name() string { return s.name }
+
+func (s *testSink) Flush(ctx context.Context) error {
+	s.called <- time.Now()
+	return s.flushFunc(ctx)
+}
+
+func TestApplication_SignalFlushesSinks(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.SetDefaults()
+	cfg.ShutdownGracePeriod = 200 * time.Millisecond
+	logging.InitLogger("debug", false)
+	app := New(cfg, &MockEC2Client{}, &MockTerraformParser{}, &MockDriftDetector{}, &MockReportGenerator{}, logging.GetLogger())
+
+	webhook := newTestSink("webhook", func(ctx context.Context) error { return nil })
+	// Ignores its context, so only the grace period stops the wait for it
+	metrics := newTestSink("metrics", func(ctx context.Context) error {
+		time.Sleep(2 * time.Second)
+		return nil
+	})
+	app.RegisterSink(webhook)
+	app.RegisterSink(metrics)
+
+	assert.NoError(t, app.Start())
+
+	// A detection run in progress when the signal arrives
+	runCancelled := make(chan struct{})
+	app.wg.Add(1)
+	go func() {
+		defer app.wg.Done()
+		<-app.Context().Done()
+		close(runCancelled)
+	}()
+
+	signalled := time.Now()
+	app.signalChan <- syscall.SIGTERM
+
+	select {
+	case <-runCancelled:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the in-flight run to be cancelled")
+	}
+	for _, sink := range []*testSink{webhook, metrics} {
+		select {
+		case at := <-sink.called:
+			assert.Less(t, at.Sub(signalled), cfg.ShutdownGracePeriod, "%s flushed outside the grace window", sink.name)
+		case <-time.After(time.Second):
+			t.Errorf("Expected %s to be flushed", sink.name)
+		}
+	}
+	assert.Equal(t, syscall.SIGTERM, app.InterruptSignal())
+	assert.True(t, app.IsShuttingDown())
+}
+
+func TestApplication_FlushSinks(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.SetDefaults()
+	logging.InitLogger("debug", false)
+	app := New(cfg, &MockEC2Client{}, &MockTerraformParser{}, &MockDriftDetector{}, &MockReportGenerator{}, logging.GetLogger())
+
+	app.RegisterSink(newTestSink("webhook", func(ctx context.Context) error { return nil }))
+	app.RegisterSink(newTestSink("slack", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+	app.RegisterSink(newTestSink("metrics", func(ctx context.Context) error { return nil }))
+
+	flushed, dropped := app.flushSinks(50 * time.Millisecond)
+	assert.Equal(t, []string{"webhook", "metrics"}, flushed)
+	assert.Equal(t, []string{"slack"}, dropped)
+}
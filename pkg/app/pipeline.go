@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"firefly-task/pkg/interfaces"
+	"firefly-task/report"
+)
+
+// ReportOutput holds the generated report and the recommendations derived
+// from the same results
+type ReportOutput struct {
+	Report   []byte
+	Analysis *report.DriftAnalysis
+}
+
+// GenerateReportWithRecommendations generates the report in format and the
+// drift recommendations for results. With Config.ConcurrentRecommendations
+// the recommendations are generated in their own goroutine while the report
+// is written; both are joined before returning. Errors from either path are
+// joined into the returned error.
+func (a *Application) GenerateReportWithRecommendations(ctx context.Context, results map[string]*interfaces.DriftResult, format string) (*ReportOutput, error) {
+	a.wg.Add(1)
+	defer a.wg.Done()
+
+	if a.config == nil || !a.config.ConcurrentRecommendations {
+		data, err := a.GenerateReport(results, format)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate report: %w", err)
+		}
+		analysis, err := a.generateRecommendations(ctx, results)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate recommendations: %w", err)
+		}
+		return &ReportOutput{Report: data, Analysis: analysis}, nil
+	}
+
+	// Each path reads its own copy of the map, so a writer or analyzer that
+	// adds or drops entries cannot race with the other
+	analysisResults := copyResults(results)
+	reportResults := copyResults(results)
+
+	var analysis *report.DriftAnalysis
+	var recommendErr error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		analysis, recommendErr = a.generateRecommendations(ctx, analysisResults)
+	}()
+
+	data, reportErr := a.GenerateReport(reportResults, format)
+	<-done
+
+	var errs []error
+	if reportErr != nil {
+		errs = append(errs, fmt.Errorf("failed to generate report: %w", reportErr))
+	}
+	if recommendErr != nil {
+		errs = append(errs, fmt.Errorf("failed to generate recommendations: %w", recommendErr))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return &ReportOutput{Report: data, Analysis: analysis}, nil
+}
+
+// generateRecommendations runs the configured recommender, turning a panic
+// into an error so a failure in the background goroutine reaches the caller
+func (a *Application) generateRecommendations(ctx context.Context, results map[string]*interfaces.DriftResult) (analysis *report.DriftAnalysis, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			analysis, err = nil, fmt.Errorf("recommendation generation panicked: %v", r)
+		}
+	}()
+
+	if a.recommender != nil {
+		return a.recommender(ctx, results)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return report.AnalyzeDriftPatterns(results, report.DefaultRecommendationConfig()), nil
+}
+
+// copyResults returns a shallow copy of results
+func copyResults(results map[string]*interfaces.DriftResult) map[string]*interfaces.DriftResult {
+	copied := make(map[string]*interfaces.DriftResult, len(results))
+	for key, result := range results {
+		copied[key] = result
+	}
+	return copied
+}
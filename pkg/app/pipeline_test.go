@@ -0,0 +1,119 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"firefly-task/config"
+	"firefly-task/pkg/interfaces"
+	"firefly-task/pkg/logging"
+	"firefly-task/report"
+)
+
+func newPipelineTestApp(concurrent bool) (*Application, *MockReportGenerator) {
+	cfg := &config.Config{}
+	cfg.SetDefaults()
+	cfg.ConcurrentRecommendations = concurrent
+	logging.InitLogger("debug", false)
+	mockReport := &MockReportGenerator{}
+	app := New(cfg, &MockEC2Client{}, &MockTerraformParser{}, &MockDriftDetector{}, mockReport, logging.GetLogger())
+	return app, mockReport
+}
+
+func pipelineTestResults() map[string]*interfaces.DriftResult {
+	return map[string]*interfaces.DriftResult{
+		"i-web": {
+			ResourceID: "i-web",
+			IsDrifted:  true,
+			Severity:   interfaces.SeverityCritical,
+			DriftDetails: []*interfaces.DriftDetail{
+				{Attribute: "instance_type", ExpectedValue: "t3.micro", ActualValue: "t3.large", Severity: interfaces.SeverityCritical},
+			},
+		},
+		"i-db": {ResourceID: "i-db", Severity: interfaces.SeverityNone},
+	}
+}
+
+func TestGenerateReportWithRecommendations_Concurrent(t *testing.T) {
+	app, mockReport := newPipelineTestApp(true)
+	results := pipelineTestResults()
+
+	// The report waits for recommendation generation to start, which only
+	// happens if the two run at the same time
+	recommendStarted := make(chan struct{})
+	app.recommender = func(ctx context.Context, results map[string]*interfaces.DriftResult) (*report.DriftAnalysis, error) {
+		close(recommendStarted)
+		return report.AnalyzeDriftPatterns(results, report.DefaultRecommendationConfig()), nil
+	}
+	mockReport.On("GenerateJSONReport", results).Run(func(mock.Arguments) {
+		select {
+		case <-recommendStarted:
+		case <-time.After(2 * time.Second):
+			t.Error("Expected recommendations to be generated while the report is written")
+		}
+	}).Return([]byte(`{"ok":true}`), nil)
+
+	output, err := app.GenerateReportWithRecommendations(context.Background(), results, "json")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`{"ok":true}`), output.Report)
+	if assert.NotNil(t, output.Analysis) && assert.NotEmpty(t, output.Analysis.Recommendations) {
+		assert.Equal(t, report.PriorityUrgent, output.Analysis.Recommendations[0].Priority)
+		assert.Equal(t, []string{"i-web"}, output.Analysis.Recommendations[0].Resources)
+	}
+	mockReport.AssertExpectations(t)
+}
+
+func TestGenerateReportWithRecommendations_Errors(t *testing.T) {
+	reportErr := errors.New("disk full")
+	recommendErr := errors.New("analysis failed")
+
+	tests := []struct {
+		name         string
+		concurrent   bool
+		reportErr    error
+		recommendErr error
+		want         []error
+	}{
+		{"report error", true, reportErr, nil, []error{reportErr}},
+		{"recommendation error", true, nil, recommendErr, []error{recommendErr}},
+		{"both errors", true, reportErr, recommendErr, []error{reportErr, recommendErr}},
+		{"sequential recommendation error", false, nil, recommendErr, []error{recommendErr}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, mockReport := newPipelineTestApp(tt.concurrent)
+			results := pipelineTestResults()
+			app.recommender = func(ctx context.Context, results map[string]*interfaces.DriftResult) (*report.DriftAnalysis, error) {
+				if tt.recommendErr != nil {
+					return nil, tt.recommendErr
+				}
+				return &report.DriftAnalysis{}, nil
+			}
+			mockReport.On("GenerateJSONReport", results).Return([]byte("{}"), tt.reportErr)
+
+			output, err := app.GenerateReportWithRecommendations(context.Background(), results, "json")
+			assert.Nil(t, output)
+			for _, want := range tt.want {
+				assert.ErrorIs(t, err, want)
+			}
+		})
+	}
+}
+
+func TestGenerateReportWithRecommendations_RecoversPanic(t *testing.T) {
+	app, mockReport := newPipelineTestApp(true)
+	results := pipelineTestResults()
+	app.recommender = func(ctx context.Context, results map[string]*interfaces.DriftResult) (*report.DriftAnalysis, error) {
+		panic("boom")
+	}
+	mockReport.On("GenerateJSONReport", results).Return([]byte("{}"), nil)
+
+	_, err := app.GenerateReportWithRecommendations(context.Background(), results, "json")
+	assert.ErrorContains(t, err, "recommendation generation panicked: boom")
+}
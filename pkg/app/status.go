@@ -0,0 +1,172 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"firefly-task/drift"
+	"firefly-task/pkg/interfaces"
+	"firefly-task/report"
+)
+
+// Run states reported by StatusServer
+const (
+	RunStateIdle    = "idle"
+	RunStateRunning = "running"
+)
+
+// statusShutdownTimeout bounds how long Serve waits for in-flight requests
+// once its context is done
+const statusShutdownTimeout = 5 * time.Second
+
+// RunStatus is the JSON body served at /status
+type RunStatus struct {
+	State       string          `json:"state"`
+	LastRunTime *time.Time      `json:"last_run_time,omitempty"`
+	LastSummary *report.Summary `json:"last_summary,omitempty"`
+	DriftScore  int             `json:"drift_score"`
+}
+
+// StatusServer serves /healthz and /status for a long-running process that
+// checks for drift repeatedly. Callers report each run with RunStarted and
+// RunFinished.
+type StatusServer struct {
+	mu     sync.RWMutex
+	status RunStatus
+}
+
+// NewStatusServer creates an idle StatusServer with no recorded runs
+func NewStatusServer() *StatusServer {
+	return &StatusServer{status: RunStatus{State: RunStateIdle}}
+}
+
+// RunStarted marks a drift run as in progress
+func (s *StatusServer) RunStarted() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.State = RunStateRunning
+}
+
+// RunEnded marks the server idle, keeping the last recorded run. It is used
+// when a run stops without results to record.
+func (s *StatusServer) RunEnded() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.State = RunStateIdle
+}
+
+// RunFinished records the results of the latest run and marks the server idle
+func (s *StatusServer) RunFinished(results map[string]*interfaces.DriftResult) {
+	summary := report.Summarize(results)
+	score := drift.ComputeBatchDriftScore(results, nil, nil)
+	now := time.Now().UTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = RunStatus{
+		State:       RunStateIdle,
+		LastRunTime: &now,
+		LastSummary: &summary,
+		DriftScore:  score,
+	}
+}
+
+// Status returns the current run status
+func (s *StatusServer) Status() RunStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.status
+}
+
+// Handler returns the HTTP handler serving /healthz and /status
+func (s *StatusServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte("ok\n"))
+	})
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s.Status())
+	})
+	return mux
+}
+
+// ListenAndServe serves the status endpoints on addr until ctx is done
+func (s *StatusServer) ListenAndServe(ctx context.Context, addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, listener)
+}
+
+// Serve serves the status endpoints on listener until ctx is done, then shuts
+// the server down gracefully
+func (s *StatusServer) Serve(ctx context.Context, listener net.Listener) error {
+	server := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), statusShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errChan; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// startStatusServer serves the status endpoints on Config.StatusAddr until the
+// application shuts down
+func (a *Application) startStatusServer() error {
+	listener, err := net.Listen("tcp", a.config.StatusAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on status address %s: %w", a.config.StatusAddr, err)
+	}
+	status := NewStatusServer()
+
+	a.mu.Lock()
+	a.status = status
+	a.statusListener = listener
+	a.mu.Unlock()
+
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		if err := status.Serve(a.ctx, listener); err != nil {
+			log.Printf("Status server stopped: %v", err)
+		}
+	}()
+	log.Printf("Serving status on %s", listener.Addr())
+	return nil
+}
+
+// statusServer returns the running status server, or nil when Config.StatusAddr
+// is unset
+func (a *Application) statusServer() *StatusServer {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.status
+}
@@ -0,0 +1,184 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"firefly-task/config"
+	"firefly-task/pkg/interfaces"
+	"firefly-task/pkg/logging"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStatusServer_Serve(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	baseURL := "http://" + listener.Addr().String()
+
+	status := NewStatusServer()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- status.Serve(ctx, listener)
+	}()
+
+	resp, err := http.Get(baseURL + "/healthz")
+	if err != nil {
+		t.Fatalf("GET /healthz failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || strings.TrimSpace(string(body)) != "ok" {
+		t.Errorf("Expected /healthz to return 200 ok, got %d %q", resp.StatusCode, body)
+	}
+
+	status.RunStarted()
+	if got := status.Status().State; got != RunStateRunning {
+		t.Errorf("Expected state %s during a run, got %s", RunStateRunning, got)
+	}
+	status.RunFinished(map[string]*interfaces.DriftResult{
+		"i-1": {
+			ResourceID: "i-1",
+			IsDrifted:  true,
+			Severity:   interfaces.SeverityHigh,
+			DriftDetails: []*interfaces.DriftDetail{
+				{Attribute: "instance_type", Severity: interfaces.SeverityHigh},
+				{Attribute: "tags", Severity: interfaces.SeverityLow},
+			},
+		},
+		"i-2": {ResourceID: "i-2", Severity: interfaces.SeverityNone},
+	})
+
+	resp, err = http.Get(baseURL + "/status")
+	if err != nil {
+		t.Fatalf("GET /status failed: %v", err)
+	}
+	var got RunStatus
+	err = json.NewDecoder(resp.Body).Decode(&got)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("Failed to decode /status: %v", err)
+	}
+
+	if got.State != RunStateIdle {
+		t.Errorf("Expected state %s after a run, got %s", RunStateIdle, got.State)
+	}
+	if got.LastRunTime == nil {
+		t.Error("Expected last run time to be set")
+	}
+	if got.LastSummary == nil {
+		t.Fatal("Expected last summary to be set")
+	}
+	if got.LastSummary.TotalResources != 2 || got.LastSummary.DriftedResources != 1 || got.LastSummary.TotalDifferences != 2 {
+		t.Errorf("Unexpected summary: %+v", got.LastSummary)
+	}
+	if got.DriftScore != 6 {
+		t.Errorf("Expected drift score 6, got %d", got.DriftScore)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Serve() returned %v after shutdown", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve() did not return after context cancellation")
+	}
+}
+
+// getStatus fetches /status from the application's status server
+func getStatus(t *testing.T, app *Application) RunStatus {
+	t.Helper()
+	app.mu.Lock()
+	addr := app.statusListener.Addr().String()
+	app.mu.Unlock()
+
+	resp, err := http.Get("http://" + addr + "/status")
+	if err != nil {
+		t.Fatalf("GET /status failed: %v", err)
+	}
+	defer resp.Body.Close()
+	var status RunStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		t.Fatalf("Failed to decode /status: %v", err)
+	}
+	return status
+}
+
+func TestBatchCommand_StatusAddr(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.SetDefaults()
+	mockEC2 := &MockEC2Client{}
+	mockTF := &MockTerraformParser{}
+	mockDrift := &MockDriftDetector{}
+	mockReport := &MockReportGenerator{}
+	logging.InitLogger("debug", false)
+	app := New(cfg, mockEC2, mockTF, mockDrift, mockReport, logging.GetLogger())
+
+	dir := t.TempDir()
+	inputFile := filepath.Join(dir, "instances.txt")
+	if err := os.WriteFile(inputFile, []byte("i-1\ni-2\n"), 0644); err != nil {
+		t.Fatalf("Failed to write input file: %v", err)
+	}
+
+	instances := map[string]*interfaces.EC2Instance{"i-1": {InstanceID: "i-1"}, "i-2": {InstanceID: "i-2"}}
+	tfConfigs := map[string]*interfaces.TerraformConfig{}
+	driftResults := map[string]*interfaces.DriftResult{
+		"i-1": {
+			ResourceID:    "i-1",
+			IsDrifted:     true,
+			Severity:      interfaces.SeverityHigh,
+			DetectionTime: time.Now(),
+			DriftDetails:  []*interfaces.DriftDetail{{Attribute: "instance_type", Severity: interfaces.SeverityHigh}},
+		},
+		"i-2": {ResourceID: "i-2", Severity: interfaces.SeverityNone, DetectionTime: time.Now()},
+	}
+
+	var during, after RunStatus
+	mockEC2.On("GetMultipleEC2Instances", mock.Anything, []string{"i-1", "i-2"}).Return(instances, nil)
+	mockTF.On("ParseTerraformHCL", "/path/to/terraform").Return(tfConfigs, nil)
+	mockDrift.On("DetectMultipleDrift", instances, tfConfigs, DefaultAttributes).
+		Run(func(mock.Arguments) { during = getStatus(t, app) }).
+		Return(driftResults, nil)
+	mockReport.On("GenerateJSONReport", mock.Anything).
+		Run(func(mock.Arguments) { after = getStatus(t, app) }).
+		Return([]byte("{}"), nil)
+
+	err := NewCommandHandler(app).ExecuteCommand([]string{"batch",
+		"--input-file", inputFile,
+		"--tf-path", "/path/to/terraform",
+		"--output", filepath.Join(dir, "report.json"),
+		"--status-addr", "127.0.0.1:0",
+	})
+	if err != nil {
+		t.Fatalf("batch failed: %v", err)
+	}
+
+	if during.State != RunStateRunning {
+		t.Errorf("Expected state %s during the run, got %s", RunStateRunning, during.State)
+	}
+	if after.State != RunStateIdle || after.LastRunTime == nil || after.LastSummary == nil {
+		t.Fatalf("Expected an idle status with the last run recorded, got %+v", after)
+	}
+	if after.LastSummary.TotalResources != 2 || after.LastSummary.DriftedResources != 1 {
+		t.Errorf("Unexpected summary: %+v", after.LastSummary)
+	}
+
+	app.mu.Lock()
+	addr := app.statusListener.Addr().String()
+	app.mu.Unlock()
+	if _, err := http.Get("http://" + addr + "/healthz"); err == nil {
+		t.Error("Expected the status server to stop with the application")
+	}
+}
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"firefly-task/pkg/interfaces"
+	"firefly-task/report"
+)
+
+// webhookSink posts drift results to Config.WebhookURL in the background, so
+// a run can write its report while deliveries are in flight. Pending
+// deliveries are flushed when the application shuts down.
+type webhookSink struct {
+	uploader *report.ReportUploader
+	url      string
+
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+}
+
+// newWebhookSink creates a webhookSink posting to url
+func newWebhookSink(url string) *webhookSink {
+	return &webhookSink{
+		uploader: report.NewReportUploader(report.NewReportConfig()),
+		url:      url,
+	}
+}
+
+// Name identifies the sink in shutdown logs
+func (s *webhookSink) Name() string {
+	return "webhook"
+}
+
+// Deliver starts posting results to the webhook
+func (s *webhookSink) Deliver(results map[string]*interfaces.DriftResult) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.uploader.SendToWebhook(results, s.url); err != nil {
+			s.mu.Lock()
+			s.errs = append(s.errs, err)
+			s.mu.Unlock()
+		}
+	}()
+}
+
+// Flush waits for pending deliveries, returning their errors, or ctx's error
+// if it is done first
+func (s *webhookSink) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return errors.Join(s.errs...)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// deliverWebhook hands results to the webhook sink when one is configured
+func (a *Application) deliverWebhook(results map[string]*interfaces.DriftResult) {
+	a.mu.Lock()
+	sink := a.webhook
+	a.mu.Unlock()
+	if sink != nil {
+		sink.Deliver(results)
+	}
+}
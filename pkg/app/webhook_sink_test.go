@@ -0,0 +1,88 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"firefly-task/config"
+	"firefly-task/pkg/interfaces"
+	"firefly-task/pkg/logging"
+	"firefly-task/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchCommand_WebhookURLFlushedOnShutdown(t *testing.T) {
+	payloads := make(chan report.WebhookPayload, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Slow enough that the run finishes before the delivery does
+		time.Sleep(50 * time.Millisecond)
+		var payload report.WebhookPayload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		payloads <- payload
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{}
+	cfg.SetDefaults()
+	cfg.ShutdownGracePeriod = 2 * time.Second
+	mockEC2 := &MockEC2Client{}
+	mockTF := &MockTerraformParser{}
+	mockDrift := &MockDriftDetector{}
+	mockReport := &MockReportGenerator{}
+	logging.InitLogger("debug", false)
+	app := New(cfg, mockEC2, mockTF, mockDrift, mockReport, logging.GetLogger())
+
+	dir := t.TempDir()
+	inputFile := filepath.Join(dir, "instances.txt")
+	require.NoError(t, os.WriteFile(inputFile, []byte("i-1\n"), 0644))
+
+	instances := map[string]*interfaces.EC2Instance{"i-1": {InstanceID: "i-1"}}
+	tfConfigs := map[string]*interfaces.TerraformConfig{}
+	driftResults := map[string]*interfaces.DriftResult{
+		"i-1": {ResourceID: "i-1", IsDrifted: true, DetectionTime: time.Now()},
+	}
+	mockEC2.On("GetMultipleEC2Instances", mock.Anything, []string{"i-1"}).Return(instances, nil)
+	mockTF.On("ParseTerraformHCL", "/path/to/terraform").Return(tfConfigs, nil)
+	mockDrift.On("DetectMultipleDrift", instances, tfConfigs, DefaultAttributes).Return(driftResults, nil)
+	mockReport.On("GenerateJSONReport", mock.Anything).Return([]byte("{}"), nil)
+
+	handler := NewCommandHandler(app)
+	err := handler.ExecuteCommand([]string{"batch",
+		"--input-file", inputFile,
+		"--tf-path", "/path/to/terraform",
+		"--output", filepath.Join(dir, "report.json"),
+		"--webhook-url", server.URL,
+	})
+	require.NoError(t, err)
+
+	// Shutdown flushed the webhook sink before the command returned
+	select {
+	case payload := <-payloads:
+		assert.Equal(t, report.WebhookSchemaVersion, payload.SchemaVersion)
+		assert.Contains(t, payload.Results, "i-1")
+	default:
+		t.Fatal("Expected the webhook delivery to finish before the command returned")
+	}
+}
+
+func TestWebhookSink_FlushReportsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	sink := newWebhookSink(server.URL)
+	sink.Deliver(map[string]*interfaces.DriftResult{})
+
+	err := sink.Flush(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "400")
+}
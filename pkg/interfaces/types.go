@@ -172,6 +172,22 @@ type DriftResult struct {
 
 	// Severity is the overall severity of the drift
 	Severity SeverityLevel `json:"severity"`
+
+	// Tags are the resource's tags as reported by AWS
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// DisplayName is a human-friendly name for reports, taken from the Name
+	// tag; ResourceID remains the identifier for targeting
+	DisplayName string `json:"display_name,omitempty"`
+
+	// Owner is the team or contact responsible for the resource, used to
+	// route remediation
+	Owner string `json:"owner,omitempty"`
+
+	// MatchedDetails lists attributes that were verified equal, when the
+	// detector records matches for auditing. They are kept apart from
+	// DriftDetails and do not affect IsDrifted or Severity.
+	MatchedDetails []*DriftDetail `json:"matched_details,omitempty"`
 }
 
 // SeverityLevel defines the severity of a drift
@@ -244,6 +260,62 @@ type DriftDetail struct {
 
 	// Severity is the severity of the drift for this attribute
 	Severity SeverityLevel `json:"severity"`
+
+	// ComparisonTrace records how the difference was computed, when tracing is enabled
+	ComparisonTrace *ComparisonTrace `json:"comparison_trace,omitempty"`
+
+	// Known marks drift that was already present in an accepted baseline
+	Known bool `json:"known,omitempty"`
+
+	// ReasonCode is a stable code for the kind of difference, for automation
+	// that should not parse Description
+	ReasonCode ReasonCode `json:"reason_code,omitempty"`
+
+	// Matched marks an attribute that was compared and found equal; such
+	// details are only recorded in DriftResult.MatchedDetails
+	Matched bool `json:"matched,omitempty"`
+
+	// SetDelta lists the elements added and removed for unordered array
+	// comparisons configured to report them
+	SetDelta *SetDelta `json:"set_delta,omitempty"`
+}
+
+// SetDelta is the multiset difference between two unordered arrays
+type SetDelta struct {
+	// Added holds elements present in AWS but not in the Terraform configuration
+	Added []interface{} `json:"added"`
+
+	// Removed holds elements in the Terraform configuration that AWS lacks
+	Removed []interface{} `json:"removed"`
+
+	// Matched counts the elements present on both sides
+	Matched int `json:"matched"`
+}
+
+// ReasonCode classifies why a drift detail was reported
+type ReasonCode string
+
+const (
+	// ReasonValueChanged indicates both sides have the attribute with different values
+	ReasonValueChanged ReasonCode = "VALUE_CHANGED"
+	// ReasonMissingInAWS indicates the attribute is only in the Terraform configuration
+	ReasonMissingInAWS ReasonCode = "MISSING_IN_AWS"
+	// ReasonMissingInTerraform indicates the attribute is only on the AWS resource
+	ReasonMissingInTerraform ReasonCode = "MISSING_IN_TERRAFORM"
+	// ReasonTypeMismatch indicates the values have incompatible types
+	ReasonTypeMismatch ReasonCode = "TYPE_MISMATCH"
+)
+
+// ComparisonTrace describes the comparator and settings that produced a drift detail
+type ComparisonTrace struct {
+	// Comparator is the name of the comparison strategy used
+	Comparator string `json:"comparator"`
+
+	// CaseSensitive indicates whether string comparison was case sensitive
+	CaseSensitive bool `json:"case_sensitive"`
+
+	// Tolerance is the numeric tolerance applied, if any
+	Tolerance *float64 `json:"tolerance,omitempty"`
 }
 
 // DriftStatistics represents statistics about drift detection results
@@ -0,0 +1,18 @@
+// Package numfmt formats numbers consistently for drift comparison and
+// report display
+package numfmt
+
+import (
+	"math"
+	"strconv"
+)
+
+// FormatFloat rounds v to precision decimals and formats it without trailing
+// zeros. A negative precision formats v without rounding.
+func FormatFloat(v float64, precision int) string {
+	if precision < 0 {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	scale := math.Pow(10, float64(precision))
+	return strconv.FormatFloat(math.Round(v*scale)/scale, 'f', -1, 64)
+}
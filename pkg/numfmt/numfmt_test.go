@@ -0,0 +1,23 @@
+package numfmt
+
+import "testing"
+
+func TestFormatFloat(t *testing.T) {
+	tests := []struct {
+		value     float64
+		precision int
+		want      string
+	}{
+		{3.140000001, 2, "3.14"},
+		{3.145, 2, "3.15"},
+		{2.5, 0, "3"},
+		{1.10, 3, "1.1"},
+		{123.456, -1, "123.456"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatFloat(tt.value, tt.precision); got != tt.want {
+			t.Errorf("FormatFloat(%v, %d) = %q, want %q", tt.value, tt.precision, got, tt.want)
+		}
+	}
+}
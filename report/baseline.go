@@ -0,0 +1,72 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// DriftFingerprint identifies a drift detail by resource, attribute and values
+func DriftFingerprint(resourceID string, detail *interfaces.DriftDetail) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%v\x00%v",
+		resourceID, detail.Attribute, detail.ExpectedValue, detail.ActualValue)))
+	return hex.EncodeToString(sum[:])
+}
+
+// BaselineFingerprints returns the set of drift fingerprints in a result set
+func BaselineFingerprints(results map[string]*interfaces.DriftResult) map[string]bool {
+	fingerprints := make(map[string]bool)
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		for _, detail := range result.DriftDetails {
+			if detail != nil {
+				fingerprints[DriftFingerprint(result.ResourceID, detail)] = true
+			}
+		}
+	}
+	return fingerprints
+}
+
+// LoadBaseline reads a previously written JSON report to use as a baseline
+func LoadBaseline(path string) (map[string]*interfaces.DriftResult, error) {
+	return readReportResults(path)
+}
+
+// DowngradeKnownDrift marks drift details that already existed in the
+// baseline as known and lowers them to SeverityNone, keeping them in the
+// report. New drift keeps its severity. Each result's overall severity is
+// recomputed. It returns the number of details downgraded.
+func DowngradeKnownDrift(current, baseline map[string]*interfaces.DriftResult) int {
+	known := BaselineFingerprints(baseline)
+	if len(known) == 0 {
+		return 0
+	}
+
+	downgraded := 0
+	for _, result := range current {
+		if result == nil {
+			continue
+		}
+
+		changed := false
+		for _, detail := range result.DriftDetails {
+			if detail == nil || !known[DriftFingerprint(result.ResourceID, detail)] {
+				continue
+			}
+			detail.Known = true
+			detail.Severity = interfaces.SeverityNone
+			downgraded++
+			changed = true
+		}
+
+		if changed {
+			result.Severity = result.GetHighestSeverity()
+		}
+	}
+
+	return downgraded
+}
@@ -0,0 +1,165 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// ErrObjectNotFound is returned by an ObjectStore when the requested object
+// does not exist
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectStore reads and writes whole objects in a bucket, such as S3
+type ObjectStore interface {
+	GetObject(ctx context.Context, bucket, key string) ([]byte, error)
+	PutObject(ctx context.Context, bucket, key string, data []byte) error
+}
+
+// S3API is the subset of the S3 client used by S3ObjectStore
+type S3API interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+// S3ObjectStore is an ObjectStore backed by an S3 client
+type S3ObjectStore struct {
+	client S3API
+}
+
+// NewS3ObjectStore creates an ObjectStore using client, typically
+// s3.NewFromConfig(cfg)
+func NewS3ObjectStore(client S3API) *S3ObjectStore {
+	return &S3ObjectStore{client: client}
+}
+
+// GetObject downloads bucket/key. A missing key or bucket returns an error
+// wrapping ErrObjectNotFound.
+func (s *S3ObjectStore) GetObject(ctx context.Context, bucket, key string) ([]byte, error) {
+	output, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) {
+			switch apiErr.ErrorCode() {
+			case "NoSuchKey", "NotFound":
+				return nil, fmt.Errorf("%w: %v", ErrObjectNotFound, err)
+			}
+		}
+		return nil, err
+	}
+	defer output.Body.Close()
+	return io.ReadAll(output.Body)
+}
+
+// PutObject uploads data to bucket/key as JSON
+func (s *S3ObjectStore) PutObject(ctx context.Context, bucket, key string, data []byte) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(FormatJSON.MIMEType()),
+	})
+	return err
+}
+
+// LoadBaselineFromS3 reads a baseline report stored at bucket/key
+func LoadBaselineFromS3(ctx context.Context, store ObjectStore, bucket, key string) (map[string]*interfaces.DriftResult, error) {
+	if store == nil {
+		return nil, NewReportError(ErrorTypeInvalidInput, "object store cannot be nil")
+	}
+	data, err := store.GetObject(ctx, bucket, key)
+	if err != nil {
+		return nil, WrapReportError(ErrorTypeFileOperation, fmt.Sprintf("failed to download baseline s3://%s/%s", bucket, key), err)
+	}
+	return parseReportResults(data, fmt.Sprintf("s3://%s/%s", bucket, key))
+}
+
+// SaveBaselineToS3 stores results at bucket/key as the baseline for later runs
+func SaveBaselineToS3(ctx context.Context, store ObjectStore, bucket, key string, results map[string]*interfaces.DriftResult) error {
+	if store == nil {
+		return NewReportError(ErrorTypeInvalidInput, "object store cannot be nil")
+	}
+	data, err := marshalBaseline(results)
+	if err != nil {
+		return err
+	}
+	return putBaseline(ctx, store, bucket, key, data)
+}
+
+func marshalBaseline(results map[string]*interfaces.DriftResult) ([]byte, error) {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return nil, WrapError(ErrorTypeMarshaling, "failed to marshal baseline", err)
+	}
+	return data, nil
+}
+
+func putBaseline(ctx context.Context, store ObjectStore, bucket, key string, data []byte) error {
+	if err := store.PutObject(ctx, bucket, key, data); err != nil {
+		return WrapReportError(ErrorTypeFileOperation, fmt.Sprintf("failed to upload baseline s3://%s/%s", bucket, key), err)
+	}
+	return nil
+}
+
+// BaselineSync is the outcome of SyncBaselineWithS3
+type BaselineSync struct {
+	// Downgraded is the number of drift details already in the baseline
+	Downgraded int
+	// NewDrift is the number of drift details not in the baseline
+	NewDrift int
+	// Saved reports whether current was written back as the new baseline
+	Saved bool
+}
+
+// SyncBaselineWithS3 compares current against the last-known-good baseline
+// at bucket/key, downgrading drift already present there as
+// DowngradeKnownDrift does. When the run is clean, meaning it found no drift
+// beyond the baseline, current is saved back as the new baseline. A missing
+// baseline object is treated as an empty baseline, so the first clean run
+// creates it.
+func SyncBaselineWithS3(ctx context.Context, store ObjectStore, bucket, key string, current map[string]*interfaces.DriftResult) (*BaselineSync, error) {
+	baseline, err := LoadBaselineFromS3(ctx, store, bucket, key)
+	if err != nil && !errors.Is(err, ErrObjectNotFound) {
+		return nil, err
+	}
+
+	// Snapshot current before DowngradeKnownDrift rewrites its severities in
+	// place, so a saved baseline keeps the real ones
+	snapshot, err := marshalBaseline(current)
+	if err != nil {
+		return nil, err
+	}
+
+	outcome := &BaselineSync{Downgraded: DowngradeKnownDrift(current, baseline)}
+	for _, result := range current {
+		if result == nil {
+			continue
+		}
+		for _, detail := range result.DriftDetails {
+			if detail != nil && !detail.Known {
+				outcome.NewDrift++
+			}
+		}
+	}
+
+	if outcome.NewDrift > 0 {
+		return outcome, nil
+	}
+	if err := putBaseline(ctx, store, bucket, key, snapshot); err != nil {
+		return outcome, err
+	}
+	outcome.Saved = true
+	return outcome, nil
+}
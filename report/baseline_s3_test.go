@@ -0,0 +1,154 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// mockObjectStore is an in-memory ObjectStore that records uploads
+type mockObjectStore struct {
+	objects map[string][]byte
+	puts    []string
+	getErr  error
+}
+
+func (m *mockObjectStore) GetObject(ctx context.Context, bucket, key string) ([]byte, error) {
+	if m.getErr != nil {
+		return nil, m.getErr
+	}
+	data, ok := m.objects[bucket+"/"+key]
+	if !ok {
+		return nil, ErrObjectNotFound
+	}
+	return data, nil
+}
+
+func (m *mockObjectStore) PutObject(ctx context.Context, bucket, key string, data []byte) error {
+	if m.objects == nil {
+		m.objects = make(map[string][]byte)
+	}
+	m.objects[bucket+"/"+key] = data
+	m.puts = append(m.puts, bucket+"/"+key)
+	return nil
+}
+
+func TestSyncBaselineWithS3(t *testing.T) {
+	baselineData, err := json.Marshal(createTestDriftResults())
+	require.NoError(t, err)
+	store := &mockObjectStore{objects: map[string][]byte{"drift-state/baseline.json": baselineData}}
+	ctx := context.Background()
+
+	baseline, err := LoadBaselineFromS3(ctx, store, "drift-state", "baseline.json")
+	require.NoError(t, err)
+	assert.Len(t, baseline, 4)
+
+	// Only drift already in the baseline: known, and saved back as clean
+	current := createTestDriftResults()
+	outcome, err := SyncBaselineWithS3(ctx, store, "drift-state", "baseline.json", current)
+	require.NoError(t, err)
+	assert.Equal(t, 3, outcome.Downgraded)
+	assert.Equal(t, 0, outcome.NewDrift)
+	assert.True(t, outcome.Saved)
+	assert.Equal(t, []string{"drift-state/baseline.json"}, store.puts)
+	assert.Equal(t, interfaces.SeverityNone, current["aws_instance.web-server-2"].Severity)
+
+	// The saved baseline keeps the severities from before the downgrade
+	saved, err := LoadBaselineFromS3(ctx, store, "drift-state", "baseline.json")
+	require.NoError(t, err)
+	original := createTestDriftResults()["aws_instance.web-server-2"]
+	assert.Equal(t, original.Severity, saved["aws_instance.web-server-2"].Severity)
+	assert.False(t, saved["aws_instance.web-server-2"].DriftDetails[0].Known)
+
+	// New drift is reported and the baseline is left alone
+	current = createTestDriftResults()
+	current["aws_lb.main"].DriftDetails[0].ActualValue = "maybe"
+	outcome, err = SyncBaselineWithS3(ctx, store, "drift-state", "baseline.json", current)
+	require.NoError(t, err)
+	assert.Equal(t, 2, outcome.Downgraded)
+	assert.Equal(t, 1, outcome.NewDrift)
+	assert.False(t, outcome.Saved)
+	assert.Len(t, store.puts, 1)
+	assert.Equal(t, interfaces.SeverityHigh, current["aws_lb.main"].Severity)
+}
+
+func TestSyncBaselineWithS3_MissingBaseline(t *testing.T) {
+	store := &mockObjectStore{}
+	current := map[string]*interfaces.DriftResult{
+		"i-clean": {ResourceID: "i-clean", Severity: interfaces.SeverityNone},
+	}
+
+	outcome, err := SyncBaselineWithS3(context.Background(), store, "drift-state", "baseline.json", current)
+	require.NoError(t, err)
+	assert.True(t, outcome.Saved, "a clean first run creates the baseline")
+
+	saved, err := LoadBaselineFromS3(context.Background(), store, "drift-state", "baseline.json")
+	require.NoError(t, err)
+	assert.Contains(t, saved, "i-clean")
+}
+
+func TestSyncBaselineWithS3_DownloadError(t *testing.T) {
+	store := &mockObjectStore{getErr: errors.New("access denied")}
+
+	_, err := SyncBaselineWithS3(context.Background(), store, "drift-state", "baseline.json", createTestDriftResults())
+	require.Error(t, err)
+	assert.True(t, IsReportError(err, ErrorTypeFileOperation))
+	assert.Empty(t, store.puts)
+}
+
+// fakeS3 is an in-memory S3API
+type fakeS3 struct {
+	objects      map[string][]byte
+	contentTypes map[string]string
+}
+
+func (f *fakeS3) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	data, ok := f.objects[aws.ToString(params.Bucket)+"/"+aws.ToString(params.Key)]
+	if !ok {
+		return nil, &smithy.GenericAPIError{Code: "NoSuchKey", Message: "The specified key does not exist."}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(data))}, nil
+}
+
+func (f *fakeS3) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	data, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	key := aws.ToString(params.Bucket) + "/" + aws.ToString(params.Key)
+	f.objects[key] = data
+	f.contentTypes[key] = aws.ToString(params.ContentType)
+	return &s3.PutObjectOutput{}, nil
+}
+
+func TestS3ObjectStore(t *testing.T) {
+	client := &fakeS3{objects: map[string][]byte{}, contentTypes: map[string]string{}}
+	store := NewS3ObjectStore(client)
+	ctx := context.Background()
+
+	_, err := store.GetObject(ctx, "drift-state", "baseline.json")
+	assert.ErrorIs(t, err, ErrObjectNotFound)
+
+	current := map[string]*interfaces.DriftResult{
+		"i-clean": {ResourceID: "i-clean", Severity: interfaces.SeverityNone},
+	}
+	outcome, err := SyncBaselineWithS3(ctx, store, "drift-state", "baseline.json", current)
+	require.NoError(t, err)
+	assert.True(t, outcome.Saved)
+	assert.Equal(t, "application/json", client.contentTypes["drift-state/baseline.json"])
+
+	saved, err := LoadBaselineFromS3(ctx, store, "drift-state", "baseline.json")
+	require.NoError(t, err)
+	assert.Contains(t, saved, "i-clean")
+}
@@ -0,0 +1,69 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func TestDowngradeKnownDrift(t *testing.T) {
+	baseline := createTestDriftResults()
+	current := createTestDriftResults()
+
+	// New drift on a resource that already had known drift
+	webServer2 := current["aws_instance.web-server-2"]
+	webServer2.DriftDetails = append(webServer2.DriftDetails, &interfaces.DriftDetail{
+		Attribute:     "ami",
+		ExpectedValue: "ami-old",
+		ActualValue:   "ami-new",
+		Severity:      interfaces.SeverityHigh,
+	})
+
+	// Same attribute as the baseline, but the value drifted further
+	current["aws_lb.main"].DriftDetails[0].ActualValue = "maybe"
+
+	downgraded := DowngradeKnownDrift(current, baseline)
+	assert.Equal(t, 2, downgraded)
+
+	known := current["aws_instance.web-server-1"].DriftDetails[0]
+	assert.True(t, known.Known)
+	assert.Equal(t, interfaces.SeverityNone, known.Severity)
+	assert.Equal(t, interfaces.SeverityNone, current["aws_instance.web-server-1"].Severity)
+	assert.True(t, current["aws_instance.web-server-1"].IsDrifted, "known drift stays recorded")
+
+	assert.True(t, webServer2.DriftDetails[0].Known)
+	assert.False(t, webServer2.DriftDetails[1].Known)
+	assert.Equal(t, interfaces.SeverityHigh, webServer2.DriftDetails[1].Severity)
+	assert.Equal(t, interfaces.SeverityHigh, webServer2.Severity)
+
+	changed := current["aws_lb.main"]
+	assert.False(t, changed.DriftDetails[0].Known)
+	assert.Equal(t, interfaces.SeverityHigh, changed.Severity)
+}
+
+func TestDowngradeKnownDrift_EmptyBaseline(t *testing.T) {
+	current := createTestDriftResults()
+	assert.Equal(t, 0, DowngradeKnownDrift(current, nil))
+	assert.Equal(t, interfaces.SeverityCritical, current["aws_instance.web-server-2"].Severity)
+}
+
+func TestLoadBaseline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	data, err := NewStandardReportGenerator().GenerateJSONReport(createTestDriftResults())
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0644))
+
+	baseline, err := LoadBaseline(path)
+	require.NoError(t, err)
+	assert.Len(t, baseline, 4)
+
+	encoded, err := json.Marshal(baseline["aws_lb.main"])
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), "publicly_accessible")
+}
@@ -4,11 +4,14 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"html"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"firefly-task/pkg/interfaces"
@@ -23,6 +26,7 @@ const (
 	PlatformJenkins       CICDPlatform = "jenkins"
 	PlatformAzureDevOps   CICDPlatform = "azure-devops"
 	PlatformCircleCI      CICDPlatform = "circleci"
+	PlatformTravis        CICDPlatform = "travis"
 	PlatformGeneric       CICDPlatform = "generic"
 )
 
@@ -31,6 +35,9 @@ type Artifact struct {
 	Path string `json:"path"`
 	Type string `json:"type"`
 	Size int64  `json:"size"`
+	// ContentEncoding is "gzip" for compressed artifacts; uploaders should
+	// send it as the Content-Encoding header
+	ContentEncoding string `json:"content_encoding,omitempty"`
 }
 
 // CIReportGenerator implements the ReportGenerator interface for CI/CD pipelines
@@ -39,6 +46,10 @@ type CIReportGenerator struct {
 	Platform  CICDPlatform
 	workspace string
 	OutputDir string
+	// ArtifactConcurrency caps how many artifacts WriteArtifacts writes at
+	// once. Zero or negative writes all artifacts in parallel; 1 writes them
+	// sequentially.
+	ArtifactConcurrency int
 }
 
 // String returns the string representation of CICDPlatform
@@ -54,6 +65,8 @@ func (p CICDPlatform) String() string {
 		return "azure-devops"
 	case PlatformCircleCI:
 		return "circleci"
+	case PlatformTravis:
+		return "travis"
 	case PlatformGeneric:
 		return "generic"
 	default:
@@ -81,6 +94,20 @@ func NewCIReportGeneratorWithConfig(config *ReportConfig, platform CICDPlatform,
 	}
 }
 
+// SupportedPlatforms returns the CI/CD platforms with dedicated output
+// support, followed by the generic fallback
+func SupportedPlatforms() []CICDPlatform {
+	return []CICDPlatform{
+		PlatformGitHubActions,
+		PlatformGitLab,
+		PlatformJenkins,
+		PlatformAzureDevOps,
+		PlatformCircleCI,
+		PlatformTravis,
+		PlatformGeneric,
+	}
+}
+
 // DetectPlatform automatically detects the CI/CD platform from environment
 func DetectPlatform() CICDPlatform {
 	if os.Getenv("GITHUB_ACTIONS") == "true" {
@@ -98,6 +125,9 @@ func DetectPlatform() CICDPlatform {
 	if os.Getenv("CIRCLECI") == "true" {
 		return PlatformCircleCI
 	}
+	if os.Getenv("TRAVIS") == "true" {
+		return PlatformTravis
+	}
 	return PlatformGeneric
 }
 
@@ -314,6 +344,7 @@ type CIAction struct {
 	Description string `json:"description"`
 	Priority    string `json:"priority"`
 	Command     string `json:"command,omitempty"`
+	Owner       string `json:"owner,omitempty"`
 }
 
 // CIMetadata contains CI pipeline metadata
@@ -399,39 +430,27 @@ func (crg *CIReportGenerator) buildCIReport(results map[string]*interfaces.Drift
 
 // buildCISummary creates a CI-focused summary
 func (crg *CIReportGenerator) buildCISummary(results map[string]*interfaces.DriftResult) CISummary {
-	totalResources := len(results)
-	resourcesWithDrift := 0
-	totalDifferences := 0
-	severityCounts := make(map[string]int)
-	highestSeverity := interfaces.SeverityNone
+	summary := Summarize(results)
 
-	for _, result := range results {
-		if result.IsDrifted {
-			resourcesWithDrift++
-			totalDifferences += len(result.DriftDetails)
-			if result.Severity > highestSeverity {
-				highestSeverity = result.Severity
-			}
-		}
-		severityCounts[strings.ToLower(string(result.Severity))]++
+	severityCounts := make(map[string]int, len(summary.SeverityCounts))
+	for severity, count := range summary.SeverityCounts {
+		severityCounts[strings.ToLower(string(severity))] = count
 	}
 
-	cleanResources := totalResources - resourcesWithDrift
-
 	highestSeverityStr := "NONE"
-	if highestSeverity != interfaces.SeverityNone {
-		highestSeverityStr = strings.ToUpper(string(highestSeverity))
+	if summary.HighestSeverity != interfaces.SeverityNone {
+		highestSeverityStr = strings.ToUpper(string(summary.HighestSeverity))
 	}
 
 	return CISummary{
-		TotalResources:     totalResources,
-		ResourcesWithDrift: resourcesWithDrift,
-		DriftedResources:   resourcesWithDrift,
-		CleanResources:     cleanResources,
-		TotalDifferences:   totalDifferences,
+		TotalResources:     summary.TotalResources,
+		ResourcesWithDrift: summary.DriftedResources,
+		DriftedResources:   summary.DriftedResources,
+		CleanResources:     summary.CleanResources,
+		TotalDifferences:   summary.TotalDifferences,
 		SeverityCounts:     severityCounts,
 		HighestSeverity:    highestSeverityStr,
-		Passed:             resourcesWithDrift == 0,
+		Passed:             summary.DriftedResources == 0,
 	}
 }
 
@@ -450,6 +469,7 @@ func (crg *CIReportGenerator) generateCIActions(results map[string]*interfaces.D
 				ResourceID:  resourceID,
 				Description: fmt.Sprintf("Drift detected in %s: %s", diff.Attribute, diff.Description),
 				Priority:    strings.ToLower(string(diff.Severity)),
+				Owner:       result.Owner,
 			}
 
 			// Add command suggestions based on drift type
@@ -487,43 +507,91 @@ func (crg *CIReportGenerator) WriteArtifacts(results map[string]*interfaces.Drif
 		return nil, WrapReportError(ErrorTypeFileOperation, "failed to create artifact directory", err)
 	}
 
-	var artifacts []Artifact
-
-	// Write JSON artifact
-	jsonArtifact, err := crg.WriteJSONArtifact(results)
-	if err != nil {
-		return nil, err
+	single := func(write func(map[string]*interfaces.DriftResult) (*Artifact, error)) func() ([]Artifact, error) {
+		return func() ([]Artifact, error) {
+			artifact, err := write(results)
+			if err != nil {
+				return nil, err
+			}
+			return []Artifact{*artifact}, nil
+		}
 	}
-	artifacts = append(artifacts, *jsonArtifact)
 
-	// Write JUnit XML artifact
-	junitArtifact, err := crg.WriteJUnitXMLArtifact(results)
-	if err != nil {
-		return nil, err
+	// Writers run in this order of precedence; the returned artifacts keep it
+	// regardless of which writer finishes first
+	writers := []func() ([]Artifact, error){
+		single(crg.WriteJSONArtifact),
+		single(crg.WriteJUnitXMLArtifact),
+		single(crg.WriteSummaryArtifact),
+		func() ([]Artifact, error) {
+			interfaceResults := make(map[string]interfaces.DriftResult)
+			for k, v := range results {
+				interfaceResults[k] = *v
+			}
+			return crg.writePlatformSpecificArtifacts(interfaceResults, artifactDir)
+		},
 	}
-	artifacts = append(artifacts, *junitArtifact)
 
-	// Write summary artifact
-	summaryArtifact, err := crg.WriteSummaryArtifact(results)
-	if err != nil {
-		return nil, err
+	limit := crg.ArtifactConcurrency
+	if limit <= 0 || limit > len(writers) {
+		limit = len(writers)
 	}
-	artifacts = append(artifacts, *summaryArtifact)
 
-	// Write platform-specific artifacts
-		interfaceResults := make(map[string]interfaces.DriftResult)
-	for k, v := range results {
-		interfaceResults[k] = *v
+	written := make([][]Artifact, len(writers))
+	errs := make([]error, len(writers))
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i, write := range writers {
+		wg.Add(1)
+		go func(i int, write func() ([]Artifact, error)) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			written[i], errs[i] = write()
+		}(i, write)
 	}
-	platformArtifacts, err := crg.writePlatformSpecificArtifacts(interfaceResults, artifactDir)
-	if err != nil {
-		return artifacts, err
+	wg.Wait()
+
+	var artifacts []Artifact
+	platformIndex := len(writers) - 1
+	for i := range writers {
+		if errs[i] != nil {
+			// Core artifacts are required; platform artifacts are best effort
+			if i < platformIndex {
+				return nil, errs[i]
+			}
+			return artifacts, errs[i]
+		}
+		artifacts = append(artifacts, written[i]...)
 	}
-	artifacts = append(artifacts, platformArtifacts...)
 
 	return artifacts, nil
 }
 
+// artifactPath returns the path recorded for an artifact written to
+// filePath. With RelativePaths set it is made relative to ArtifactBaseDir,
+// or to OutputDir when no base is configured.
+func (crg *CIReportGenerator) artifactPath(filePath string) string {
+	if crg.config == nil || !crg.config.RelativePaths {
+		return filePath
+	}
+
+	base := crg.config.ArtifactBaseDir
+	if base == "" {
+		base = crg.OutputDir
+	}
+	absBase, err1 := filepath.Abs(base)
+	absPath, err2 := filepath.Abs(filePath)
+	if err1 != nil || err2 != nil {
+		return filePath
+	}
+	rel, err := filepath.Rel(absBase, absPath)
+	if err != nil {
+		return filePath
+	}
+	return filepath.ToSlash(rel)
+}
+
 // WriteJSONArtifact writes a JSON artifact and returns artifact info
 func (crg *CIReportGenerator) WriteJSONArtifact(results map[string]*interfaces.DriftResult) (*Artifact, error) {
 	// Convert to interface results
@@ -543,17 +611,7 @@ func (crg *CIReportGenerator) WriteJSONArtifact(results map[string]*interfaces.D
 		return nil, err
 	}
 
-	// Get file size
-	fileInfo, err := os.Stat(filePath)
-	if err != nil {
-		return nil, WrapReportError(ErrorTypeFileOperation, "failed to get file info", err)
-	}
-
-	return &Artifact{
-		Path: filePath,
-		Type: "json",
-		Size: fileInfo.Size(),
-	}, nil
+	return crg.coreArtifact(filePath, "json")
 }
 
 // WriteJUnitXMLArtifact writes a JUnit XML artifact and returns artifact info
@@ -568,17 +626,7 @@ func (crg *CIReportGenerator) WriteJUnitXMLArtifact(results map[string]*interfac
 		return nil, err
 	}
 
-	// Get file size
-	fileInfo, err := os.Stat(filePath)
-	if err != nil {
-		return nil, WrapReportError(ErrorTypeFileOperation, "failed to get file info", err)
-	}
-
-	return &Artifact{
-		Path: filePath,
-		Type: "junit-xml",
-		Size: fileInfo.Size(),
-	}, nil
+	return crg.coreArtifact(filePath, "junit-xml")
 }
 
 // WriteSummaryArtifact writes a summary artifact and returns artifact info
@@ -592,21 +640,45 @@ func (crg *CIReportGenerator) WriteSummaryArtifact(results map[string]*interface
 		return nil, WrapReportError(ErrorTypeFileOperation, "failed to write summary file", err)
 	}
 
-	// Get file size
+	return crg.coreArtifact(filePath, "summary")
+}
+
+// coreArtifact compresses filePath when configured and describes the
+// resulting file
+func (crg *CIReportGenerator) coreArtifact(filePath, artifactType string) (*Artifact, error) {
+	if crg.config != nil && crg.config.Compress {
+		compressed, err := gzipFile(filePath)
+		if err != nil {
+			return nil, WrapReportError(ErrorTypeFileOperation, "failed to compress artifact", err)
+		}
+		filePath = compressed
+	}
+
 	fileInfo, err := os.Stat(filePath)
 	if err != nil {
 		return nil, WrapReportError(ErrorTypeFileOperation, "failed to get file info", err)
 	}
 
 	return &Artifact{
-		Path: filePath,
-		Type: "summary",
-		Size: fileInfo.Size(),
+		Path:            crg.artifactPath(filePath),
+		Type:            artifactType,
+		Size:            fileInfo.Size(),
+		ContentEncoding: ContentEncoding(filePath),
 	}, nil
 }
 
 // SetExitCode sets appropriate exit code based on drift results
 func (crg *CIReportGenerator) SetExitCode(results map[string]*interfaces.DriftResult) int {
+	var failOnAttributes []string
+	if crg.config != nil {
+		failOnAttributes = crg.config.FailOnAttributes
+	}
+	return exitCodeFor(results, failOnAttributes)
+}
+
+// exitCodeFor returns the CI exit code for results: 2 for critical drift, 1
+// for high severity drift or drift in failOnAttributes, otherwise 0
+func exitCodeFor(results map[string]*interfaces.DriftResult, failOnAttributes []string) int {
 	if results == nil {
 		return 1 // Error
 	}
@@ -633,12 +705,39 @@ func (crg *CIReportGenerator) SetExitCode(results map[string]*interfaces.DriftRe
 	if hasHigh {
 		return 1 // High severity drift
 	}
+	if HasAttributeDrift(results, failOnAttributes) {
+		return 1 // Drift in an attribute that always fails the build
+	}
 	if hasDrift {
 		return 0 // Drift detected but not critical
 	}
 	return 0 // No drift
 }
 
+// HasAttributeDrift reports whether any drifted detail's attribute is in attributes
+func HasAttributeDrift(results map[string]*interfaces.DriftResult, attributes []string) bool {
+	if len(attributes) == 0 {
+		return false
+	}
+
+	watched := make(map[string]bool, len(attributes))
+	for _, attr := range attributes {
+		watched[attr] = true
+	}
+
+	for _, result := range results {
+		if result == nil || !result.IsDrifted {
+			continue
+		}
+		for _, detail := range result.DriftDetails {
+			if detail != nil && watched[detail.Attribute] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // SetEnvironmentVariables sets CI/CD environment variables with results
 func (crg *CIReportGenerator) SetEnvironmentVariables(results map[string]*interfaces.DriftResult) error {
 	summary := crg.buildCISummary(results)
@@ -675,6 +774,8 @@ func (crg *CIReportGenerator) SetEnvironmentVariables(results map[string]*interf
 		return crg.setGitLabEnv(envVars, results)
 	case PlatformJenkins:
 		return crg.setJenkinsEnv(envVars, results)
+	case PlatformTravis:
+		return crg.setTravisEnv(envVars)
 	default:
 		return crg.setGenericEnv(envVars)
 	}
@@ -728,6 +829,8 @@ func (crg *CIReportGenerator) getJobID() string {
 		return os.Getenv("BUILD_BUILDID")
 	case PlatformCircleCI:
 		return os.Getenv("CIRCLE_BUILD_NUM")
+	case PlatformTravis:
+		return os.Getenv("TRAVIS_JOB_ID")
 	default:
 		return "unknown"
 	}
@@ -745,6 +848,8 @@ func (crg *CIReportGenerator) getBuildNumber() string {
 		return os.Getenv("BUILD_BUILDNUMBER")
 	case PlatformCircleCI:
 		return os.Getenv("CIRCLE_BUILD_NUM")
+	case PlatformTravis:
+		return os.Getenv("TRAVIS_BUILD_NUMBER")
 	default:
 		return "unknown"
 	}
@@ -762,6 +867,8 @@ func (crg *CIReportGenerator) getBranch() string {
 		return os.Getenv("BUILD_SOURCEBRANCHNAME")
 	case PlatformCircleCI:
 		return os.Getenv("CIRCLE_BRANCH")
+	case PlatformTravis:
+		return os.Getenv("TRAVIS_BRANCH")
 	default:
 		return "unknown"
 	}
@@ -779,6 +886,8 @@ func (crg *CIReportGenerator) getCommitSHA() string {
 		return os.Getenv("BUILD_SOURCEVERSION")
 	case PlatformCircleCI:
 		return os.Getenv("CIRCLE_SHA1")
+	case PlatformTravis:
+		return os.Getenv("TRAVIS_COMMIT")
 	default:
 		return "unknown"
 	}
@@ -812,6 +921,8 @@ func (crg *CIReportGenerator) SetPlatformSpecificVariables(results map[string]*i
 		return crg.setGitLabEnv(envVars, results)
 	case PlatformJenkins:
 		return crg.setJenkinsEnv(envVars, results)
+	case PlatformTravis:
+		return crg.setTravisEnv(envVars)
 	default:
 		return crg.setGenericEnv(envVars)
 	}
@@ -911,6 +1022,36 @@ func (crg *CIReportGenerator) setJenkinsEnv(envVars map[string]string, results m
 	return nil
 }
 
+func (crg *CIReportGenerator) setTravisEnv(envVars map[string]string) error {
+	// Travis has no env file mechanism, so write a script later steps can source
+	exportFile := filepath.Join(crg.workspace, "drift.env.sh")
+	file, err := os.Create(exportFile)
+	if err != nil {
+		return WrapReportError(ErrorTypeFileOperation, "failed to create export file", err)
+	}
+	defer file.Close()
+
+	keys := make([]string, 0, len(envVars))
+	for key := range envVars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if _, err := file.WriteString(fmt.Sprintf("export %s=%s\n", key, shellQuote(envVars[key]))); err != nil {
+			return WrapReportError(ErrorTypeFileOperation, "failed to write to export file", err)
+		}
+	}
+
+	return nil
+}
+
+// shellQuote single-quotes value for a POSIX shell, so nothing in it is
+// expanded when the export file is sourced
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
 func (crg *CIReportGenerator) setGenericEnv(envVars map[string]string) error {
 	// For generic platforms, just set environment variables
 	for key, value := range envVars {
@@ -951,6 +1092,13 @@ func (crg *CIReportGenerator) writeJUnitXML(results map[string]interfaces.DriftR
 }
 
 func (crg *CIReportGenerator) writeSummaryFile(results map[string]*interfaces.DriftResult, filePath string) error {
+	if confirmation, quiet := quietCleanReport(crg.config, results); quiet {
+		if err := os.WriteFile(filePath, []byte(confirmation), 0644); err != nil {
+			return WrapReportError(ErrorTypeFileOperation, "failed to write summary file", err)
+		}
+		return nil
+	}
+
 	summary := crg.buildCISummary(results)
 	content := fmt.Sprintf(`Drift Detection Summary
 ======================
@@ -1021,7 +1169,7 @@ func (crg *CIReportGenerator) writeGitHubActionsArtifacts(results map[string]int
 		return nil, WrapReportError(ErrorTypeFileOperation, "failed to stat GitHub summary", err)
 	}
 	return []Artifact{{
-		Path: summaryFile,
+		Path: crg.artifactPath(summaryFile),
 		Type: "github-summary-md",
 		Size: info.Size(),
 	}}, nil
@@ -1048,11 +1196,32 @@ func (crg *CIReportGenerator) writeGitLabArtifacts(results map[string]interfaces
 	if err != nil {
 		return nil, WrapReportError(ErrorTypeFileOperation, "failed to stat GitLab note", err)
 	}
-	return []Artifact{{
-		Path: noteFile,
+	artifacts := []Artifact{{
+		Path: crg.artifactPath(noteFile),
 		Type: "gitlab-note-md",
 		Size: info.Size(),
-	}}, nil
+	}}
+
+	// Write Code Quality report for merge request widgets
+	codeQualityFile := filepath.Join(artifactDir, gitLabCodeQualityFile)
+	codeQuality, err := GenerateGitLabCodeQuality(pointerResults)
+	if err != nil {
+		return nil, err
+	}
+	err = os.WriteFile(codeQualityFile, codeQuality, 0644)
+	if err != nil {
+		return nil, WrapReportError(ErrorTypeFileOperation, "failed to write GitLab Code Quality report", err)
+	}
+	info, err = os.Stat(codeQualityFile)
+	if err != nil {
+		return nil, WrapReportError(ErrorTypeFileOperation, "failed to stat GitLab Code Quality report", err)
+	}
+	artifacts = append(artifacts, Artifact{
+		Path: crg.artifactPath(codeQualityFile),
+		Type: "gitlab-codequality-json",
+		Size: info.Size(),
+	})
+	return artifacts, nil
 }
 
 func (crg *CIReportGenerator) writeJenkinsArtifacts(results map[string]interfaces.DriftResult, artifactDir string) ([]Artifact, error) {
@@ -1077,7 +1246,7 @@ func (crg *CIReportGenerator) writeJenkinsArtifacts(results map[string]interface
 		return nil, WrapReportError(ErrorTypeFileOperation, "failed to stat Jenkins HTML report", err)
 	}
 	return []Artifact{{
-		Path: htmlFile,
+		Path: crg.artifactPath(htmlFile),
 		Type: "jenkins-html-report",
 		Size: info.Size(),
 	}}, nil
@@ -1086,31 +1255,146 @@ func (crg *CIReportGenerator) writeJenkinsArtifacts(results map[string]interface
 // Summary generation helpers
 
 func (crg *CIReportGenerator) generateMarkdownSummary(results map[string]*interfaces.DriftResult) (string, error) {
+	if confirmation, quiet := quietCleanReport(crg.config, results); quiet {
+		return confirmation, nil
+	}
+
 	summary := crg.buildCISummary(results)
 
+	t := func(key string) string { return localize(crg.config, key) }
+
 	var md strings.Builder
-	md.WriteString(fmt.Sprintf("# Terraform Drift Detection Summary\n\n## Summary\n- **Total Resources**: %d\n- **Resources with Drift**: %d\n- **Total Differences**: %d\n\n## Severity Breakdown\n- 🔴 **Critical**: %d\n- 🟠 **High**: %d\n- 🟡 **Medium**: %d\n- 🔵 **Low**: %d\n",
-		summary.TotalResources,
-		summary.ResourcesWithDrift,
-		summary.TotalDifferences,
-		summary.SeverityCounts["critical"],
-		summary.SeverityCounts["high"],
-		summary.SeverityCounts["medium"],
-		summary.SeverityCounts["low"],
+	md.WriteString(fmt.Sprintf("# %s\n\n## %s\n- **%s**: %d\n- **%s**: %d\n- **%s**: %d\n\n## %s\n- 🔴 **%s**: %d\n- 🟠 **%s**: %d\n- 🟡 **%s**: %d\n- 🔵 **%s**: %d\n",
+		t(MsgReportTitle),
+		t(MsgSummary),
+		t(MsgTotalResources), summary.TotalResources,
+		t(MsgResourcesWithDrift), summary.ResourcesWithDrift,
+		t(MsgTotalDifferences), summary.TotalDifferences,
+		t(MsgSeverityBreakdown),
+		t(MsgCritical), summary.SeverityCounts["critical"],
+		t(MsgHigh), summary.SeverityCounts["high"],
+		t(MsgMedium), summary.SeverityCounts["medium"],
+		t(MsgLow), summary.SeverityCounts["low"],
 	))
 
 	if summary.ResourcesWithDrift == 0 {
-		md.WriteString("\n## ✅ Result\n\nNo drift detected! All resources are in sync.\n")
+		md.WriteString(fmt.Sprintf("\n## ✅ %s\n\n%s %s\n", t(MsgResult), t(MsgNoDriftDetected), t(MsgAllInSync)))
 	} else {
-		md.WriteString("\n## ⚠️ Action Required\n\nDrift detected in infrastructure. Review the detailed report and consider running `terraform plan` and `terraform apply`.\n")
+		md.WriteString(fmt.Sprintf("\n## ⚠️ %s\n\n%s\n", t(MsgActionRequired), t(MsgActionAdvice)))
+		md.WriteString(crg.markdownDriftedResources(results))
+	}
+
+	if crg.config != nil && crg.config.GroupByModule {
+		md.WriteString(markdownModuleGroups(GroupByModule(results)))
+	}
+
+	age, err := loadDriftAge(crg.config, results)
+	if err != nil {
+		return "", err
+	}
+	if age != nil {
+		md.WriteString(age.Markdown())
 	}
 
 	return md.String(), nil
 }
 
+// markdownDriftedResources lists drifted resources under headers named by
+// display name, keeping the resource ID alongside for targeting
+func (crg *CIReportGenerator) markdownDriftedResources(results map[string]*interfaces.DriftResult) string {
+	keys := make([]string, 0, len(results))
+	for key, result := range results {
+		if result != nil && result.IsDrifted {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var md strings.Builder
+	md.WriteString("\n## Drifted Resources\n")
+	for _, key := range keys {
+		result := results[key]
+		md.WriteString(fmt.Sprintf("\n### %s\n- **ID**: `%s`\n", resourceLabel(key, result), key))
+		if result.Owner != "" {
+			md.WriteString(fmt.Sprintf("- **Owner**: %s\n", result.Owner))
+		}
+		md.WriteString(fmt.Sprintf("- **Severity**: %s\n- **Differences**: %d\n",
+			strings.ToUpper(string(result.Severity)), len(result.DriftDetails)))
+		if result.ResourceType != "" {
+			md.WriteString(fmt.Sprintf("- **Type**: %s\n", resourceTypeLabel(crg.config, result.ResourceType)))
+		}
+	}
+	return md.String()
+}
+
+// WriteGitHubAnnotations writes one GitHub Actions workflow command per
+// drifted resource to w, so drift shows up as annotations on the run:
+// ::error for critical and high severity, ::warning for medium and low.
+// Resources are written in resource ID order.
+func (crg *CIReportGenerator) WriteGitHubAnnotations(results map[string]*interfaces.DriftResult, w io.Writer) error {
+	if results == nil {
+		return NewReportError(ErrorTypeInvalidInput, "results cannot be nil")
+	}
+	if w == nil {
+		return NewReportError(ErrorTypeInvalidInput, "writer cannot be nil")
+	}
+
+	keys := make([]string, 0, len(results))
+	for key, result := range results {
+		if result != nil && result.IsDrifted {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		result := results[key]
+		command := "warning"
+		if result.Severity == interfaces.SeverityCritical || result.Severity == interfaces.SeverityHigh {
+			command = "error"
+		}
+
+		attributes := make([]string, 0, len(result.DriftDetails))
+		for _, detail := range result.DriftDetails {
+			if detail != nil {
+				attributes = append(attributes, detail.Attribute)
+			}
+		}
+		message := fmt.Sprintf("%s drifted (%s)", key, strings.ToUpper(string(result.Severity)))
+		if len(attributes) > 0 {
+			message += ": " + strings.Join(attributes, ", ")
+		}
+
+		if _, err := fmt.Fprintf(w, "::%s title=%s::%s\n", command,
+			escapeAnnotationProperty("Drift in "+key), escapeAnnotationData(message)); err != nil {
+			return WrapError(ErrorTypeGenerationFailed, "failed to write GitHub annotation", err)
+		}
+	}
+	return nil
+}
+
+// escapeAnnotationData escapes a workflow command message
+func escapeAnnotationData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeAnnotationProperty escapes a workflow command property value, which
+// additionally cannot contain unescaped ':' or ','
+func escapeAnnotationProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
 func (crg *CIReportGenerator) generateHTMLSummary(results map[string]*interfaces.DriftResult) (string, error) {
 	summary := crg.buildCISummary(results)
 
+	var byType strings.Builder
+	if groups := driftedByType(crg.config, results); len(groups) > 0 {
+		byType.WriteString("\n        <h3>Drift by Resource Type</h3>\n")
+		for _, group := range groups {
+			byType.WriteString(fmt.Sprintf("        <p>%s: %d</p>\n", html.EscapeString(group.Label), group.Count))
+		}
+	}
+
 	return fmt.Sprintf(`<!DOCTYPE html>
 <html>
 <head>
@@ -1137,7 +1421,7 @@ func (crg *CIReportGenerator) generateHTMLSummary(results map[string]*interfaces
         <p class="critical">Critical: %d</p>
         <p class="high">High: %d</p>
         <p class="medium">Medium: %d</p>
-        <p class="low">Low: %d</p>
+        <p class="low">Low: %d</p>%s
     </div>
     
     <p><em>Generated: %s</em></p>
@@ -1150,6 +1434,7 @@ func (crg *CIReportGenerator) generateHTMLSummary(results map[string]*interfaces
 		summary.SeverityCounts["high"],
 		summary.SeverityCounts["medium"],
 		summary.SeverityCounts["low"],
+		byType.String(),
 		time.Now().Format(time.RFC3339),
 	), nil
 }
@@ -1,10 +1,12 @@
 package report
 
 import (
+	"compress/gzip"
 	"encoding/json"
 	"encoding/xml"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -92,6 +94,7 @@ func TestCICDPlatform_String(t *testing.T) {
 		{PlatformJenkins, "jenkins"},
 		{PlatformAzureDevOps, "azure-devops"},
 		{PlatformCircleCI, "circleci"},
+		{PlatformTravis, "travis"},
 		{PlatformGeneric, "generic"},
 		{CICDPlatform("unknown-platform"), "unknown"},
 	}
@@ -109,7 +112,7 @@ func TestDetectCICDPlatform(t *testing.T) {
 	originalEnv := make(map[string]string)
 	envVars := []string{
 		"GITHUB_ACTIONS", "GITLAB_CI", "JENKINS_URL", "JENKINS_HOME",
-		"AZURE_HTTP_USER_AGENT", "TF_BUILD", "CIRCLECI", "CI",
+		"AZURE_HTTP_USER_AGENT", "TF_BUILD", "CIRCLECI", "TRAVIS", "CI",
 	}
 
 	for _, envVar := range envVars {
@@ -168,6 +171,11 @@ func TestDetectCICDPlatform(t *testing.T) {
 			envVars:  map[string]string{"CIRCLECI": "true"},
 			expected: PlatformCircleCI,
 		},
+		{
+			name:     "Travis CI",
+			envVars:  map[string]string{"TRAVIS": "true"},
+			expected: PlatformTravis,
+		},
 		{
 			name:     "Generic CI",
 			envVars:  map[string]string{"CI": "true"},
@@ -251,6 +259,41 @@ func TestCIReportGenerator_WriteArtifacts(t *testing.T) {
 	assert.True(t, artifactTypes["summary"])
 }
 
+func TestCIReportGenerator_WriteArtifacts_Concurrency(t *testing.T) {
+	for _, limit := range []int{0, 1, 2} {
+		generator := NewCIReportGeneratorWithConfig(NewReportConfig(), PlatformGeneric, filepath.Join(t.TempDir(), "artifacts"))
+		generator.ArtifactConcurrency = limit
+
+		artifacts, err := generator.WriteArtifacts(createTestReportData())
+		require.NoError(t, err)
+
+		var types []string
+		for _, artifact := range artifacts {
+			types = append(types, artifact.Type)
+			_, err := os.Stat(artifact.Path)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, []string{"json", "junit-xml", "summary"}, types[:3], "limit %d", limit)
+	}
+}
+
+func TestCIReportGenerator_WriteArtifacts_RelativePaths(t *testing.T) {
+	base := t.TempDir()
+	config := NewReportConfig().WithRelativePaths(base)
+	generator := NewCIReportGeneratorWithConfig(config, PlatformGitHubActions, filepath.Join(base, "artifacts"))
+
+	artifacts, err := generator.WriteArtifacts(createTestReportData())
+	require.NoError(t, err)
+	require.NotEmpty(t, artifacts)
+
+	for _, artifact := range artifacts {
+		assert.False(t, filepath.IsAbs(artifact.Path), "expected relative path, got %s", artifact.Path)
+		assert.True(t, strings.HasPrefix(artifact.Path, "artifacts/"), artifact.Path)
+		_, err := os.Stat(filepath.Join(base, artifact.Path))
+		require.NoError(t, err)
+	}
+}
+
 func TestCIReportGenerator_WriteJSONArtifact(t *testing.T) {
 	generator := NewCIReportGenerator()
 	data := createTestReportData()
@@ -325,6 +368,27 @@ func TestCIReportGenerator_WriteSummaryArtifact(t *testing.T) {
 	assert.Contains(t, summaryText, "Total Resources")
 }
 
+func TestCIReportGenerator_WriteSummaryArtifact_QuietWhenClean(t *testing.T) {
+	config := NewReportConfig().WithQuietWhenClean(true)
+	generator := NewCIReportGeneratorWithConfig(config, PlatformGeneric, t.TempDir())
+
+	clean := map[string]*interfaces.DriftResult{
+		"aws_instance.clean": {ResourceID: "aws_instance.clean", IsDrifted: false},
+	}
+	artifact, err := generator.WriteSummaryArtifact(clean)
+	require.NoError(t, err)
+	content, err := os.ReadFile(artifact.Path)
+	require.NoError(t, err)
+	assert.Equal(t, "No drift detected! All resources are in sync. (1 resources checked)\n", string(content))
+
+	artifact, err = generator.WriteSummaryArtifact(createTestReportData())
+	require.NoError(t, err)
+	content, err = os.ReadFile(artifact.Path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# Terraform Drift Detection Summary")
+	assert.Contains(t, string(content), "Action Required")
+}
+
 func TestCIReportGenerator_SetExitCode(t *testing.T) {
 	generator := NewCIReportGenerator()
 
@@ -369,6 +433,81 @@ func TestCIReportGenerator_SetExitCode(t *testing.T) {
 	}
 }
 
+func TestCIReportGenerator_SetExitCode_FailOnAttributes(t *testing.T) {
+	config := NewReportConfig().WithFailOnAttributes([]string{"ami"})
+	generator := NewCIReportGeneratorWithConfig(config, PlatformGeneric, t.TempDir())
+
+	driftIn := func(attribute string) map[string]*interfaces.DriftResult {
+		return map[string]*interfaces.DriftResult{
+			"i-123": {
+				ResourceID:    "i-123",
+				ResourceType:  "aws_instance",
+				IsDrifted:     true,
+				DetectionTime: time.Now(),
+				Severity:      interfaces.SeverityLow,
+				DriftDetails: []*interfaces.DriftDetail{
+					{
+						Attribute:     attribute,
+						ExpectedValue: "expected",
+						ActualValue:   "actual",
+						DriftType:     "changed",
+						Severity:      interfaces.SeverityLow,
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("unlisted attribute passes", func(t *testing.T) {
+		assert.Equal(t, 0, generator.SetExitCode(driftIn("tags")))
+	})
+
+	t.Run("listed attribute fails", func(t *testing.T) {
+		assert.Equal(t, 1, generator.SetExitCode(driftIn("ami")))
+	})
+}
+
+func TestCIReportGenerator_TravisMetadata(t *testing.T) {
+	t.Setenv("TRAVIS_JOB_ID", "4242")
+	t.Setenv("TRAVIS_BUILD_NUMBER", "17")
+	t.Setenv("TRAVIS_BRANCH", "main")
+	t.Setenv("TRAVIS_COMMIT", "abc123")
+
+	generator := NewCIReportGeneratorWithConfig(NewReportConfig(), PlatformTravis, t.TempDir())
+	results := map[string]interfaces.DriftResult{}
+	for id, result := range createTestReportData() {
+		results[id] = *result
+	}
+
+	report, err := generator.GenerateCIReport(results)
+	require.NoError(t, err)
+
+	assert.Equal(t, "travis", report.Metadata.Platform)
+	assert.Equal(t, "4242", report.Metadata.JobID)
+	assert.Equal(t, "17", report.Metadata.BuildNumber)
+	assert.Equal(t, "main", report.Metadata.Branch)
+	assert.Equal(t, "abc123", report.Metadata.CommitSHA)
+}
+
+func TestCIReportGenerator_SetTravisEnv(t *testing.T) {
+	workspace := t.TempDir()
+	generator := NewCIReportGeneratorWithConfig(NewReportConfig(), PlatformTravis, workspace)
+
+	require.NoError(t, generator.SetPlatformSpecificVariables(createTestReportData()))
+
+	content, err := os.ReadFile(filepath.Join(workspace, "drift.env.sh"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `export DRIFT_TOTAL_RESOURCES='3'`)
+	assert.Contains(t, string(content), `export DRIFT_HAS_DRIFT='true'`)
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, `'plain'`, shellQuote("plain"))
+	assert.Equal(t, `''`, shellQuote(""))
+	assert.Equal(t, `'$HOME and \n stay literal'`, shellQuote(`$HOME and \n stay literal`))
+	assert.Equal(t, `'it'\''s'`, shellQuote("it's"))
+}
+
 func TestCIReportGenerator_SetEnvironmentVariables(t *testing.T) {
 	// Save original environment
 	originalVars := map[string]string{
@@ -651,3 +790,147 @@ func BenchmarkDetectCICDPlatform(b *testing.B) {
 		DetectCICDPlatform()
 	}
 }
+
+func BenchmarkCIReportGenerator_WriteArtifacts_Concurrency(b *testing.B) {
+	results := make(map[string]*interfaces.DriftResult)
+	for i := 0; i < 2000; i++ {
+		id := "aws_instance.web-" + strconv.Itoa(i)
+		results[id] = &interfaces.DriftResult{
+			ResourceID:   id,
+			ResourceType: "aws_instance",
+			IsDrifted:    true,
+			Severity:     interfaces.SeverityHigh,
+			DriftDetails: []*interfaces.DriftDetail{
+				{Attribute: "instance_type", ExpectedValue: "t3.micro", ActualValue: "t3.large", Severity: interfaces.SeverityHigh},
+			},
+		}
+	}
+
+	for _, limit := range []int{1, 0} {
+		name := "sequential"
+		if limit == 0 {
+			name = "parallel"
+		}
+		b.Run(name, func(b *testing.B) {
+			generator := NewCIReportGeneratorWithConfig(NewReportConfig(), PlatformGeneric, b.TempDir())
+			generator.ArtifactConcurrency = limit
+			for i := 0; i < b.N; i++ {
+				if _, err := generator.WriteArtifacts(results); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestCIReportGenerator_MarkdownSummary_DisplayNames(t *testing.T) {
+	results := createTestDriftResults()
+	results["aws_instance.web-server-1"].DisplayName = "frontend"
+
+	markdown, err := NewCIReportGenerator().generateMarkdownSummary(results)
+	require.NoError(t, err)
+
+	assert.Contains(t, markdown, "## Drifted Resources")
+	assert.Contains(t, markdown, "### frontend\n- **ID**: `aws_instance.web-server-1`")
+	assert.Contains(t, markdown, "### aws_instance.web-server-2\n- **ID**: `aws_instance.web-server-2`")
+	assert.NotContains(t, markdown, "aws_db_instance.database")
+}
+
+func TestCIReportGenerator_GenerateCIActions_Owner(t *testing.T) {
+	results := createTestDriftResults()
+	results["aws_instance.web-server-1"].Owner = "platform"
+
+	actions := NewCIReportGenerator().generateCIActions(results)
+	require.NotEmpty(t, actions)
+	for _, action := range actions {
+		if action.ResourceID == "aws_instance.web-server-1" {
+			assert.Equal(t, "platform", action.Owner)
+		} else {
+			assert.Empty(t, action.Owner)
+		}
+	}
+
+	markdown, err := NewCIReportGenerator().generateMarkdownSummary(results)
+	require.NoError(t, err)
+	assert.Contains(t, markdown, "- **ID**: `aws_instance.web-server-1`\n- **Owner**: platform\n")
+	assert.Contains(t, markdown, "- **ID**: `aws_instance.web-server-2`\n- **Severity**")
+}
+
+func TestCIReportGenerator_ResourceTypeLabels(t *testing.T) {
+	results := createTestDriftResults()
+	config := NewReportConfig().WithResourceTypeLabels(map[string]string{"aws_instance": "EC2 Instance"})
+	generator := NewCIReportGeneratorWithConfig(config, PlatformGeneric, t.TempDir())
+
+	markdown, err := generator.generateMarkdownSummary(results)
+	require.NoError(t, err)
+	assert.Contains(t, markdown, "- **Type**: EC2 Instance\n")
+	assert.Contains(t, markdown, "- **Type**: aws_lb\n")
+	assert.NotContains(t, markdown, "- **Type**: aws_instance")
+
+	html, err := generator.generateHTMLSummary(results)
+	require.NoError(t, err)
+	assert.Contains(t, html, "<p>EC2 Instance: 2</p>")
+	assert.Contains(t, html, "<p>aws_lb: 1</p>")
+}
+
+func TestCIReportGenerator_WriteArtifacts_Compressed(t *testing.T) {
+	dir := t.TempDir()
+	config := NewReportConfig().WithCompression(true)
+	generator := NewCIReportGeneratorWithConfig(config, PlatformGeneric, dir)
+
+	artifacts, err := generator.WriteArtifacts(createTestDriftResults())
+	require.NoError(t, err)
+	require.NotEmpty(t, artifacts)
+
+	for _, artifact := range artifacts {
+		assert.True(t, strings.HasSuffix(artifact.Path, ".gz"), artifact.Path)
+		assert.Equal(t, "gzip", artifact.ContentEncoding)
+	}
+
+	file, err := os.Open(filepath.Join(dir, "drift-report.ci.json.gz"))
+	require.NoError(t, err)
+	defer file.Close()
+	zr, err := gzip.NewReader(file)
+	require.NoError(t, err)
+	var report map[string]interface{}
+	require.NoError(t, json.NewDecoder(zr).Decode(&report))
+	assert.Contains(t, report, "summary")
+}
+
+func TestCIReportGenerator_WriteGitHubAnnotations(t *testing.T) {
+	results := createTestReportData()
+	results["aws_instance.tags"] = &interfaces.DriftResult{
+		ResourceID: "aws_instance.tags",
+		IsDrifted:  true,
+		Severity:   interfaces.SeverityMedium,
+		DriftDetails: []*interfaces.DriftDetail{
+			{Attribute: "tags", Severity: interfaces.SeverityMedium},
+			{Attribute: "monitoring", Severity: interfaces.SeverityLow},
+		},
+	}
+	results["aws_instance.az"] = &interfaces.DriftResult{
+		ResourceID:   "aws_instance.az",
+		IsDrifted:    true,
+		Severity:     interfaces.SeverityLow,
+		DriftDetails: []*interfaces.DriftDetail{{Attribute: "availability_zone", Severity: interfaces.SeverityLow}},
+	}
+
+	var out strings.Builder
+	require.NoError(t, NewCIReportGenerator().WriteGitHubAnnotations(results, &out))
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	assert.Equal(t, []string{
+		"::warning title=Drift in aws_instance.az::aws_instance.az drifted (LOW): availability_zone",
+		"::warning title=Drift in aws_instance.tags::aws_instance.tags drifted (MEDIUM): tags, monitoring",
+		"::error title=Drift in aws_instance.test::aws_instance.test drifted (HIGH): instance_type",
+		"::error title=Drift in aws_s3_bucket.data::aws_s3_bucket.data drifted (CRITICAL): public_access_block",
+	}, lines)
+	assert.NotContains(t, out.String(), "aws_instance.clean")
+
+	assert.Error(t, NewCIReportGenerator().WriteGitHubAnnotations(nil, &out))
+}
+
+func TestEscapeAnnotation(t *testing.T) {
+	assert.Equal(t, "100%25 drift%0Anext line", escapeAnnotationData("100% drift\nnext line"))
+	assert.Equal(t, `module.a%3Aaws_instance.web["x%2Cy"]`, escapeAnnotationProperty(`module.a:aws_instance.web["x,y"]`))
+}
@@ -0,0 +1,97 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// gitLabCodeQualityFile is the artifact name GitLab CI jobs conventionally
+// declare under artifacts:reports:codequality
+const gitLabCodeQualityFile = "gl-code-quality-report.json"
+
+// GitLabCodeQualityIssue is one entry of a GitLab Code Quality report
+type GitLabCodeQualityIssue struct {
+	Description string                    `json:"description"`
+	CheckName   string                    `json:"check_name"`
+	Fingerprint string                    `json:"fingerprint"`
+	Severity    string                    `json:"severity"`
+	Location    GitLabCodeQualityLocation `json:"location"`
+}
+
+// GitLabCodeQualityLocation points a Code Quality issue at a file and line
+type GitLabCodeQualityLocation struct {
+	Path  string                 `json:"path"`
+	Lines GitLabCodeQualityLines `json:"lines"`
+}
+
+// GitLabCodeQualityLines is the line range of a Code Quality issue
+type GitLabCodeQualityLines struct {
+	Begin int `json:"begin"`
+}
+
+// gitLabCodeQualitySeverity maps a drift severity to a Code Quality severity
+func gitLabCodeQualitySeverity(severity interfaces.SeverityLevel) string {
+	switch severity {
+	case interfaces.SeverityCritical:
+		return "critical"
+	case interfaces.SeverityHigh:
+		return "major"
+	case interfaces.SeverityMedium:
+		return "minor"
+	default:
+		return "info"
+	}
+}
+
+// GenerateGitLabCodeQuality builds a GitLab Code Quality report with one
+// issue per drifted attribute. Source positions are not tracked, so each
+// issue is located at line 1 of a path named after the resource address.
+// Issues are ordered by resource key and attribute.
+func GenerateGitLabCodeQuality(results map[string]*interfaces.DriftResult) ([]byte, error) {
+	if results == nil {
+		return nil, NewReportError(ErrorTypeInvalidInput, "results cannot be nil")
+	}
+
+	keys := make([]string, 0, len(results))
+	for key := range results {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	issues := []GitLabCodeQualityIssue{}
+	for _, key := range keys {
+		result := results[key]
+		if result == nil || !result.IsDrifted {
+			continue
+		}
+		resourceID := result.ResourceID
+		if resourceID == "" {
+			resourceID = key
+		}
+		for _, detail := range orderedDetails(result.DriftDetails, nil) {
+			if detail == nil {
+				continue
+			}
+			issues = append(issues, GitLabCodeQualityIssue{
+				Description: fmt.Sprintf("%s drifted on %s: expected %v, actual %v",
+					detail.Attribute, resourceID, detail.ExpectedValue, detail.ActualValue),
+				CheckName:   "terraform-drift/" + detail.Attribute,
+				Fingerprint: DriftFingerprint(resourceID, detail),
+				Severity:    gitLabCodeQualitySeverity(detail.Severity),
+				Location: GitLabCodeQualityLocation{
+					Path:  resourceID,
+					Lines: GitLabCodeQualityLines{Begin: 1},
+				},
+			})
+		}
+	}
+
+	data, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return nil, WrapError(ErrorTypeMarshaling, "failed to marshal GitLab Code Quality report", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,87 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func TestGenerateGitLabCodeQuality(t *testing.T) {
+	results := map[string]*interfaces.DriftResult{
+		"aws_instance.web": {
+			ResourceID: "aws_instance.web",
+			IsDrifted:  true,
+			Severity:   interfaces.SeverityCritical,
+			DriftDetails: []*interfaces.DriftDetail{
+				{Attribute: "tags", ExpectedValue: "a", ActualValue: "b", Severity: interfaces.SeverityMedium},
+				{Attribute: "instance_type", ExpectedValue: "t3.micro", ActualValue: "t3.large", Severity: interfaces.SeverityCritical},
+			},
+		},
+		"aws_instance.api": {
+			ResourceID: "aws_instance.api",
+			IsDrifted:  true,
+			Severity:   interfaces.SeverityHigh,
+			DriftDetails: []*interfaces.DriftDetail{
+				{Attribute: "security_groups", ExpectedValue: "sg-1", ActualValue: "sg-2", Severity: interfaces.SeverityHigh},
+				{Attribute: "monitoring", ExpectedValue: true, ActualValue: false, Severity: interfaces.SeverityLow},
+			},
+		},
+		"aws_instance.clean": {ResourceID: "aws_instance.clean"},
+	}
+
+	data, err := GenerateGitLabCodeQuality(results)
+	require.NoError(t, err)
+
+	var issues []GitLabCodeQualityIssue
+	require.NoError(t, json.Unmarshal(data, &issues))
+	require.Len(t, issues, 4)
+
+	severities := make(map[string]string)
+	fingerprints := make(map[string]bool)
+	for _, issue := range issues {
+		severities[issue.Location.Path+"/"+issue.CheckName] = issue.Severity
+		assert.False(t, fingerprints[issue.Fingerprint], "duplicate fingerprint %s", issue.Fingerprint)
+		fingerprints[issue.Fingerprint] = true
+		assert.Equal(t, 1, issue.Location.Lines.Begin)
+		assert.NotEmpty(t, issue.Description)
+	}
+	assert.Equal(t, map[string]string{
+		"aws_instance.web/terraform-drift/instance_type":   "critical",
+		"aws_instance.api/terraform-drift/security_groups": "major",
+		"aws_instance.web/terraform-drift/tags":            "minor",
+		"aws_instance.api/terraform-drift/monitoring":      "info",
+	}, severities)
+	assert.Equal(t, "aws_instance.api", issues[0].Location.Path, "issues should be ordered by resource key")
+
+	_, err = GenerateGitLabCodeQuality(nil)
+	assert.Error(t, err)
+}
+
+func TestCIReportGenerator_GitLabCodeQualityArtifact(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "artifacts")
+	generator := NewCIReportGeneratorWithConfig(NewReportConfig(), PlatformGitLab, dir)
+
+	artifacts, err := generator.WriteArtifacts(createTestReportData())
+	require.NoError(t, err)
+
+	var found bool
+	for _, artifact := range artifacts {
+		if artifact.Type == "gitlab-codequality-json" {
+			found = true
+			assert.Equal(t, gitLabCodeQualityFile, filepath.Base(artifact.Path))
+		}
+	}
+	require.True(t, found, "expected a GitLab Code Quality artifact")
+
+	data, err := os.ReadFile(filepath.Join(dir, gitLabCodeQualityFile))
+	require.NoError(t, err)
+	var issues []GitLabCodeQualityIssue
+	require.NoError(t, json.Unmarshal(data, &issues))
+	assert.NotEmpty(t, issues)
+}
@@ -0,0 +1,385 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// CommentClientConfig controls retries and timeouts for the pull and merge
+// request comment APIs
+type CommentClientConfig struct {
+	// MaxAttempts is the total number of tries per request, including the
+	// first; values below 1 mean a single try
+	MaxAttempts int
+	// Timeout bounds a whole request, including its retries
+	Timeout time.Duration
+	// BaseDelay is the backoff before the first retry of a 5xx response; it
+	// doubles on every further retry up to MaxDelay
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// DefaultCommentClientConfig returns retry settings suited to the GitHub and
+// GitLab APIs
+func DefaultCommentClientConfig() CommentClientConfig {
+	return CommentClientConfig{
+		MaxAttempts: 4,
+		Timeout:     30 * time.Second,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// NewCommentClient returns an HTTP client that retries rate limited (429)
+// requests after the Retry-After delay, and transient 5xx responses to
+// idempotent requests with exponential backoff. A 5xx response to a POST or
+// PATCH is not retried, since the server may already have created the comment.
+func NewCommentClient(config CommentClientConfig) *http.Client {
+	if config.MaxAttempts < 1 {
+		config.MaxAttempts = 1
+	}
+	return &http.Client{
+		Timeout: config.Timeout,
+		Transport: &retryTransport{
+			base:   http.DefaultTransport,
+			config: config,
+			sleep:  sleepContext,
+		},
+	}
+}
+
+// retryTransport retries requests according to a CommentClientConfig
+type retryTransport struct {
+	base   http.RoundTripper
+	config CommentClientConfig
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+func (rt *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 && req.Body != nil {
+			if req.GetBody == nil {
+				return nil, NewReportError(ErrorTypeGenerationFailed, "cannot retry request with a non-replayable body")
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, WrapError(ErrorTypeGenerationFailed, "failed to replay request body", err)
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := rt.base.RoundTrip(attemptReq)
+		if err != nil || attempt >= rt.config.MaxAttempts {
+			return resp, err
+		}
+
+		delay, retry := rt.retryDelay(req.Method, resp, attempt)
+		if !retry {
+			return resp, nil
+		}
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+
+		if err := rt.sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryDelay reports whether resp to a request with method should be retried
+// and how long to wait
+func (rt *retryTransport) retryDelay(method string, resp *http.Response, attempt int) (time.Duration, bool) {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return delay, true
+		}
+		return rt.backoff(attempt), true
+	case !isIdempotent(method):
+		return 0, false
+	case resp.StatusCode == http.StatusBadGateway,
+		resp.StatusCode == http.StatusServiceUnavailable,
+		resp.StatusCode == http.StatusGatewayTimeout,
+		resp.StatusCode == http.StatusInternalServerError:
+		return rt.backoff(attempt), true
+	default:
+		return 0, false
+	}
+}
+
+// isIdempotent reports whether repeating a request with method has the same
+// effect as sending it once
+func isIdempotent(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the exponential delay before retry number attempt
+func (rt *retryTransport) backoff(attempt int) time.Duration {
+	delay := rt.config.BaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if rt.config.MaxDelay > 0 && delay >= rt.config.MaxDelay {
+			return rt.config.MaxDelay
+		}
+	}
+	return delay
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if delay := at.Sub(now); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Comment is the subset of a GitHub issue comment or GitLab note used to find
+// an existing drift comment
+type Comment struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+}
+
+// FindStickyComment returns the first comment on the paginated comment list at
+// endpoint that contains marker, or nil if no comment has it. header is added
+// to every request, e.g. for auth. Pages after the match are not fetched.
+func FindStickyComment(ctx context.Context, client *http.Client, endpoint string, header http.Header, marker string) (*Comment, error) {
+	var found *Comment
+	err := eachCommentPage(ctx, client, endpoint, header, func(page []Comment) bool {
+		for i := range page {
+			if strings.Contains(page[i].Body, marker) {
+				found = &page[i]
+				return false
+			}
+		}
+		return true
+	})
+	return found, err
+}
+
+// eachCommentPage follows Link rel="next" headers (GitHub and GitLab) or
+// GitLab's X-Next-Page header from endpoint, adding header to every request,
+// and calls visit with every page of comments until visit returns
+// false or there are no more pages
+func eachCommentPage(ctx context.Context, client *http.Client, endpoint string, header http.Header, visit func([]Comment) bool) error {
+	if client == nil {
+		client = NewCommentClient(DefaultCommentClientConfig())
+	}
+
+	for next := endpoint; next != ""; {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, next, nil)
+		if err != nil {
+			return WrapError(ErrorTypeConfiguration, "failed to build comment list request", err)
+		}
+		for key, values := range header {
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return WrapError(ErrorTypeGenerationFailed, "failed to list comments", err)
+		}
+
+		var page []Comment
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+			resp.Body.Close()
+			return NewReportErrorf(ErrorTypeGenerationFailed, "comment API returned %s: %s",
+				resp.Status, strings.TrimSpace(string(body)))
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return WrapError(ErrorTypeMarshaling, "failed to decode comment list", err)
+		}
+
+		if !visit(page) {
+			return nil
+		}
+		next = nextPageURL(resp, req.URL)
+	}
+	return nil
+}
+
+// nextPageURL returns the URL of the page after resp, or "" on the last page
+func nextPageURL(resp *http.Response, current *url.URL) string {
+	for _, link := range strings.Split(resp.Header.Get("Link"), ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 {
+			continue
+		}
+		for _, param := range parts[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				target := strings.Trim(strings.TrimSpace(parts[0]), "<>")
+				if resolved, err := current.Parse(target); err == nil {
+					return resolved.String()
+				}
+			}
+		}
+	}
+
+	if page := resp.Header.Get("X-Next-Page"); page != "" {
+		next := *current
+		query := next.Query()
+		query.Set("page", page)
+		next.RawQuery = query.Encode()
+		return next.String()
+	}
+	return ""
+}
+
+// PullRequestCommentMarker is embedded in the drift comment so later runs
+// update it instead of adding another
+const PullRequestCommentMarker = "<!-- firefly-drift-report -->"
+
+// commentTarget locates the comments of the pull or merge request a CI run
+// belongs to
+type commentTarget struct {
+	// comments lists the request's comments and creates new ones
+	comments string
+	// item returns the endpoint that edits comment id
+	item func(id int64) string
+	// updateMethod edits a comment at item
+	updateMethod string
+	header       http.Header
+}
+
+// commentTarget reads the pull or merge request of the current run from the
+// platform's environment
+func (crg *CIReportGenerator) commentTarget() (*commentTarget, error) {
+	switch crg.Platform {
+	case PlatformGitHubActions:
+		repo, token := os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_TOKEN")
+		number := strings.TrimSuffix(strings.TrimPrefix(os.Getenv("GITHUB_REF"), "refs/pull/"), "/merge")
+		if repo == "" || token == "" || number == "" || strings.Contains(number, "/") {
+			return nil, NewReportError(ErrorTypeConfiguration, "pull request comments need GITHUB_REPOSITORY, GITHUB_TOKEN and a refs/pull/<n>/merge GITHUB_REF")
+		}
+		api := os.Getenv("GITHUB_API_URL")
+		if api == "" {
+			api = "https://api.github.com"
+		}
+		return &commentTarget{
+			comments:     fmt.Sprintf("%s/repos/%s/issues/%s/comments", api, repo, number),
+			item:         func(id int64) string { return fmt.Sprintf("%s/repos/%s/issues/comments/%d", api, repo, id) },
+			updateMethod: http.MethodPatch,
+			header: http.Header{
+				"Authorization": []string{"Bearer " + token},
+				"Accept":        []string{"application/vnd.github+json"},
+			},
+		}, nil
+	case PlatformGitLab:
+		api, project, iid, token := os.Getenv("CI_API_V4_URL"), os.Getenv("CI_PROJECT_ID"), os.Getenv("CI_MERGE_REQUEST_IID"), os.Getenv("GITLAB_TOKEN")
+		if api == "" || project == "" || iid == "" || token == "" {
+			return nil, NewReportError(ErrorTypeConfiguration, "merge request comments need CI_API_V4_URL, CI_PROJECT_ID, CI_MERGE_REQUEST_IID and GITLAB_TOKEN")
+		}
+		notes := fmt.Sprintf("%s/projects/%s/merge_requests/%s/notes", api, url.PathEscape(project), iid)
+		return &commentTarget{
+			comments:     notes,
+			item:         func(id int64) string { return fmt.Sprintf("%s/%d", notes, id) },
+			updateMethod: http.MethodPut,
+			header:       http.Header{"PRIVATE-TOKEN": []string{token}},
+		}, nil
+	default:
+		return nil, NewReportErrorf(ErrorTypeConfiguration, "pull request comments are not supported on %s", crg.Platform)
+	}
+}
+
+// PostPullRequestComment posts the markdown drift summary to the pull request
+// (GitHub Actions) or merge request (GitLab) of the current run, editing the
+// comment left by an earlier run when there is one. A nil client uses
+// NewCommentClient with DefaultCommentClientConfig.
+func (crg *CIReportGenerator) PostPullRequestComment(ctx context.Context, client *http.Client, results map[string]*interfaces.DriftResult) error {
+	target, err := crg.commentTarget()
+	if err != nil {
+		return err
+	}
+	if client == nil {
+		client = NewCommentClient(DefaultCommentClientConfig())
+	}
+
+	summary, err := crg.generateMarkdownSummary(results)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(map[string]string{"body": PullRequestCommentMarker + "\n" + summary})
+	if err != nil {
+		return WrapError(ErrorTypeMarshaling, "failed to marshal comment", err)
+	}
+
+	existing, err := FindStickyComment(ctx, client, target.comments+"?per_page=100", target.header, PullRequestCommentMarker)
+	if err != nil {
+		return err
+	}
+	method, endpoint := http.MethodPost, target.comments
+	if existing != nil {
+		method, endpoint = target.updateMethod, target.item(existing.ID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return WrapError(ErrorTypeConfiguration, "failed to build comment request", err)
+	}
+	for key, values := range target.header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return WrapError(ErrorTypeGenerationFailed, "failed to post comment", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return NewReportErrorf(ErrorTypeGenerationFailed, "comment API returned %s: %s",
+			resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
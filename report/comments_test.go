@@ -0,0 +1,218 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordSleeps replaces the client's sleep with one that records delays
+func recordSleeps(client *http.Client) *[]time.Duration {
+	var delays []time.Duration
+	client.Transport.(*retryTransport).sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	return &delays
+}
+
+func TestCommentClient_RetriesRateLimit(t *testing.T) {
+	var calls int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "7")
+			http.Error(w, "rate limited", http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewCommentClient(DefaultCommentClientConfig())
+	delays := recordSleeps(client)
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"body":"drift"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Equal(t, []time.Duration{7 * time.Second}, *delays)
+	assert.Equal(t, []string{`{"body":"drift"}`, `{"body":"drift"}`}, bodies, "body should be replayed on retry")
+}
+
+func TestCommentClient_ServerErrorBackoff(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	config := CommentClientConfig{MaxAttempts: 3, Timeout: time.Second, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	client := NewCommentClient(config)
+	delays := recordSleeps(client)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode, "last response is returned once attempts run out")
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, *delays)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	delay, ok := parseRetryAfter("3", now)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, delay)
+
+	delay, ok = parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, 90*time.Second, delay)
+
+	_, ok = parseRetryAfter("soon", now)
+	assert.False(t, ok)
+}
+
+func TestFindStickyComment_Pagination(t *testing.T) {
+	pages := map[string][]Comment{
+		"1": {{ID: 1, Body: "looks good"}, {ID: 2, Body: "ship it"}},
+		"2": {{ID: 3, Body: "unrelated"}, {ID: 4, Body: "<!-- drift-report -->\n# Drift"}},
+		"3": {{ID: 5, Body: "after"}},
+	}
+	var requested []string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		requested = append(requested, page)
+		assert.Equal(t, "token abc", r.Header.Get("Authorization"))
+		if page != "3" {
+			next := map[string]string{"1": "2", "2": "3"}[page]
+			w.Header().Set("Link", fmt.Sprintf(`<%s/comments?page=%s>; rel="next", <%s/comments?page=3>; rel="last"`, server.URL, next, server.URL))
+		}
+		json.NewEncoder(w).Encode(pages[page])
+	}))
+	defer server.Close()
+
+	header := http.Header{"Authorization": []string{"token abc"}}
+	comment, err := FindStickyComment(context.Background(), server.Client(), server.URL+"/comments", header, "<!-- drift-report -->")
+	require.NoError(t, err)
+	require.NotNil(t, comment)
+	assert.Equal(t, int64(4), comment.ID)
+	assert.Equal(t, []string{"1", "2"}, requested, "pages after the match should not be fetched")
+
+	missing, err := FindStickyComment(context.Background(), server.Client(), server.URL+"/comments", header, "no such marker")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestFindStickyComment_GitLabNextPageHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("X-Next-Page", "2")
+			json.NewEncoder(w).Encode([]Comment{{ID: 1, Body: "first"}})
+			return
+		}
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"), "existing query parameters should be kept")
+		json.NewEncoder(w).Encode([]Comment{{ID: 2, Body: "<!-- drift-report -->"}})
+	}))
+	defer server.Close()
+
+	comment, err := FindStickyComment(context.Background(), nil, server.URL+"/notes?per_page=100", nil, "<!-- drift-report -->")
+	require.NoError(t, err)
+	require.NotNil(t, comment)
+	assert.Equal(t, int64(2), comment.ID)
+}
+
+func TestCommentClient_DoesNotRetryPostOnServerError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewCommentClient(DefaultCommentClientConfig())
+	delays := recordSleeps(client)
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"body":"drift"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "a POST that may have created a comment must not be repeated")
+	assert.Empty(t, *delays)
+}
+
+func TestPostPullRequestComment_GitHubCreatesThenUpdates(t *testing.T) {
+	var mu sync.Mutex
+	var existing []Comment
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		assert.Equal(t, "Bearer gh-token", r.Header.Get("Authorization"))
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(existing)
+		case http.MethodPost, http.MethodPatch:
+			var body struct{ Body string }
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.True(t, strings.HasPrefix(body.Body, PullRequestCommentMarker))
+			if r.Method == http.MethodPost {
+				existing = append(existing, Comment{ID: 42, Body: body.Body})
+				w.WriteHeader(http.StatusCreated)
+			}
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("GITHUB_API_URL", server.URL)
+	t.Setenv("GITHUB_REPOSITORY", "acme/infra")
+	t.Setenv("GITHUB_REF", "refs/pull/7/merge")
+	t.Setenv("GITHUB_TOKEN", "gh-token")
+
+	crg := NewCIReportGeneratorWithConfig(NewReportConfig(), PlatformGitHubActions, t.TempDir())
+	results := createTestDriftResults()
+	require.NoError(t, crg.PostPullRequestComment(context.Background(), server.Client(), results))
+	require.NoError(t, crg.PostPullRequestComment(context.Background(), server.Client(), results))
+
+	assert.Equal(t, []string{
+		"GET /repos/acme/infra/issues/7/comments",
+		"POST /repos/acme/infra/issues/7/comments",
+		"GET /repos/acme/infra/issues/7/comments",
+		"PATCH /repos/acme/infra/issues/comments/42",
+	}, requests)
+}
+
+func TestPostPullRequestComment_Unconfigured(t *testing.T) {
+	t.Setenv("GITHUB_REF", "refs/heads/main")
+	crg := NewCIReportGeneratorWithConfig(NewReportConfig(), PlatformGitHubActions, t.TempDir())
+	err := crg.PostPullRequestComment(context.Background(), nil, createTestDriftResults())
+	assert.True(t, IsReportError(err, ErrorTypeConfiguration))
+
+	crg.Platform = PlatformJenkins
+	err = crg.PostPullRequestComment(context.Background(), nil, createTestDriftResults())
+	assert.True(t, IsReportError(err, ErrorTypeConfiguration))
+}
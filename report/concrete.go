@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"firefly-task/pkg/interfaces"
 	"github.com/sirupsen/logrus"
@@ -135,8 +138,114 @@ func (g *ConcreteReportGenerator) GenerateYAMLReportWithContext(ctx context.Cont
 // GenerateTableReportWithContext generates a table format report with context
 func (g *ConcreteReportGenerator) GenerateTableReportWithContext(ctx context.Context, driftResults map[string]*interfaces.DriftResult, options map[string]interface{}) ([]byte, error) {
 	g.logger.Debugf("ConcreteReportGenerator: Generating table report for %d drift results", len(driftResults))
-	// Implement table generation logic here
-	return nil, fmt.Errorf("not implemented")
+
+	maxWidth, err := maxColumnWidth(options)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(driftResults))
+	for key, result := range driftResults {
+		if result != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	rows := [][]string{{"Resource ID", "Type", "Status", "Severity", "Differences"}}
+	for _, key := range keys {
+		result := driftResults[key]
+		resourceID := result.ResourceID
+		if resourceID == "" {
+			resourceID = key
+		}
+		status := "No Drift"
+		if result.IsDrifted {
+			status = "Drift"
+		}
+		rows = append(rows, []string{
+			truncateCell(resourceID, maxWidth),
+			result.ResourceType,
+			status,
+			string(result.Severity),
+			strconv.Itoa(len(result.DriftDetails)),
+		})
+	}
+
+	return []byte(renderASCIITable(rows)), nil
+}
+
+// maxColumnWidth reads options["max_col_width"], the width resource IDs are
+// truncated to; 0 means no limit
+func maxColumnWidth(options map[string]interface{}) (int, error) {
+	value, ok := options["max_col_width"]
+	if !ok {
+		return 0, nil
+	}
+
+	var width int
+	switch v := value.(type) {
+	case int:
+		width = v
+	case int64:
+		width = int(v)
+	case float64:
+		width = int(v)
+	default:
+		return 0, fmt.Errorf("max_col_width must be a number, got %T", value)
+	}
+	if width < 0 {
+		return 0, fmt.Errorf("max_col_width cannot be negative, got %d", width)
+	}
+	return width, nil
+}
+
+// truncateCell shortens s to at most width characters, ending in "..." when
+// cut. A width of 0 leaves s unchanged.
+func truncateCell(s string, width int) string {
+	runes := []rune(s)
+	if width <= 0 || len(runes) <= width {
+		return s
+	}
+	if width <= 3 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-3]) + "..."
+}
+
+// renderASCIITable draws rows as a bordered table whose columns are padded to
+// their widest cell. The first row is the header.
+func renderASCIITable(rows [][]string) string {
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	var border strings.Builder
+	border.WriteString("+")
+	for _, w := range widths {
+		border.WriteString(strings.Repeat("-", w+2) + "+")
+	}
+	border.WriteString("\n")
+
+	var b strings.Builder
+	b.WriteString(border.String())
+	for i, row := range rows {
+		b.WriteString("|")
+		for j, cell := range row {
+			b.WriteString(" " + cell + strings.Repeat(" ", widths[j]-utf8.RuneCountInString(cell)) + " |")
+		}
+		b.WriteString("\n")
+		if i == 0 {
+			b.WriteString(border.String())
+		}
+	}
+	b.WriteString(border.String())
+	return b.String()
 }
 
 // GenerateHTMLReportWithContext generates an HTML format report with context
@@ -168,6 +277,10 @@ func (g *ConcreteReportGenerator) GenerateCustomReport(ctx context.Context, drif
 		return g.GenerateHTMLReportWithContext(ctx, driftResults, options)
 	case "markdown", "md":
 		return g.GenerateMarkdownReportWithContext(ctx, driftResults, options)
+	case "csv":
+		return NewCSVGenerator().Generate(driftResults, options)
+	case "sarif":
+		return NewSARIFGenerator().Generate(driftResults)
 	default:
 		return nil, fmt.Errorf("unsupported custom format: %s", format)
 	}
@@ -401,5 +514,5 @@ func (factory *ConcreteReportFactory) CreateReportFilter(ctx context.Context, co
 // ListSupportedFormats returns a list of supported report formats
 func (factory *ConcreteReportFactory) ListSupportedFormats(ctx context.Context) ([]string, error) {
 	factory.logger.Debug("ConcreteReportFactory: Listing supported formats")
-	return []string{"json", "yaml", "table", "html", "markdown"}, nil
+	return ListSupportedFormats(), nil
 }
\ No newline at end of file
@@ -7,12 +7,14 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"firefly-task/pkg/interfaces"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 )
 
@@ -235,9 +237,50 @@ func TestConcreteReportGenerator_GenerateTableReport(t *testing.T) {
 	driftResults := createTestDriftResults()
 
 	result, err := generator.GenerateTableReport(driftResults)
+	require.NoError(t, err)
+	assert.NotContains(t, result, "not implemented")
+
+	lines := strings.Split(strings.TrimRight(result, "\n"), "\n")
+	require.Len(t, lines, 4+len(driftResults))
+	assert.Regexp(t, `^\| Resource ID +\| Type +\| Status +\| Severity +\| Differences \|$`, lines[1])
+	for _, line := range lines {
+		assert.Equal(t, len(lines[0]), len(line), "row %q is not aligned", line)
+	}
+
+	// Column separators line up on every row
+	for _, line := range lines[1:] {
+		if strings.HasPrefix(line, "+") {
+			continue
+		}
+		for i, ch := range lines[0] {
+			if ch == '+' {
+				assert.Equal(t, byte('|'), line[i], "separator misaligned in %q", line)
+			}
+		}
+	}
+	assert.Contains(t, result, "| critical ")
+	assert.Contains(t, result, "| No Drift ")
+}
+
+func TestConcreteReportGenerator_GenerateTableReport_MaxColWidth(t *testing.T) {
+	generator := NewConcreteReportGenerator(logrus.New())
+	driftResults := map[string]*interfaces.DriftResult{
+		"long": {
+			ResourceID:   "i-0123456789abcdef0123456789",
+			ResourceType: "aws_instance",
+			IsDrifted:    true,
+			Severity:     interfaces.SeverityHigh,
+			DriftDetails: []*interfaces.DriftDetail{{Attribute: "instance_type"}, {Attribute: "tags"}},
+		},
+	}
+
+	data, err := generator.GenerateTableReportWithContext(context.Background(), driftResults, map[string]interface{}{"max_col_width": 12})
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "| i-0123456... | aws_instance | Drift  | high     | 2           |")
+	assert.NotContains(t, string(data), "i-0123456789abcdef")
+
+	_, err = generator.GenerateTableReportWithContext(context.Background(), driftResults, map[string]interface{}{"max_col_width": "wide"})
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "not implemented")
-	assert.Equal(t, "", result)
 }
 
 func TestConcreteReportGenerator_GenerateHTMLReport(t *testing.T) {
@@ -285,9 +328,8 @@ func TestConcreteReportGenerator_GenerateCustomReport(t *testing.T) {
 			format: "yml",
 		},
 		{
-			name:          "table format (not implemented)",
-			format:        "table",
-			expectedError: "not implemented",
+			name:   "table format",
+			format: "table",
 		},
 		{
 			name:          "HTML format (not implemented)",
@@ -643,5 +685,38 @@ func TestConcreteReportFactory_ListSupportedFormats(t *testing.T) {
 	assert.Contains(t, formats, "table")
 	assert.Contains(t, formats, "html")
 	assert.Contains(t, formats, "markdown")
-	assert.Len(t, formats, 5)
+	assert.Contains(t, formats, "sarif")
+	assert.Contains(t, formats, "csv")
+	assert.Len(t, formats, 7)
+}
+
+func TestConcreteReportFactory_SupportedFormatsGenerate(t *testing.T) {
+	logger := logrus.New()
+	factory := NewConcreteReportFactory(logger)
+	driftResults := createTestDriftResults()
+
+	created, err := factory.CreateReportGenerator(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	generator := created.(*ConcreteReportGenerator)
+
+	formats, err := factory.ListSupportedFormats(context.Background())
+	require.NoError(t, err)
+	for _, format := range formats {
+		t.Run(format, func(t *testing.T) {
+			result, err := generator.GenerateCustomReport(context.Background(), driftResults, format, map[string]interface{}{})
+			if err != nil {
+				// HTML and Markdown go through their dedicated generators
+				assert.Contains(t, err.Error(), "not implemented")
+				return
+			}
+			assert.NotEmpty(t, result)
+		})
+	}
+
+	sarif, err := generator.GenerateCustomReport(context.Background(), driftResults, "sarif", nil)
+	require.NoError(t, err)
+	var log SARIFLog
+	require.NoError(t, json.Unmarshal(sarif, &log))
+	assert.Equal(t, SARIFVersion, log.Version)
+	assert.NotEmpty(t, log.Runs[0].Results)
 }
\ No newline at end of file
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"firefly-task/pkg/interfaces"
+	"firefly-task/pkg/numfmt"
 )
 
 // ANSI color codes for console output
@@ -55,10 +56,19 @@ func (crg *ConsoleReportGenerator) GenerateReport(results map[string]*interfaces
 	if config.Format == 0 && !config.IncludeTimestamp && !config.IncludeSummary && !config.ColorOutput {
 		return nil, NewReportError(ErrorTypeInvalidInput, "config appears to be uninitialized")
 	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
 
 	// Update color setting from config
 	crg.colorEnabled = config.ColorOutput
 
+	if config.Format == FormatConsole || config.Format == FormatTable {
+		if confirmation, quiet := quietCleanReport(&config, results); quiet {
+			return []byte(confirmation), nil
+		}
+	}
+
 	switch config.Format {
 	case FormatConsole:
 		consoleReport, err := crg.GenerateConsoleReport(results)
@@ -99,6 +109,15 @@ func (crg *ConsoleReportGenerator) GenerateTableReport(results map[string]*inter
 	if results == nil {
 		return "", NewReportError(ErrorTypeInvalidInput, "results cannot be nil")
 	}
+	if crg.config != nil {
+		if err := crg.config.Validate(); err != nil {
+			return "", err
+		}
+	}
+
+	if confirmation, quiet := quietCleanReport(crg.config, results); quiet {
+		return confirmation, nil
+	}
 
 	var builder strings.Builder
 
@@ -134,7 +153,7 @@ func (crg *ConsoleReportGenerator) GenerateTableReport(results map[string]*inter
 
 		row := fmt.Sprintf("%-30s %-15s %-10s %-15s\n",
 			result.ResourceID,
-			result.ResourceType,
+			resourceTypeLabel(crg.config, result.ResourceType),
 			crg.colorize(status, statusColor),
 			crg.colorize(string(result.Severity), crg.getSeverityColor(result.Severity)))
 		builder.WriteString(row)
@@ -148,6 +167,15 @@ func (crg *ConsoleReportGenerator) GenerateConsoleReport(results map[string]*int
 	if results == nil {
 		return "", NewReportError(ErrorTypeInvalidInput, "results cannot be nil")
 	}
+	if crg.config != nil {
+		if err := crg.config.Validate(); err != nil {
+			return "", err
+		}
+	}
+
+	if confirmation, quiet := quietCleanReport(crg.config, results); quiet {
+		return confirmation, nil
+	}
 
 	var builder strings.Builder
 
@@ -163,7 +191,7 @@ func (crg *ConsoleReportGenerator) GenerateConsoleReport(results map[string]*int
 	}
 
 	// Detailed results section
-	builder.WriteString(crg.colorize("\n📋 DETAILED RESULTS:\n", ColorBold+ColorWhite))
+	builder.WriteString(crg.colorize(fmt.Sprintf("\n📋 %s:\n", crg.t(MsgDetailedResults, true)), ColorBold+ColorWhite))
 	builder.WriteString(crg.colorize(strings.Repeat("═", 80), ColorDim) + "\n")
 
 	// Sort results by resource ID for consistent output
@@ -181,11 +209,83 @@ func (crg *ConsoleReportGenerator) GenerateConsoleReport(results map[string]*int
 	// Results by severity
 	builder.WriteString(crg.generateResultsBySeverity(results))
 
+	if crg.config != nil && crg.config.GroupByModule {
+		builder.WriteString(crg.generateModuleGroups(results))
+	}
+
+	age, err := loadDriftAge(crg.config, results)
+	if err != nil {
+		return "", err
+	}
+	if age != nil {
+		builder.WriteString(crg.generateDriftAge(age))
+	}
 
+	if crg.config != nil && crg.config.ShowExitBanner {
+		builder.WriteString(crg.GenerateExitBanner(results, crg.config))
+	}
 
 	return builder.String(), nil
 }
 
+// GenerateExitBanner renders the build verdict that SetExitCode would return
+// for results under policy's fail-on attributes: red when the build will
+// fail, yellow for drift below the failure threshold, green when clean
+func (crg *ConsoleReportGenerator) GenerateExitBanner(results map[string]*interfaces.DriftResult, policy *ReportConfig) string {
+	var failOnAttributes []string
+	if policy != nil {
+		failOnAttributes = policy.FailOnAttributes
+	}
+	exitCode := exitCodeFor(results, failOnAttributes)
+
+	critical, high, drifted := 0, 0, 0
+	for _, result := range results {
+		if result == nil || !result.IsDrifted {
+			continue
+		}
+		drifted++
+		switch result.Severity {
+		case interfaces.SeverityCritical:
+			critical++
+		case interfaces.SeverityHigh:
+			high++
+		}
+	}
+
+	var message, color string
+	switch {
+	case results == nil:
+		message, color = "BUILD WILL FAIL: no drift results", ColorRed+ColorBold
+	case critical > 0:
+		message, color = fmt.Sprintf("BUILD WILL FAIL: %d critical drifts", critical), ColorRed+ColorBold
+	case high > 0:
+		message, color = fmt.Sprintf("BUILD WILL FAIL: %d high severity drifts", high), ColorRed+ColorBold
+	case exitCode != 0:
+		message, color = "BUILD WILL FAIL: drift in fail-on attributes", ColorRed+ColorBold
+	case drifted > 0:
+		message, color = fmt.Sprintf("BUILD WILL PASS: %d drifted resources below the failure threshold", drifted), ColorYellow+ColorBold
+	default:
+		message, color = "BUILD WILL PASS: no drift detected", ColorGreen+ColorBold
+	}
+
+	line := strings.Repeat("═", 80)
+	return "\n" + crg.colorize(fmt.Sprintf("%s\n%s (exit code %d)\n%s", line, message, exitCode, line), color) + "\n"
+}
+
+// generateDriftAge renders the new/persistent/flapping buckets
+func (crg *ConsoleReportGenerator) generateDriftAge(age *DriftAge) string {
+	var builder strings.Builder
+	builder.WriteString(crg.colorize("\n⏳ DRIFT AGE:\n", ColorBold+ColorWhite))
+	builder.WriteString(crg.colorize(strings.Repeat("═", 80), ColorDim) + "\n")
+	for _, bucket := range age.buckets() {
+		builder.WriteString(fmt.Sprintf("%s: %d\n", bucket.label, len(bucket.ids)))
+		for _, id := range bucket.ids {
+			builder.WriteString(fmt.Sprintf("  • %s\n", id))
+		}
+	}
+	return builder.String()
+}
+
 // WriteToFile delegates to standard generator
 func (crg *ConsoleReportGenerator) WriteToFile(content []byte, filePath string) error {
 	standardGen := NewStandardReportGenerator()
@@ -194,6 +294,15 @@ func (crg *ConsoleReportGenerator) WriteToFile(content []byte, filePath string)
 
 // Helper methods for console formatting
 
+// t returns the localized message for key, upper-cased for section headers
+func (crg *ConsoleReportGenerator) t(key string, header bool) string {
+	msg := localize(crg.config, key)
+	if header {
+		return strings.ToUpper(msg)
+	}
+	return msg
+}
+
 // colorize applies color to text if colors are enabled
 func (crg *ConsoleReportGenerator) colorize(text, color string) string {
 	if !crg.colorEnabled {
@@ -257,20 +366,11 @@ func (crg *ConsoleReportGenerator) generateCustomHeader(title string, colorEnabl
 func (crg *ConsoleReportGenerator) generateSummarySection(results map[string]*interfaces.DriftResult, colorEnabled bool) string {
 	var builder strings.Builder
 
-	totalResources := len(results)
-	resourcesWithDrift := 0
-	totalDifferences := 0
-	highestSeverity := interfaces.SeverityNone
-
-	for _, result := range results {
-		if result.IsDrifted {
-			resourcesWithDrift++
-			totalDifferences += len(result.DriftDetails)
-			if result.Severity > highestSeverity {
-				highestSeverity = result.Severity
-			}
-		}
-	}
+	summary := Summarize(results)
+	totalResources := summary.TotalResources
+	resourcesWithDrift := summary.DriftedResources
+	totalDifferences := summary.TotalDifferences
+	highestSeverity := summary.HighestSeverity
 
 	builder.WriteString("\nSUMMARY:\n")
 	builder.WriteString(fmt.Sprintf("Total Resources: %d\n", totalResources))
@@ -291,57 +391,50 @@ func (crg *ConsoleReportGenerator) generateSummarySection(results map[string]*in
 func (crg *ConsoleReportGenerator) generateColoredSummary(results map[string]*interfaces.DriftResult) string {
 	if len(results) == 0 {
 		var builder strings.Builder
-		builder.WriteString(crg.colorize("\n📊 SUMMARY:\n", ColorBold+ColorWhite))
-		builder.WriteString(fmt.Sprintf("   Total Resources: %s\n", crg.colorize("0", ColorCyan)))
-		builder.WriteString(fmt.Sprintf("   Resources with Drift: %s\n", crg.colorize("0", ColorGreen)))
-		builder.WriteString(fmt.Sprintf("   %s\n", crg.colorize("✅ No drift detected!", ColorGreen+ColorBold)))
+		builder.WriteString(crg.colorize(fmt.Sprintf("\n📊 %s:\n", crg.t(MsgSummary, true)), ColorBold+ColorWhite))
+		builder.WriteString(fmt.Sprintf("   %s: %s\n", crg.t(MsgTotalResources, false), crg.colorize("0", ColorCyan)))
+		builder.WriteString(fmt.Sprintf("   %s: %s\n", crg.t(MsgResourcesWithDrift, false), crg.colorize("0", ColorGreen)))
+		builder.WriteString(fmt.Sprintf("   %s\n", crg.colorize("✅ "+crg.t(MsgNoDriftDetected, false), ColorGreen+ColorBold)))
 		return builder.String()
 	}
 
 	var builder strings.Builder
 
-	totalResources := len(results)
-	resourcesWithDrift := 0
-	totalDifferences := 0
-	severityCounts := make(map[interfaces.SeverityLevel]int)
-
-	for _, result := range results {
-		if result.IsDrifted {
-			resourcesWithDrift++
-			totalDifferences += len(result.DriftDetails)
-		}
-		severityCounts[result.Severity]++
-	}
+	summary := Summarize(results)
+	totalResources := summary.TotalResources
+	resourcesWithDrift := summary.DriftedResources
+	totalDifferences := summary.TotalDifferences
+	severityCounts := summary.SeverityCounts
 
-	builder.WriteString(crg.colorize("\n📊 SUMMARY:\n", ColorBold+ColorWhite))
-	builder.WriteString(fmt.Sprintf("   Total Resources: %s\n", crg.colorize(fmt.Sprintf("%d", totalResources), ColorCyan)))
+	builder.WriteString(crg.colorize(fmt.Sprintf("\n📊 %s:\n", crg.t(MsgSummary, true)), ColorBold+ColorWhite))
+	builder.WriteString(fmt.Sprintf("   %s: %s\n", crg.t(MsgTotalResources, false), crg.colorize(fmt.Sprintf("%d", totalResources), ColorCyan)))
 
 	if resourcesWithDrift > 0 {
-		builder.WriteString(fmt.Sprintf("   Resources with Drift: %s\n", crg.colorize(fmt.Sprintf("%d", resourcesWithDrift), ColorRed)))
-		builder.WriteString(fmt.Sprintf("   Total Differences: %s\n", crg.colorize(fmt.Sprintf("%d", totalDifferences), ColorYellow)))
+		builder.WriteString(fmt.Sprintf("   %s: %s\n", crg.t(MsgResourcesWithDrift, false), crg.colorize(fmt.Sprintf("%d", resourcesWithDrift), ColorRed)))
+		builder.WriteString(fmt.Sprintf("   %s: %s\n", crg.t(MsgTotalDifferences, false), crg.colorize(fmt.Sprintf("%d", totalDifferences), ColorYellow)))
 	} else {
-		builder.WriteString(fmt.Sprintf("   Resources with Drift: %s\n", crg.colorize("0", ColorGreen)))
-		builder.WriteString(fmt.Sprintf("   %s\n", crg.colorize("✅ No drift detected!", ColorGreen+ColorBold)))
+		builder.WriteString(fmt.Sprintf("   %s: %s\n", crg.t(MsgResourcesWithDrift, false), crg.colorize("0", ColorGreen)))
+		builder.WriteString(fmt.Sprintf("   %s\n", crg.colorize("✅ "+crg.t(MsgNoDriftDetected, false), ColorGreen+ColorBold)))
 	}
 
 	// Severity breakdown
 	if resourcesWithDrift > 0 {
-		builder.WriteString("\n🔍 SEVERITY BREAKDOWN:\n")
+		builder.WriteString(fmt.Sprintf("\n🔍 %s:\n", crg.t(MsgSeverityBreakdown, true)))
 		// Show severity breakdown
 		if count := severityCounts[interfaces.SeverityCritical]; count > 0 {
-			severityText := fmt.Sprintf("   Critical: %d", count)
+			severityText := fmt.Sprintf("   %s: %d", crg.t(MsgCritical, false), count)
 			builder.WriteString(crg.colorize(severityText, crg.getSeverityColor(interfaces.SeverityCritical)) + "\n")
 		}
 		if count := severityCounts[interfaces.SeverityHigh]; count > 0 {
-			severityText := fmt.Sprintf("   High: %d", count)
+			severityText := fmt.Sprintf("   %s: %d", crg.t(MsgHigh, false), count)
 			builder.WriteString(crg.colorize(severityText, crg.getSeverityColor(interfaces.SeverityHigh)) + "\n")
 		}
 		if count := severityCounts[interfaces.SeverityMedium]; count > 0 {
-			severityText := fmt.Sprintf("   Medium: %d", count)
+			severityText := fmt.Sprintf("   %s: %d", crg.t(MsgMedium, false), count)
 			builder.WriteString(crg.colorize(severityText, crg.getSeverityColor(interfaces.SeverityMedium)) + "\n")
 		}
 		if count := severityCounts[interfaces.SeverityLow]; count > 0 {
-			severityText := fmt.Sprintf("   Low: %d", count)
+			severityText := fmt.Sprintf("   %s: %d", crg.t(MsgLow, false), count)
 			builder.WriteString(crg.colorize(severityText, crg.getSeverityColor(interfaces.SeverityLow)) + "\n")
 		}
 	}
@@ -354,7 +447,7 @@ func (crg *ConsoleReportGenerator) formatResourceResult(resourceKey string, resu
 	var builder strings.Builder
 
 	// Resource header
-	resourceHeader := fmt.Sprintf("\n🔧 Resource: %s", resourceKey)
+	resourceHeader := fmt.Sprintf("\n🔧 Resource: %s", resourceLabel(resourceKey, result))
 	if result.IsDrifted {
 		resourceHeader = crg.colorize(resourceHeader, ColorRed+ColorBold)
 	} else {
@@ -365,6 +458,12 @@ func (crg *ConsoleReportGenerator) formatResourceResult(resourceKey string, resu
 	if result.ResourceID != "" {
 		builder.WriteString(fmt.Sprintf("   Instance ID: %s\n", crg.colorize(result.ResourceID, ColorCyan)))
 	}
+	if result.ResourceType != "" {
+		builder.WriteString(fmt.Sprintf("   Type: %s\n", resourceTypeLabel(crg.config, result.ResourceType)))
+	}
+	if result.Owner != "" {
+		builder.WriteString(fmt.Sprintf("   Owner: %s\n", result.Owner))
+	}
 
 	// Status
 	status := "✅ No Drift"
@@ -380,14 +479,28 @@ func (crg *ConsoleReportGenerator) formatResourceResult(resourceKey string, resu
 	// Differences
 	if result.IsDrifted {
 		builder.WriteString(fmt.Sprintf("   %s:\n", crg.colorize("Differences", ColorYellow+ColorBold)))
-		for i, diff := range result.DriftDetails {
+		for i, diff := range orderedDetails(result.DriftDetails, crg.config) {
 			builder.WriteString(fmt.Sprintf("     %d. %s\n", i+1, crg.colorize(diff.Attribute, ColorWhite+ColorBold)))
-			builder.WriteString(fmt.Sprintf("        Expected: %s\n", crg.colorize(fmt.Sprintf("%v", diff.ExpectedValue), ColorGreen)))
-			builder.WriteString(fmt.Sprintf("        Actual:   %s\n", crg.colorize(fmt.Sprintf("%v", diff.ActualValue), ColorRed)))
+			builder.WriteString(fmt.Sprintf("        Expected: %s\n", crg.colorize(formatValue(diff.ExpectedValue, crg.config), ColorGreen)))
+			builder.WriteString(fmt.Sprintf("        Actual:   %s\n", crg.colorize(formatValue(diff.ActualValue, crg.config), ColorRed)))
 			builder.WriteString(fmt.Sprintf("        Severity: %s\n", crg.colorize(string(diff.Severity), crg.getSeverityColor(diff.Severity))))
 			if diff.Description != "" {
 				builder.WriteString(fmt.Sprintf("        Description: %s\n", crg.colorize(diff.Description, ColorDim)))
 			}
+			if diff.SetDelta != nil {
+				builder.WriteString(fmt.Sprintf("        Added:    %s\n", crg.colorize(formatValue(diff.SetDelta.Added, crg.config), ColorRed)))
+				builder.WriteString(fmt.Sprintf("        Removed:  %s\n", crg.colorize(formatValue(diff.SetDelta.Removed, crg.config), ColorGreen)))
+			}
+			if crg.config != nil && crg.config.Verbose && diff.ComparisonTrace != nil {
+				builder.WriteString(fmt.Sprintf("        Trace: %s\n", crg.colorize(formatComparisonTrace(diff.ComparisonTrace), ColorDim)))
+			}
+		}
+	}
+
+	if len(result.MatchedDetails) > 0 {
+		builder.WriteString(fmt.Sprintf("   ✅ Verified equal (%d):\n", len(result.MatchedDetails)))
+		for _, match := range result.MatchedDetails {
+			builder.WriteString(fmt.Sprintf("     - %s: %s\n", match.Attribute, crg.colorize(formatValue(match.ActualValue, crg.config), ColorGreen)))
 		}
 	}
 
@@ -395,6 +508,32 @@ func (crg *ConsoleReportGenerator) formatResourceResult(resourceKey string, resu
 	return builder.String()
 }
 
+// formatValue renders a drift value for display, rounding floats when a
+// precision is configured
+func formatValue(value interface{}, config *ReportConfig) string {
+	if config == nil || config.FloatPrecision == nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	switch v := value.(type) {
+	case float64:
+		return numfmt.FormatFloat(v, *config.FloatPrecision)
+	case float32:
+		return numfmt.FormatFloat(float64(v), *config.FloatPrecision)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// formatComparisonTrace renders a comparison trace on a single line
+func formatComparisonTrace(trace *interfaces.ComparisonTrace) string {
+	text := fmt.Sprintf("comparator=%s case_sensitive=%t", trace.Comparator, trace.CaseSensitive)
+	if trace.Tolerance != nil {
+		text += fmt.Sprintf(" tolerance=%g", *trace.Tolerance)
+	}
+	return text
+}
+
 // generateProgressIndicator creates a simple progress indicator
 func (crg *ConsoleReportGenerator) generateProgressIndicator(totalResources int) string {
 	var builder strings.Builder
@@ -441,7 +580,7 @@ func (crg *ConsoleReportGenerator) generateResultsBySeverity(results map[string]
 		return ""
 	}
 
-	builder.WriteString(crg.colorize("\n🎯 RESULTS BY SEVERITY:\n", ColorBold+ColorWhite))
+	builder.WriteString(crg.colorize(fmt.Sprintf("\n🎯 %s:\n", crg.t(MsgResultsBySeverity, true)), ColorBold+ColorWhite))
 
 	// Show results by severity in order
 	severities := []interfaces.SeverityLevel{interfaces.SeverityCritical, interfaces.SeverityHigh, interfaces.SeverityMedium, interfaces.SeverityLow}
@@ -479,7 +618,7 @@ func (crg *ConsoleReportGenerator) GenerateSimpleReport(results map[string]*inte
 		if result.IsDrifted {
 			builder.WriteString(fmt.Sprintf("Status: Drift Detected (%d differences)\n", len(result.DriftDetails)))
 			builder.WriteString(fmt.Sprintf("Severity: %s\n", string(result.Severity)))
-			for i, diff := range result.DriftDetails {
+			for i, diff := range orderedDetails(result.DriftDetails, crg.config) {
 				builder.WriteString(fmt.Sprintf("  %d. %s: %v -> %v\n", i+1, diff.Attribute, diff.ExpectedValue, diff.ActualValue))
 			}
 		} else {
@@ -1,6 +1,7 @@
 package report
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -362,3 +363,265 @@ func BenchmarkConsoleReportGenerator_GenerateTable(b *testing.B) {
 	}
 }
 */
+
+func TestConsoleReportGenerator_VerboseComparisonTrace(t *testing.T) {
+	results := createTestDriftResults()
+	results["aws_instance.web-server-1"].DriftDetails[0].ComparisonTrace = &interfaces.ComparisonTrace{
+		Comparator:    "exact",
+		CaseSensitive: true,
+	}
+
+	generator := NewConsoleReportGenerator()
+	generator.WithConfig(NewReportConfig().WithColorOutput(false))
+	output, err := generator.GenerateConsoleReport(results)
+	require.NoError(t, err)
+	assert.NotContains(t, output, "Trace:")
+
+	generator.WithConfig(NewReportConfig().WithColorOutput(false).WithVerbose(true))
+	output, err = generator.GenerateConsoleReport(results)
+	require.NoError(t, err)
+	assert.Contains(t, output, "Trace: comparator=exact case_sensitive=true")
+}
+
+func TestFormatValue_FloatPrecision(t *testing.T) {
+	assert.Equal(t, "3.140000001", formatValue(3.140000001, NewReportConfig()))
+	assert.Equal(t, "3.14", formatValue(3.140000001, NewReportConfig().WithFloatPrecision(2)))
+	assert.Equal(t, "t3.micro", formatValue("t3.micro", NewReportConfig().WithFloatPrecision(2)))
+	assert.Equal(t, "<nil>", formatValue(nil, nil))
+}
+
+func TestReportConfig_ValidateFloatPrecision(t *testing.T) {
+	assert.NoError(t, NewReportConfig().Validate())
+	assert.NoError(t, NewReportConfig().WithFloatPrecision(0).Validate())
+
+	err := NewReportConfig().WithFloatPrecision(-1).Validate()
+	assert.True(t, IsReportError(err, ErrorTypeConfiguration))
+
+	generator := NewConsoleReportGenerator()
+	generator.WithConfig(NewReportConfig().WithFloatPrecision(-2))
+	_, err = generator.GenerateConsoleReport(createTestDriftResults())
+	assert.True(t, IsReportError(err, ErrorTypeConfiguration))
+}
+
+func TestConsoleReportGenerator_QuietWhenClean(t *testing.T) {
+	clean := map[string]*interfaces.DriftResult{
+		"aws_instance.a": {ResourceID: "aws_instance.a", IsDrifted: false},
+		"aws_instance.b": {ResourceID: "aws_instance.b", IsDrifted: false},
+	}
+	config := NewReportConfig().WithFormat(FormatConsole).WithColor(false).WithQuietWhenClean(true)
+
+	data, err := NewConsoleReportGenerator().GenerateReport(clean, *config)
+	require.NoError(t, err)
+	output := string(data)
+	assert.Equal(t, 1, strings.Count(output, "\n"))
+	assert.Contains(t, output, "No drift detected!")
+	assert.Contains(t, output, "2 resources checked")
+	assert.NotContains(t, output, "SUMMARY")
+
+	drifted := map[string]*interfaces.DriftResult{
+		"aws_instance.a": {ResourceID: "aws_instance.a", IsDrifted: false},
+		"aws_instance.b": {
+			ResourceID: "aws_instance.b",
+			IsDrifted:  true,
+			Severity:   interfaces.SeverityHigh,
+			DriftDetails: []*interfaces.DriftDetail{
+				{Attribute: "instance_type", ExpectedValue: "t3.micro", ActualValue: "t3.large", Severity: interfaces.SeverityHigh},
+			},
+		},
+	}
+	data, err = NewConsoleReportGenerator().GenerateReport(drifted, *config)
+	require.NoError(t, err)
+	output = string(data)
+	assert.Contains(t, output, "SUMMARY")
+	assert.Contains(t, output, "aws_instance.b")
+}
+
+func TestConsoleReportGenerator_GenerateExitBanner(t *testing.T) {
+	generator := NewConsoleReportGenerator()
+	policy := NewReportConfig()
+
+	drifted := func(severity interfaces.SeverityLevel, attribute string) *interfaces.DriftResult {
+		return &interfaces.DriftResult{
+			IsDrifted: true,
+			Severity:  severity,
+			DriftDetails: []*interfaces.DriftDetail{
+				{Attribute: attribute, Severity: severity},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		results map[string]*interfaces.DriftResult
+		policy  *ReportConfig
+		message string
+		color   string
+	}{
+		{
+			name: "critical",
+			results: map[string]*interfaces.DriftResult{
+				"a": drifted(interfaces.SeverityCritical, "security_groups"),
+				"b": drifted(interfaces.SeverityCritical, "instance_type"),
+				"c": drifted(interfaces.SeverityLow, "tags"),
+			},
+			policy:  policy,
+			message: "BUILD WILL FAIL: 2 critical drifts (exit code 2)",
+			color:   ColorRed + ColorBold,
+		},
+		{
+			name: "drift under threshold",
+			results: map[string]*interfaces.DriftResult{
+				"a": drifted(interfaces.SeverityMedium, "tags"),
+				"b": {IsDrifted: false, Severity: interfaces.SeverityNone},
+			},
+			policy:  policy,
+			message: "BUILD WILL PASS: 1 drifted resources below the failure threshold (exit code 0)",
+			color:   ColorYellow + ColorBold,
+		},
+		{
+			name: "fail-on attribute",
+			results: map[string]*interfaces.DriftResult{
+				"a": drifted(interfaces.SeverityMedium, "tags"),
+			},
+			policy:  NewReportConfig().WithFailOnAttributes([]string{"tags"}),
+			message: "BUILD WILL FAIL: drift in fail-on attributes (exit code 1)",
+			color:   ColorRed + ColorBold,
+		},
+		{
+			name: "clean",
+			results: map[string]*interfaces.DriftResult{
+				"a": {IsDrifted: false, Severity: interfaces.SeverityNone},
+			},
+			policy:  policy,
+			message: "BUILD WILL PASS: no drift detected (exit code 0)",
+			color:   ColorGreen + ColorBold,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			banner := generator.GenerateExitBanner(tt.results, tt.policy)
+			assert.Contains(t, banner, tt.message)
+			assert.True(t, strings.HasPrefix(strings.TrimPrefix(banner, "\n"), tt.color), "banner should start with %q", tt.color)
+
+			ci := NewCIReportGeneratorWithConfig(tt.policy, PlatformGeneric, t.TempDir())
+			assert.Contains(t, banner, fmt.Sprintf("(exit code %d)", ci.SetExitCode(tt.results)))
+		})
+	}
+}
+
+func TestConsoleReportGenerator_ExitBannerPrintedLast(t *testing.T) {
+	config := NewReportConfig().WithFormat(FormatConsole).WithColorOutput(false).WithExitBanner(true)
+	generator := NewConsoleReportGenerator()
+	generator.WithConfig(config)
+
+	output, err := generator.GenerateConsoleReport(createTestDriftResults())
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(strings.TrimRight(output, "═\n"), "BUILD WILL FAIL: 1 critical drifts (exit code 2)"))
+}
+
+func TestConsoleReportGenerator_AttributeDisplayOrder(t *testing.T) {
+	results := map[string]*interfaces.DriftResult{
+		"aws_instance.web": {
+			ResourceID: "aws_instance.web",
+			IsDrifted:  true,
+			Severity:   interfaces.SeverityCritical,
+			DriftDetails: []*interfaces.DriftDetail{
+				{Attribute: "tags", ExpectedValue: "a", ActualValue: "b", Severity: interfaces.SeverityLow},
+				{Attribute: "ami", ExpectedValue: "ami-1", ActualValue: "ami-2", Severity: interfaces.SeverityHigh},
+				{Attribute: "security_groups", ExpectedValue: "sg-1", ActualValue: "sg-2", Severity: interfaces.SeverityCritical},
+			},
+		},
+	}
+	config := NewReportConfig().WithColorOutput(false).WithAttributeDisplayOrder([]string{"security_groups"})
+	generator := NewConsoleReportGenerator()
+	generator.WithConfig(config)
+
+	output, err := generator.GenerateConsoleReport(results)
+	require.NoError(t, err)
+	sg := strings.Index(output, "1. security_groups")
+	ami := strings.Index(output, "2. ami")
+	tags := strings.Index(output, "3. tags")
+	require.True(t, sg >= 0 && ami >= 0 && tags >= 0, output)
+	assert.Less(t, sg, ami)
+	assert.Less(t, ami, tags)
+
+	simple, err := generator.GenerateSimpleReport(results)
+	require.NoError(t, err)
+	assert.Less(t, strings.Index(simple, "security_groups"), strings.Index(simple, "tags"))
+
+	table, err := NewStandardReportGenerator().WithConfig(config).GenerateTableReport(results)
+	require.NoError(t, err)
+	assert.Contains(t, table, "security_groups, ami, tags")
+}
+
+func TestConsoleReportGenerator_DisplayName(t *testing.T) {
+	results := map[string]*interfaces.DriftResult{
+		"i-0abc": {
+			ResourceID:  "i-0abc",
+			IsDrifted:   true,
+			Severity:    interfaces.SeverityMedium,
+			DisplayName: "web-server",
+			DriftDetails: []*interfaces.DriftDetail{
+				{Attribute: "instance_type", ExpectedValue: "t3.micro", ActualValue: "t3.large", Severity: interfaces.SeverityMedium},
+			},
+		},
+		"i-0def": {ResourceID: "i-0def", Severity: interfaces.SeverityNone},
+	}
+	generator := NewConsoleReportGenerator()
+	generator.WithConfig(NewReportConfig().WithColorOutput(false))
+
+	output, err := generator.GenerateConsoleReport(results)
+	require.NoError(t, err)
+	assert.Contains(t, output, "Resource: web-server\n")
+	assert.Contains(t, output, "Instance ID: i-0abc")
+	assert.Contains(t, output, "Resource: i-0def\n")
+}
+
+func TestConsoleReportGenerator_ResourceTypeLabels(t *testing.T) {
+	results := createTestDriftResults()
+	generator := NewConsoleReportGenerator()
+	generator.WithConfig(NewReportConfig().WithColorOutput(false).
+		WithResourceTypeLabels(map[string]string{"aws_db_instance": "RDS Database"}))
+
+	table, err := generator.GenerateTableReport(results)
+	require.NoError(t, err)
+	assert.Contains(t, table, "RDS Database")
+	assert.NotContains(t, table, "aws_db_instance ")
+	assert.Contains(t, table, "aws_lb")
+
+	output, err := generator.GenerateConsoleReport(results)
+	require.NoError(t, err)
+	assert.Contains(t, output, "Type: RDS Database\n")
+	assert.Contains(t, output, "Type: aws_instance\n")
+}
+
+func TestConsoleReportGenerator_SetDelta(t *testing.T) {
+	results := map[string]*interfaces.DriftResult{
+		"i-0abc": {
+			ResourceID: "i-0abc",
+			IsDrifted:  true,
+			Severity:   interfaces.SeverityHigh,
+			DriftDetails: []*interfaces.DriftDetail{
+				{
+					Attribute:     "security_groups",
+					ExpectedValue: []string{"sg-1", "sg-3"},
+					ActualValue:   []string{"sg-1", "sg-2"},
+					Severity:      interfaces.SeverityHigh,
+					SetDelta: &interfaces.SetDelta{
+						Added:   []interface{}{"sg-2"},
+						Removed: []interface{}{"sg-3"},
+						Matched: 1,
+					},
+				},
+			},
+		},
+	}
+	generator := NewConsoleReportGenerator()
+	generator.WithConfig(NewReportConfig().WithColorOutput(false))
+
+	output, err := generator.GenerateConsoleReport(results)
+	require.NoError(t, err)
+	assert.Contains(t, output, "Added:    [sg-2]\n")
+	assert.Contains(t, output, "Removed:  [sg-3]\n")
+}
@@ -0,0 +1,213 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// csvFlushInterval is the number of rows written between flushes
+const csvFlushInterval = 100
+
+// csvHeader lists the columns of a drift detail row, written by StreamCSV
+// and by CSVGenerator in details mode
+var csvHeader = []string{
+	"resource_id",
+	"resource_type",
+	"attribute",
+	"expected_value",
+	"actual_value",
+	"severity",
+	"drift_type",
+	"description",
+	"detection_time",
+}
+
+// StreamCSV writes one CSV row per drift detail to w as it iterates over the
+// results, without buffering the whole report. Rows are flushed periodically,
+// and w is flushed too when it implements http.Flusher.
+func StreamCSV(results map[string]*interfaces.DriftResult, w io.Writer) error {
+	if results == nil {
+		return NewReportError(ErrorTypeInvalidInput, "results cannot be nil")
+	}
+	if w == nil {
+		return NewReportError(ErrorTypeInvalidInput, "writer cannot be nil")
+	}
+
+	writer := csv.NewWriter(w)
+	flusher, _ := w.(http.Flusher)
+
+	flush := func() error {
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return WrapReportError(ErrorTypeFileOperation, "failed to write CSV", err)
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	}
+
+	if err := writer.Write(csvHeader); err != nil {
+		return WrapReportError(ErrorTypeFileOperation, "failed to write CSV header", err)
+	}
+
+	resourceIDs := make([]string, 0, len(results))
+	for id := range results {
+		resourceIDs = append(resourceIDs, id)
+	}
+	sort.Strings(resourceIDs)
+
+	rows := 0
+	for _, id := range resourceIDs {
+		result := results[id]
+		if result == nil {
+			continue
+		}
+		for _, detail := range result.DriftDetails {
+			if detail == nil {
+				continue
+			}
+			if err := writer.Write(csvDetailRow(id, result, detail)); err != nil {
+				return WrapReportError(ErrorTypeFileOperation, "failed to write CSV row", err)
+			}
+			rows++
+			if rows%csvFlushInterval == 0 {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return flush()
+}
+
+// csvDetailRow renders one drift detail of the result for resource id, in
+// csvHeader order
+func csvDetailRow(id string, result *interfaces.DriftResult, detail *interfaces.DriftDetail) []string {
+	return []string{
+		id,
+		result.ResourceType,
+		detail.Attribute,
+		csvValue(detail.ExpectedValue),
+		csvValue(detail.ActualValue),
+		string(detail.Severity),
+		detail.DriftType,
+		detail.Description,
+		csvTime(result.DetectionTime),
+	}
+}
+
+// csvValue renders a drift value for a CSV cell, leaving nil values empty
+func csvValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// CSV report modes
+const (
+	// CSVModeDetails writes one row per drift detail (the default)
+	CSVModeDetails = "details"
+	// CSVModeSummary writes one row per resource
+	CSVModeSummary = "summary"
+)
+
+// csvSummaryHeader lists the columns of a CSVGenerator summary report
+var csvSummaryHeader = []string{
+	"resource_id",
+	"resource_type",
+	"drifted",
+	"severity",
+	"differences",
+	"attributes",
+	"detection_time",
+}
+
+// CSVGenerator builds CSV reports for spreadsheet analysis. Fields holding
+// commas, quotes or newlines are quoted per RFC 4180.
+type CSVGenerator struct{}
+
+// NewCSVGenerator creates a CSVGenerator
+func NewCSVGenerator() *CSVGenerator {
+	return &CSVGenerator{}
+}
+
+// Generate writes a header row followed by one row per drift detail, as
+// StreamCSV does, or one row per resource when options["mode"] is "summary".
+// Rows are ordered by resource ID.
+func (g *CSVGenerator) Generate(results map[string]*interfaces.DriftResult, options map[string]interface{}) ([]byte, error) {
+	if results == nil {
+		return nil, NewReportError(ErrorTypeInvalidInput, "results cannot be nil")
+	}
+
+	mode := CSVModeDetails
+	if value, ok := options["mode"]; ok {
+		s, isString := value.(string)
+		if !isString || (s != CSVModeDetails && s != CSVModeSummary) {
+			return nil, NewReportErrorf(ErrorTypeInvalidInput, "CSV mode must be %q or %q, got %v", CSVModeDetails, CSVModeSummary, value)
+		}
+		mode = s
+	}
+
+	var buf bytes.Buffer
+	if mode == CSVModeDetails {
+		if err := StreamCSV(results, &buf); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	resourceIDs := make([]string, 0, len(results))
+	for id, result := range results {
+		if result != nil {
+			resourceIDs = append(resourceIDs, id)
+		}
+	}
+	sort.Strings(resourceIDs)
+
+	writer := csv.NewWriter(&buf)
+	writer.Write(csvSummaryHeader)
+	for _, id := range resourceIDs {
+		result := results[id]
+		attributes := make([]string, 0, len(result.DriftDetails))
+		for _, detail := range result.DriftDetails {
+			if detail != nil {
+				attributes = append(attributes, detail.Attribute)
+			}
+		}
+		writer.Write([]string{
+			id,
+			result.ResourceType,
+			strconv.FormatBool(result.IsDrifted),
+			string(result.Severity),
+			strconv.Itoa(len(attributes)),
+			strings.Join(attributes, ", "),
+			csvTime(result.DetectionTime),
+		})
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, WrapReportError(ErrorTypeGenerationFailed, "failed to write CSV report", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// csvTime renders a detection time in RFC 3339, leaving unset times empty
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,103 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func TestStreamCSV(t *testing.T) {
+	results := createTestReportData()
+
+	var buf bytes.Buffer
+	require.NoError(t, StreamCSV(results, &buf))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	assert.Equal(t, csvHeader, records[0])
+	// Rows are ordered by resource ID
+	detectedAt := results["aws_instance.test"].DetectionTime.UTC().Format(time.RFC3339)
+	assert.Equal(t, []string{"aws_instance.test", "aws_instance", "instance_type", "t2.micro", "t2.small", "high", "changed", "", detectedAt}, records[1])
+	assert.Equal(t, "aws_s3_bucket.data", records[2][0])
+	assert.Equal(t, "public_access_block", records[2][2])
+}
+
+func TestStreamCSV_FlushingWriter(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	require.NoError(t, StreamCSV(createTestReportData(), recorder))
+
+	assert.True(t, recorder.Flushed)
+	records, err := csv.NewReader(recorder.Body).ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, records, 3)
+}
+
+func TestStreamCSV_NilResults(t *testing.T) {
+	err := StreamCSV(nil, &bytes.Buffer{})
+	assert.True(t, IsReportError(err, ErrorTypeInvalidInput))
+}
+
+func TestCSVGenerator_Details(t *testing.T) {
+	results := createTestReportData()
+	detectedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	results["aws_instance.test"].DetectionTime = detectedAt
+	results["aws_instance.test"].DriftDetails = append(results["aws_instance.test"].DriftDetails, &interfaces.DriftDetail{
+		Attribute:     "tags",
+		ExpectedValue: "Name=web, Team=core",
+		ActualValue:   "line one\nline \"two\"",
+		DriftType:     "changed",
+		Severity:      interfaces.SeverityMedium,
+	})
+
+	data, err := NewCSVGenerator().Generate(results, nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"Name=web, Team=core"`)
+
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	// Same columns as StreamCSV
+	assert.Equal(t, csvHeader, records[0])
+	assert.Equal(t, []string{"aws_instance.test", "aws_instance", "instance_type", "t2.micro", "t2.small", "high", "changed", "", "2024-03-01T12:00:00Z"}, records[1])
+	// Commas, quotes and newlines survive the round trip
+	assert.Equal(t, "Name=web, Team=core", records[2][3])
+	assert.Equal(t, "line one\nline \"two\"", records[2][4])
+	assert.Equal(t, "aws_s3_bucket.data", records[3][0])
+
+	var streamed bytes.Buffer
+	require.NoError(t, StreamCSV(results, &streamed))
+	assert.Equal(t, streamed.Bytes(), data, "details mode should match StreamCSV")
+}
+
+func TestCSVGenerator_Summary(t *testing.T) {
+	results := createTestReportData()
+	results["aws_instance.test"].DriftDetails = append(results["aws_instance.test"].DriftDetails,
+		&interfaces.DriftDetail{Attribute: "tags", Severity: interfaces.SeverityMedium})
+
+	data, err := NewCSVGenerator().Generate(results, map[string]interface{}{"mode": CSVModeSummary})
+	require.NoError(t, err)
+
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, csvSummaryHeader, records[0])
+	assert.Equal(t, "aws_instance.clean", records[1][0])
+	assert.Equal(t, "false", records[1][2])
+	assert.Equal(t, []string{"true", "high", "2", "instance_type, tags"}, records[2][2:6])
+	assert.Equal(t, "aws_s3_bucket.data", records[3][0])
+
+	_, err = NewCSVGenerator().Generate(results, map[string]interface{}{"mode": "pivot"})
+	assert.True(t, IsReportError(err, ErrorTypeInvalidInput))
+	_, err = NewCSVGenerator().Generate(nil, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,136 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// DefaultPersistentRuns is the number of consecutive drifted runs after which
+// a resource's drift is considered persistent
+const DefaultPersistentRuns = 3
+
+// DriftAge buckets drifted resources by how long their drift has been present
+type DriftAge struct {
+	// New lists resources that drift now but did not drift in the previous run
+	New []string `json:"new"`
+	// Persistent lists resources that have drifted in more than PersistentRuns
+	// consecutive runs, including the current one
+	Persistent []string `json:"persistent"`
+	// Flapping lists resources whose drift appeared and disappeared at least
+	// twice across the history
+	Flapping []string `json:"flapping"`
+	// PersistentRuns is the threshold used for the Persistent bucket
+	PersistentRuns int `json:"persistent_runs"`
+}
+
+// ClassifyDriftAge buckets the drifted resources in results using history,
+// oldest entry first. An entry without drifted resources counts as a clean
+// run. A resource that flaps is reported as flapping even if it is
+// also new; resources drifting for a few runs below the threshold are not
+// bucketed.
+func ClassifyDriftAge(results map[string]*interfaces.DriftResult, history []HistoryEntry, persistentRuns int) *DriftAge {
+	if persistentRuns <= 0 {
+		persistentRuns = DefaultPersistentRuns
+	}
+
+	var runs []map[string]bool
+	for _, entry := range history {
+		drifted := make(map[string]bool, len(entry.DriftedResources))
+		for _, id := range entry.DriftedResources {
+			drifted[id] = true
+		}
+		runs = append(runs, drifted)
+	}
+
+	age := &DriftAge{
+		New:            []string{},
+		Persistent:     []string{},
+		Flapping:       []string{},
+		PersistentRuns: persistentRuns,
+	}
+
+	for id, result := range results {
+		if result == nil || !result.IsDrifted {
+			continue
+		}
+
+		flaps := 0
+		for i := 1; i < len(runs); i++ {
+			if runs[i][id] != runs[i-1][id] {
+				flaps++
+			}
+		}
+		if len(runs) > 0 && !runs[len(runs)-1][id] {
+			flaps++
+		}
+
+		streak := 1
+		for i := len(runs) - 1; i >= 0 && runs[i][id]; i-- {
+			streak++
+		}
+
+		switch {
+		case flaps >= 2:
+			age.Flapping = append(age.Flapping, id)
+		case streak > persistentRuns:
+			age.Persistent = append(age.Persistent, id)
+		case streak == 1:
+			age.New = append(age.New, id)
+		}
+	}
+
+	sort.Strings(age.New)
+	sort.Strings(age.Persistent)
+	sort.Strings(age.Flapping)
+
+	return age
+}
+
+// loadDriftAge reads the history configured on config and classifies results.
+// It returns nil when no history path is configured.
+func loadDriftAge(config *ReportConfig, results map[string]*interfaces.DriftResult) (*DriftAge, error) {
+	if config == nil || config.HistoryPath == "" {
+		return nil, nil
+	}
+
+	history, err := ReadHistory(config.HistoryPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return ClassifyDriftAge(results, history, config.PersistentRuns), nil
+}
+
+// Markdown renders the buckets as a markdown section
+func (da *DriftAge) Markdown() string {
+	var md strings.Builder
+	md.WriteString("\n## Drift Age\n\n")
+	for _, bucket := range da.buckets() {
+		md.WriteString(fmt.Sprintf("### %s (%d)\n", bucket.label, len(bucket.ids)))
+		if len(bucket.ids) == 0 {
+			md.WriteString("- _none_\n")
+		}
+		for _, id := range bucket.ids {
+			md.WriteString(fmt.Sprintf("- `%s`\n", id))
+		}
+		md.WriteString("\n")
+	}
+	return md.String()
+}
+
+type driftAgeBucket struct {
+	label string
+	ids   []string
+}
+
+// buckets returns the buckets in display order
+func (da *DriftAge) buckets() []driftAgeBucket {
+	return []driftAgeBucket{
+		{"New since last run", da.New},
+		{fmt.Sprintf("Persistent (more than %d runs)", da.PersistentRuns), da.Persistent},
+		{"Flapping", da.Flapping},
+	}
+}
@@ -0,0 +1,88 @@
+package report
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func TestClassifyDriftAge(t *testing.T) {
+	historyPath := filepath.Join(t.TempDir(), "history.jsonl")
+	writer := NewHistoryWriter(historyPath, 0)
+
+	runs := [][]string{
+		{"aws_instance.legacy", "aws_instance.flaky"},
+		{"aws_instance.legacy"},
+		{"aws_instance.legacy", "aws_instance.flaky"},
+	}
+	for i, drifted := range runs {
+		require.NoError(t, writer.Append(HistoryEntry{
+			Timestamp:        time.Now().Add(time.Duration(i-len(runs)) * time.Hour),
+			DriftedResources: drifted,
+		}))
+	}
+
+	drifted := func(id string) *interfaces.DriftResult {
+		return &interfaces.DriftResult{
+			ResourceID: id,
+			IsDrifted:  true,
+			Severity:   interfaces.SeverityMedium,
+			DriftDetails: []*interfaces.DriftDetail{
+				{Attribute: "tags", ExpectedValue: "a", ActualValue: "b", Severity: interfaces.SeverityMedium},
+			},
+		}
+	}
+	results := map[string]*interfaces.DriftResult{
+		"aws_instance.legacy": drifted("aws_instance.legacy"),
+		"aws_instance.fresh":  drifted("aws_instance.fresh"),
+		"aws_instance.flaky":  drifted("aws_instance.flaky"),
+		"aws_instance.clean":  {ResourceID: "aws_instance.clean"},
+	}
+
+	history, err := ReadHistory(historyPath)
+	require.NoError(t, err)
+
+	age := ClassifyDriftAge(results, history, 2)
+	assert.Equal(t, []string{"aws_instance.fresh"}, age.New)
+	assert.Equal(t, []string{"aws_instance.legacy"}, age.Persistent)
+	assert.Equal(t, []string{"aws_instance.flaky"}, age.Flapping)
+
+	config := NewReportConfig().WithFormat(FormatConsole).WithColor(false).WithDriftAge(historyPath, 2)
+
+	console, err := NewConsoleReportGenerator().WithConfig(config).(*ConsoleReportGenerator).GenerateConsoleReport(results)
+	require.NoError(t, err)
+	assert.Contains(t, console, "DRIFT AGE")
+	assert.Contains(t, console, "New since last run: 1\n  • aws_instance.fresh")
+	assert.Contains(t, console, "Persistent (more than 2 runs): 1\n  • aws_instance.legacy")
+
+	markdown, err := NewCIReportGeneratorWithConfig(config, PlatformGeneric, t.TempDir()).generateMarkdownSummary(results)
+	require.NoError(t, err)
+	assert.Contains(t, markdown, "## Drift Age")
+	assert.Contains(t, markdown, "### Flapping (1)\n- `aws_instance.flaky`")
+}
+
+func TestClassifyDriftAge_CleanRunBetweenDrift(t *testing.T) {
+	historyPath := filepath.Join(t.TempDir(), "history.jsonl")
+	writer := NewHistoryWriter(historyPath, 0)
+
+	drifted := &interfaces.DriftResult{ResourceID: "aws_instance.web", IsDrifted: true}
+	clean := &interfaces.DriftResult{ResourceID: "aws_instance.web"}
+	for _, result := range []*interfaces.DriftResult{drifted, clean, drifted} {
+		_, err := writer.Record(map[string]*interfaces.DriftResult{"aws_instance.web": result})
+		require.NoError(t, err)
+	}
+
+	history, err := ReadHistory(historyPath)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Empty(t, history[1].DriftedResources)
+
+	age := ClassifyDriftAge(map[string]*interfaces.DriftResult{"aws_instance.web": drifted}, history, 2)
+	assert.Equal(t, []string{"aws_instance.web"}, age.Flapping)
+	assert.Empty(t, age.Persistent)
+}
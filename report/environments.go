@@ -0,0 +1,169 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// EnvSummary holds drift counts for a single environment
+type EnvSummary struct {
+	TotalResources     int            `json:"total_resources"`
+	ResourcesWithDrift int            `json:"resources_with_drift"`
+	TotalDifferences   int            `json:"total_differences"`
+	SeverityCounts     map[string]int `json:"severity_counts"`
+	HighestSeverity    string         `json:"highest_severity"`
+}
+
+// SharedAttribute describes a drifted attribute found in more than one environment
+type SharedAttribute struct {
+	Attribute    string   `json:"attribute"`
+	Environments []string `json:"environments"`
+}
+
+// EnvComparison is a cross-environment rollup of drift results
+type EnvComparison struct {
+	Environments     []string              `json:"environments"`
+	Summaries        map[string]EnvSummary `json:"summaries"`
+	SharedAttributes []SharedAttribute     `json:"shared_attributes"`
+}
+
+// CompareEnvironments builds a drift matrix across named environment result sets
+func CompareEnvironments(envResults map[string]map[string]*interfaces.DriftResult) (*EnvComparison, error) {
+	if len(envResults) == 0 {
+		return nil, NewReportError(ErrorTypeInvalidInput, "at least one environment is required")
+	}
+
+	comparison := &EnvComparison{
+		Environments: make([]string, 0, len(envResults)),
+		Summaries:    make(map[string]EnvSummary, len(envResults)),
+	}
+
+	attributeEnvs := make(map[string]map[string]bool)
+
+	for env, results := range envResults {
+		comparison.Environments = append(comparison.Environments, env)
+
+		summary := EnvSummary{
+			TotalResources: len(results),
+			SeverityCounts: make(map[string]int),
+		}
+		highest := interfaces.SeverityNone
+
+		for _, result := range results {
+			if result == nil || !result.IsDrifted {
+				continue
+			}
+			summary.ResourcesWithDrift++
+			summary.TotalDifferences += len(result.DriftDetails)
+			summary.SeverityCounts[strings.ToLower(string(result.Severity))]++
+			if getSeverityOrder(result.Severity) > getSeverityOrder(highest) {
+				highest = result.Severity
+			}
+
+			for _, detail := range result.DriftDetails {
+				if detail == nil {
+					continue
+				}
+				if attributeEnvs[detail.Attribute] == nil {
+					attributeEnvs[detail.Attribute] = make(map[string]bool)
+				}
+				attributeEnvs[detail.Attribute][env] = true
+			}
+		}
+
+		summary.HighestSeverity = strings.ToUpper(string(highest))
+		comparison.Summaries[env] = summary
+	}
+
+	sort.Strings(comparison.Environments)
+
+	for attr, envs := range attributeEnvs {
+		if len(envs) < 2 {
+			continue
+		}
+		shared := SharedAttribute{Attribute: attr}
+		for env := range envs {
+			shared.Environments = append(shared.Environments, env)
+		}
+		sort.Strings(shared.Environments)
+		comparison.SharedAttributes = append(comparison.SharedAttributes, shared)
+	}
+
+	sort.Slice(comparison.SharedAttributes, func(i, j int) bool {
+		return comparison.SharedAttributes[i].Attribute < comparison.SharedAttributes[j].Attribute
+	})
+
+	return comparison, nil
+}
+
+// Markdown renders the comparison as a markdown table with environments as columns
+func (ec *EnvComparison) Markdown() string {
+	var md strings.Builder
+
+	md.WriteString("# Environment Drift Comparison\n\n")
+	md.WriteString("| Metric |")
+	for _, env := range ec.Environments {
+		md.WriteString(fmt.Sprintf(" %s |", env))
+	}
+	md.WriteString("\n|---|")
+	for range ec.Environments {
+		md.WriteString("---|")
+	}
+	md.WriteString("\n")
+
+	rows := []struct {
+		label string
+		value func(EnvSummary) string
+	}{
+		{"Total Resources", func(s EnvSummary) string { return fmt.Sprintf("%d", s.TotalResources) }},
+		{"Resources with Drift", func(s EnvSummary) string { return fmt.Sprintf("%d", s.ResourcesWithDrift) }},
+		{"Total Differences", func(s EnvSummary) string { return fmt.Sprintf("%d", s.TotalDifferences) }},
+		{"Critical", func(s EnvSummary) string { return fmt.Sprintf("%d", s.SeverityCounts["critical"]) }},
+		{"High", func(s EnvSummary) string { return fmt.Sprintf("%d", s.SeverityCounts["high"]) }},
+		{"Medium", func(s EnvSummary) string { return fmt.Sprintf("%d", s.SeverityCounts["medium"]) }},
+		{"Low", func(s EnvSummary) string { return fmt.Sprintf("%d", s.SeverityCounts["low"]) }},
+		{"Highest Severity", func(s EnvSummary) string { return s.HighestSeverity }},
+	}
+
+	for _, row := range rows {
+		md.WriteString(fmt.Sprintf("| %s |", row.label))
+		for _, env := range ec.Environments {
+			md.WriteString(fmt.Sprintf(" %s |", row.value(ec.Summaries[env])))
+		}
+		md.WriteString("\n")
+	}
+
+	if len(ec.SharedAttributes) > 0 {
+		md.WriteString("\n## Shared Drifted Attributes\n\n")
+		md.WriteString("| Attribute |")
+		for _, env := range ec.Environments {
+			md.WriteString(fmt.Sprintf(" %s |", env))
+		}
+		md.WriteString("\n|---|")
+		for range ec.Environments {
+			md.WriteString("---|")
+		}
+		md.WriteString("\n")
+
+		for _, shared := range ec.SharedAttributes {
+			present := make(map[string]bool, len(shared.Environments))
+			for _, env := range shared.Environments {
+				present[env] = true
+			}
+			md.WriteString(fmt.Sprintf("| %s |", shared.Attribute))
+			for _, env := range ec.Environments {
+				mark := " "
+				if present[env] {
+					mark = "✓"
+				}
+				md.WriteString(fmt.Sprintf(" %s |", mark))
+			}
+			md.WriteString("\n")
+		}
+	}
+
+	return md.String()
+}
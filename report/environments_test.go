@@ -0,0 +1,78 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func TestCompareEnvironments(t *testing.T) {
+	envResults := map[string]map[string]*interfaces.DriftResult{
+		"staging": {
+			"aws_instance.web": {
+				ResourceID:    "i-staging",
+				ResourceType:  "aws_instance",
+				IsDrifted:     true,
+				Severity:      interfaces.SeverityCritical,
+				DetectionTime: time.Now(),
+				DriftDetails: []*interfaces.DriftDetail{
+					{Attribute: "ami", Severity: interfaces.SeverityCritical},
+					{Attribute: "tags", Severity: interfaces.SeverityMedium},
+				},
+			},
+			"aws_instance.db": {
+				ResourceID:   "i-staging-db",
+				ResourceType: "aws_instance",
+				IsDrifted:    false,
+				Severity:     interfaces.SeverityNone,
+			},
+		},
+		"prod": {
+			"aws_instance.web": {
+				ResourceID:    "i-prod",
+				ResourceType:  "aws_instance",
+				IsDrifted:     true,
+				Severity:      interfaces.SeverityMedium,
+				DetectionTime: time.Now(),
+				DriftDetails: []*interfaces.DriftDetail{
+					{Attribute: "tags", Severity: interfaces.SeverityMedium},
+				},
+			},
+		},
+	}
+
+	comparison, err := CompareEnvironments(envResults)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"prod", "staging"}, comparison.Environments)
+
+	staging := comparison.Summaries["staging"]
+	assert.Equal(t, 2, staging.TotalResources)
+	assert.Equal(t, 1, staging.ResourcesWithDrift)
+	assert.Equal(t, 2, staging.TotalDifferences)
+	assert.Equal(t, "CRITICAL", staging.HighestSeverity)
+
+	prod := comparison.Summaries["prod"]
+	assert.Equal(t, 1, prod.ResourcesWithDrift)
+	assert.Equal(t, 1, prod.SeverityCounts["medium"])
+
+	require.Len(t, comparison.SharedAttributes, 1)
+	assert.Equal(t, "tags", comparison.SharedAttributes[0].Attribute)
+	assert.Equal(t, []string{"prod", "staging"}, comparison.SharedAttributes[0].Environments)
+
+	md := comparison.Markdown()
+	assert.Contains(t, md, "| Metric | prod | staging |")
+	assert.Contains(t, md, "| Resources with Drift | 1 | 1 |")
+	assert.Contains(t, md, "| tags | ✓ | ✓ |")
+	assert.NotContains(t, md, "| ami |")
+}
+
+func TestCompareEnvironments_Empty(t *testing.T) {
+	_, err := CompareEnvironments(nil)
+	require.Error(t, err)
+	assert.True(t, IsReportError(err, ErrorTypeInvalidInput))
+}
@@ -0,0 +1,98 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// Failing thresholds reported in ExitReason
+const (
+	ThresholdCritical         = "critical"
+	ThresholdHigh             = "high"
+	ThresholdFailOnAttributes = "fail_on_attributes"
+	ThresholdNoResults        = "no_results"
+)
+
+// ExitReason is the outcome of a run written by WriteExitReason for CI
+// orchestrators that cannot read the process exit code
+type ExitReason struct {
+	ExitCode int    `json:"exit_code"`
+	Reason   string `json:"reason"`
+	// FailingThreshold names the rule that failed the build, empty when it
+	// passed
+	FailingThreshold  string `json:"failing_threshold,omitempty"`
+	TotalResources    int    `json:"total_resources"`
+	DriftedResources  int    `json:"drifted_resources"`
+	CriticalResources int    `json:"critical_resources"`
+	HighResources     int    `json:"high_resources"`
+}
+
+// NewExitReason explains the exit code SetExitCode returns for results under
+// policy's fail-on attributes
+func NewExitReason(results map[string]*interfaces.DriftResult, policy *ReportConfig) ExitReason {
+	var failOnAttributes []string
+	if policy != nil {
+		failOnAttributes = policy.FailOnAttributes
+	}
+
+	reason := ExitReason{ExitCode: exitCodeFor(results, failOnAttributes)}
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		reason.TotalResources++
+		if !result.IsDrifted {
+			continue
+		}
+		reason.DriftedResources++
+		switch result.Severity {
+		case interfaces.SeverityCritical:
+			reason.CriticalResources++
+		case interfaces.SeverityHigh:
+			reason.HighResources++
+		}
+	}
+
+	switch {
+	case results == nil:
+		reason.Reason, reason.FailingThreshold = "no drift results", ThresholdNoResults
+	case reason.CriticalResources > 0:
+		reason.Reason = fmt.Sprintf("%d resources have critical drift", reason.CriticalResources)
+		reason.FailingThreshold = ThresholdCritical
+	case reason.HighResources > 0:
+		reason.Reason = fmt.Sprintf("%d resources have high severity drift", reason.HighResources)
+		reason.FailingThreshold = ThresholdHigh
+	case reason.ExitCode != 0:
+		reason.Reason, reason.FailingThreshold = "drift in fail-on attributes", ThresholdFailOnAttributes
+	case reason.DriftedResources > 0:
+		reason.Reason = fmt.Sprintf("%d drifted resources below the failure threshold", reason.DriftedResources)
+	default:
+		reason.Reason = "no drift detected"
+	}
+	return reason
+}
+
+// WriteExitReason writes the ExitReason for results under policy to path as
+// JSON, creating the parent directory if needed
+func WriteExitReason(results map[string]*interfaces.DriftResult, policy *ReportConfig, path string) error {
+	if path == "" {
+		return NewReportError(ErrorTypeInvalidInput, "exit reason path cannot be empty")
+	}
+
+	content, err := json.MarshalIndent(NewExitReason(results, policy), "", "  ")
+	if err != nil {
+		return WrapError(ErrorTypeMarshaling, "failed to marshal exit reason", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return WrapReportError(ErrorTypeFileOperation, "failed to create exit reason directory", err)
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return WrapReportError(ErrorTypeFileOperation, "failed to write exit reason file", err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteExitReason(t *testing.T) {
+	results := createTestDriftResults()
+	config := NewReportConfig()
+	path := filepath.Join(t.TempDir(), "ci", "exit-reason.json")
+
+	require.NoError(t, WriteExitReason(results, config, path))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var reason ExitReason
+	require.NoError(t, json.Unmarshal(content, &reason))
+
+	generator := NewCIReportGeneratorWithConfig(config, PlatformGeneric, t.TempDir())
+	assert.Equal(t, generator.SetExitCode(results), reason.ExitCode)
+	assert.Equal(t, 2, reason.ExitCode)
+	assert.Equal(t, ThresholdCritical, reason.FailingThreshold)
+	assert.Equal(t, 4, reason.TotalResources)
+	assert.Equal(t, 3, reason.DriftedResources)
+	assert.Equal(t, 1, reason.CriticalResources)
+	assert.Equal(t, 1, reason.HighResources)
+}
+
+func TestNewExitReason_Passing(t *testing.T) {
+	results := createTestDriftResults()
+	delete(results, "aws_instance.web-server-2")
+	delete(results, "aws_lb.main")
+
+	reason := NewExitReason(results, nil)
+	assert.Equal(t, 0, reason.ExitCode)
+	assert.Empty(t, reason.FailingThreshold)
+	assert.Contains(t, reason.Reason, "below the failure threshold")
+
+	reason = NewExitReason(results, NewReportConfig().WithFailOnAttributes([]string{"instance_type"}))
+	assert.Equal(t, 1, reason.ExitCode)
+	assert.Equal(t, ThresholdFailOnAttributes, reason.FailingThreshold)
+}
@@ -1,9 +1,11 @@
 package report
 
 import (
+	"compress/gzip"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -81,7 +83,16 @@ func (fw *FileWriter) WriteReport(results map[string]*interfaces.DriftResult, fi
 		content = fw.addTimestampMetadata(content, format)
 	}
 
-	// Write to file
+	// Write to file, gzipped when configured or when the path asks for it
+	if fw.compress() || strings.HasSuffix(filePath, gzipExtension) {
+		if !strings.HasSuffix(filePath, gzipExtension) {
+			filePath += gzipExtension
+		}
+		if err := writeGzipFile(filePath, content); err != nil {
+			return WrapReportError(ErrorTypeFileOperation, "failed to write compressed file", err)
+		}
+		return nil
+	}
 	if err := os.WriteFile(filePath, content, 0644); err != nil {
 		return WrapReportError(ErrorTypeFileOperation, "failed to write file", err)
 	}
@@ -89,6 +100,11 @@ func (fw *FileWriter) WriteReport(results map[string]*interfaces.DriftResult, fi
 	return nil
 }
 
+// compress reports whether reports should be gzip-compressed
+func (fw *FileWriter) compress() bool {
+	return fw.config != nil && fw.config.Compress
+}
+
 // WriteMultipleFormats writes the same report in multiple formats
 func (fw *FileWriter) WriteMultipleFormats(results map[string]*interfaces.DriftResult, baseFilePath string, formats []ReportFormat) error {
 	if len(formats) == 0 {
@@ -110,23 +126,171 @@ func (fw *FileWriter) WriteMultipleFormats(results map[string]*interfaces.DriftR
 	return nil
 }
 
+// splitSeverities are the buckets written by WriteSplitBySeverity, most
+// severe first
+var splitSeverities = []interfaces.SeverityLevel{
+	interfaces.SeverityCritical,
+	interfaces.SeverityHigh,
+	interfaces.SeverityMedium,
+	interfaces.SeverityLow,
+	interfaces.SeverityNone,
+}
+
+// WriteSplitBySeverity writes one report per overall result severity to dir,
+// named after the severity (critical.json, high.json, ...). Empty buckets are
+// skipped. It returns the written files, most severe first.
+func (fw *FileWriter) WriteSplitBySeverity(results map[string]*interfaces.DriftResult, dir string, format ReportFormat) ([]Artifact, error) {
+	if results == nil {
+		return nil, NewReportError(ErrorTypeInvalidInput, "results cannot be nil")
+	}
+	if dir == "" {
+		return nil, NewReportError(ErrorTypeInvalidInput, "output directory cannot be empty")
+	}
+
+	var artifacts []Artifact
+	for _, severity := range splitSeverities {
+		filter := NewResultFilter().WithSeverityLevels(severity)
+		bucket := make(map[string]*interfaces.DriftResult)
+		for key, result := range results {
+			if result != nil && filter.matchesResourceCriteria(key, result) {
+				bucket[key] = result
+			}
+		}
+		if len(bucket) == 0 {
+			continue
+		}
+
+		artifact, err := fw.writeSplitArtifact(bucket, dir, string(severity), "severity-"+string(severity), format)
+		if err != nil {
+			return artifacts, err
+		}
+		artifacts = append(artifacts, artifact)
+	}
+
+	return artifacts, nil
+}
+
+// WriteSplitByResourceType writes one report per resource type to dir, named
+// after the type with unsafe filename characters replaced (aws_instance.json,
+// aws_s3_bucket.json, ...). It returns the written files sorted by type.
+func (fw *FileWriter) WriteSplitByResourceType(results map[string]*interfaces.DriftResult, dir string, format ReportFormat) ([]Artifact, error) {
+	if results == nil {
+		return nil, NewReportError(ErrorTypeInvalidInput, "results cannot be nil")
+	}
+	if dir == "" {
+		return nil, NewReportError(ErrorTypeInvalidInput, "output directory cannot be empty")
+	}
+
+	byType := make(map[string]map[string]*interfaces.DriftResult)
+	for key, result := range results {
+		if result == nil {
+			continue
+		}
+		resourceType := resultResourceType(key, result)
+		if byType[resourceType] == nil {
+			byType[resourceType] = make(map[string]*interfaces.DriftResult)
+		}
+		byType[resourceType][key] = result
+	}
+
+	types := make([]string, 0, len(byType))
+	for resourceType := range byType {
+		types = append(types, resourceType)
+	}
+	sort.Strings(types)
+
+	written := make(map[string]string, len(types))
+	var artifacts []Artifact
+	for _, resourceType := range types {
+		name := sanitizeFileName(resourceType)
+		if other, ok := written[name]; ok {
+			return artifacts, NewReportErrorf(ErrorTypeFileOperation,
+				"resource types %q and %q map to the same file name %q", other, resourceType, name)
+		}
+		written[name] = resourceType
+
+		artifact, err := fw.writeSplitArtifact(byType[resourceType], dir, name, "resource-type-"+resourceType, format)
+		if err != nil {
+			return artifacts, err
+		}
+		artifacts = append(artifacts, artifact)
+	}
+
+	return artifacts, nil
+}
+
+// writeSplitArtifact writes one bucket of a split report to dir/name with the
+// format's extension and describes the written file
+func (fw *FileWriter) writeSplitArtifact(bucket map[string]*interfaces.DriftResult, dir, name, artifactType string, format ReportFormat) (Artifact, error) {
+	filePath := fw.getFilePathForFormat(filepath.Join(dir, name), format)
+	if err := fw.WriteReport(bucket, filePath, format); err != nil {
+		return Artifact{}, err
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return Artifact{}, WrapReportError(ErrorTypeFileOperation, "failed to stat split report", err)
+	}
+	return Artifact{
+		Path:            filePath,
+		Type:            artifactType,
+		Size:            info.Size(),
+		ContentEncoding: ContentEncoding(filePath),
+	}, nil
+}
+
+// resultResourceType returns the resource type of a result, parsing it from
+// the Terraform address in key when the result does not carry one. Module
+// and data source prefixes are skipped, so "module.app.aws_instance.web" is
+// an aws_instance.
+func resultResourceType(key string, result *interfaces.DriftResult) string {
+	if result.ResourceType != "" {
+		return result.ResourceType
+	}
+
+	parts := strings.Split(key, ".")
+	for len(parts) >= 2 && parts[0] == "module" {
+		parts = parts[2:]
+	}
+	if len(parts) >= 2 && parts[0] == "data" {
+		parts = parts[1:]
+	}
+	if len(parts) < 2 || parts[0] == "" {
+		return "unknown"
+	}
+	return parts[0]
+}
+
+// sanitizeFileName replaces characters other than letters, digits, '-', '_'
+// and '.' with '_' so name is safe to use as a file name
+func sanitizeFileName(name string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+	sanitized = strings.Trim(sanitized, ".")
+	if sanitized == "" {
+		return "unknown"
+	}
+	return sanitized
+}
+
 // getFilePathForFormat generates appropriate file path for each format
 func (fw *FileWriter) getFilePathForFormat(baseFilePath string, format ReportFormat) string {
 	ext := filepath.Ext(baseFilePath)
 	base := strings.TrimSuffix(baseFilePath, ext)
 
-	switch format {
-	case FormatJSON:
-		return base + ".json"
-	case FormatYAML:
-		return base + ".yaml"
-	case FormatTable, FormatConsole:
-		return base + ".txt"
-	case FormatCI:
-		return base + ".ci.json"
-	default:
-		return baseFilePath
+	path := baseFilePath
+	if extension := format.Extension(); extension != "" {
+		path = base + extension
+	}
+	if fw.compress() {
+		path += gzipExtension
 	}
+	return path
 }
 
 // addTimestampMetadata adds timestamp information to the content
@@ -220,6 +384,53 @@ func (aw *ArchiveWriter) CleanupOldReports(baseName string, maxAge time.Duration
 	return nil
 }
 
+// gzipExtension is appended to compressed report files
+const gzipExtension = ".gz"
+
+// ContentEncoding returns the HTTP Content-Encoding uploaders should set for
+// a report file: "gzip" for .gz files, otherwise ""
+func ContentEncoding(filePath string) string {
+	if strings.HasSuffix(filePath, gzipExtension) {
+		return "gzip"
+	}
+	return ""
+}
+
+// writeGzipFile writes content gzip-compressed to filePath
+func writeGzipFile(filePath string, content []byte) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(file)
+	if _, err := zw.Write(content); err != nil {
+		file.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// gzipFile replaces filePath with a gzip-compressed copy at filePath+".gz"
+// and returns the new path
+func gzipFile(filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	compressed := filePath + gzipExtension
+	if err := writeGzipFile(compressed, content); err != nil {
+		return "", err
+	}
+	if err := os.Remove(filePath); err != nil {
+		return "", err
+	}
+	return compressed, nil
+}
+
 // ReportUploader handles uploading reports to external systems
 type ReportUploader struct {
 	config *ReportConfig
@@ -245,12 +456,6 @@ func (ru *ReportUploader) UploadToGCS(filePath, bucket, object string) error {
 	return NewReportError(ErrorTypeNotImplemented, "GCS upload not implemented yet")
 }
 
-// SendToWebhook sends report data to a webhook endpoint (placeholder implementation)
-func (ru *ReportUploader) SendToWebhook(results map[string]*interfaces.DriftResult, webhookURL string) error {
-	// This would make HTTP POST request to webhook
-	return NewReportError(ErrorTypeNotImplemented, "webhook integration not implemented yet")
-}
-
 // FileRotator handles log rotation-style file management
 type FileRotator struct {
 	maxFiles int
@@ -1,8 +1,10 @@
 package report
 
 import (
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -235,14 +237,89 @@ func TestReportUploader_UploadToGCS(t *testing.T) {
 	assert.Contains(t, err.Error(), "not implemented")
 }
 
-func TestReportUploader_SendToWebhook(t *testing.T) {
-	uploader := NewReportUploader(NewReportConfig())
-	data := createTestReportData()
+func TestFileWriter_WriteSplitBySeverity(t *testing.T) {
+	results := createTestDriftResults()
+	delete(results, "aws_instance.web-server-1")
+	delete(results, "aws_db_instance.database")
+	dir := t.TempDir()
 
-	// Test placeholder implementation
-	err := uploader.SendToWebhook(data, "https://example.com/webhook")
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "not implemented")
+	artifacts, err := NewFileWriter(NewReportConfig()).WriteSplitBySeverity(results, dir, FormatJSON)
+	require.NoError(t, err)
+	require.Len(t, artifacts, 2)
+	assert.Equal(t, filepath.Join(dir, "critical.json"), artifacts[0].Path)
+	assert.Equal(t, filepath.Join(dir, "high.json"), artifacts[1].Path)
+
+	for _, artifact := range artifacts {
+		assert.Greater(t, artifact.Size, int64(0))
+	}
+
+	critical, err := os.ReadFile(filepath.Join(dir, "critical.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(critical), "aws_instance.web-server-2")
+	assert.NotContains(t, string(critical), "aws_lb.main")
+
+	high, err := os.ReadFile(filepath.Join(dir, "high.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(high), "aws_lb.main")
+	assert.NotContains(t, string(high), "aws_instance.web-server-2")
+
+	for _, empty := range []string{"medium.json", "low.json", "none.json"} {
+		_, err := os.Stat(filepath.Join(dir, empty))
+		assert.True(t, os.IsNotExist(err), "expected no file for empty bucket %s", empty)
+	}
+}
+
+func TestFileWriter_WriteSplitByResourceType(t *testing.T) {
+	results := createTestDriftResults()
+	results["module.storage.aws_s3_bucket.logs"] = &interfaces.DriftResult{
+		ResourceID: "logs-bucket",
+		IsDrifted:  true,
+		Severity:   interfaces.SeverityLow,
+		DriftDetails: []*interfaces.DriftDetail{
+			{Attribute: "versioning", ExpectedValue: true, ActualValue: false, Severity: interfaces.SeverityLow},
+		},
+	}
+	dir := t.TempDir()
+
+	artifacts, err := NewFileWriter(NewReportConfig()).WriteSplitByResourceType(results, dir, FormatJSON)
+	require.NoError(t, err)
+
+	var paths []string
+	for _, artifact := range artifacts {
+		paths = append(paths, artifact.Path)
+	}
+	assert.Equal(t, []string{
+		filepath.Join(dir, "aws_db_instance.json"),
+		filepath.Join(dir, "aws_instance.json"),
+		filepath.Join(dir, "aws_lb.json"),
+		filepath.Join(dir, "aws_s3_bucket.json"),
+	}, paths)
+	assert.Equal(t, "resource-type-aws_s3_bucket", artifacts[3].Type)
+
+	for _, artifact := range artifacts {
+		data, err := os.ReadFile(artifact.Path)
+		require.NoError(t, err)
+		var report ReportData
+		require.NoError(t, json.Unmarshal(data, &report))
+
+		resourceType := strings.TrimPrefix(artifact.Type, "resource-type-")
+		for key, result := range report.Results {
+			assert.Equal(t, resourceType, resultResourceType(key, result), "%s written to %s", key, artifact.Path)
+		}
+	}
+
+	instances, err := os.ReadFile(filepath.Join(dir, "aws_instance.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(instances), "aws_instance.web-server-1")
+	assert.Contains(t, string(instances), "aws_instance.web-server-2")
+	assert.NotContains(t, string(instances), "aws_lb.main")
+}
+
+func TestSanitizeFileName(t *testing.T) {
+	assert.Equal(t, "aws_instance", sanitizeFileName("aws_instance"))
+	assert.Equal(t, "_drift.reasonResource", sanitizeFileName("*drift.reasonResource"))
+	assert.Equal(t, "a_b_c", sanitizeFileName("a/b\\c"))
+	assert.Equal(t, "unknown", sanitizeFileName(".."))
 }
 
 func TestFileRotator_RotateIfNeeded(t *testing.T) {
@@ -489,3 +566,42 @@ func BenchmarkFileRotator_RotateIfNeeded(b *testing.B) {
 		}
 	}
 }
+
+func readGzipFile(t *testing.T, path string) []byte {
+	t.Helper()
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	zr, err := gzip.NewReader(file)
+	require.NoError(t, err)
+	content, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	return content
+}
+
+func TestFileWriter_WriteReportCompressed(t *testing.T) {
+	dir := t.TempDir()
+	results := createTestDriftResults()
+
+	writer := NewFileWriter(NewReportConfig().WithCompression(true))
+	require.NoError(t, writer.WriteReport(results, filepath.Join(dir, "report.json"), FormatJSON))
+
+	assert.NoFileExists(t, filepath.Join(dir, "report.json"))
+	content := readGzipFile(t, filepath.Join(dir, "report.json.gz"))
+	expected, err := NewStandardReportGenerator().GenerateJSONReport(results)
+	require.NoError(t, err)
+	var got, want map[string]interface{}
+	require.NoError(t, json.Unmarshal(content, &got))
+	require.NoError(t, json.Unmarshal(expected, &want))
+	delete(got, "timestamp")
+	delete(want, "timestamp")
+	assert.Equal(t, want, got)
+
+	// A .gz path compresses without the config flag
+	plain := NewFileWriter(NewReportConfig())
+	require.NoError(t, plain.WriteReport(results, filepath.Join(dir, "explicit.json.gz"), FormatJSON))
+	assert.True(t, json.Valid(readGzipFile(t, filepath.Join(dir, "explicit.json.gz"))))
+
+	assert.Equal(t, "gzip", ContentEncoding("report.json.gz"))
+	assert.Equal(t, "", ContentEncoding("report.json"))
+}
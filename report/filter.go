@@ -26,6 +26,8 @@ type FilterCriteria struct {
 	AttributeNames    []string
 	AttributePattern  *regexp.Regexp
 	ExcludeAttributes []string
+	// PinnedAttributes are always kept, even when other filters would drop them
+	PinnedAttributes []string
 
 	// Time filtering
 	After  *time.Time
@@ -126,22 +128,16 @@ func (fc *FilterCriteria) WithMinSeverity(severity interfaces.SeverityLevel) *Fi
 
 // WithResourcePattern sets the resource pattern
 func (fc *FilterCriteria) WithResourcePattern(pattern string) *FilterCriteria {
-	compiled, err := regexp.Compile(pattern)
-	if err != nil {
-		// A regex that will never match
-		compiled = regexp.MustCompile(`\b\B`)
-	}
+	// Invalid patterns compile to a regex that never matches
+	compiled, _ := compilePattern(pattern)
 	fc.ResourcePattern = compiled
 	return fc
 }
 
 // WithAttributePattern sets the attribute pattern
 func (fc *FilterCriteria) WithAttributePattern(pattern string) *FilterCriteria {
-	compiled, err := regexp.Compile(pattern)
-	if err != nil {
-		// A regex that will never match
-		compiled = regexp.MustCompile(`\b\B`)
-	}
+	// Invalid patterns compile to a regex that never matches
+	compiled, _ := compilePattern(pattern)
 	fc.AttributePattern = compiled
 	return fc
 }
@@ -158,6 +154,24 @@ func (fc *FilterCriteria) WithUntil(until time.Time) *FilterCriteria {
 	return fc
 }
 
+// ParseTimeBound parses a time window bound given either as a duration
+// relative to now (e.g. "1h", meaning one hour before now) or as an RFC3339
+// timestamp
+func ParseTimeBound(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, NewReportErrorf(ErrorTypeInvalidInput, "duration must not be negative: %s", value)
+		}
+		return now.Add(-d), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, NewReportErrorf(ErrorTypeInvalidInput, "invalid time %q: expected a duration like 1h or an RFC3339 timestamp", value)
+	}
+	return t, nil
+}
+
 // WithDriftStatus sets the drift status filter
 func (fc *FilterCriteria) WithDriftStatus(status DriftStatus) *FilterCriteria {
 	switch status {
@@ -176,7 +190,7 @@ func (fc *FilterCriteria) WithDriftStatus(status DriftStatus) *FilterCriteria {
 
 // WithValuePattern sets the value pattern filter
 func (fc *FilterCriteria) WithValuePattern(pattern string) *FilterCriteria {
-	if compiled, err := regexp.Compile(pattern); err == nil {
+	if compiled, ok := compilePattern(pattern); ok {
 		fc.ActualValuePattern = compiled
 	}
 	return fc
@@ -243,11 +257,8 @@ func (rf *ResultFilter) WithResourceTypes(resourceTypes ...string) *ResultFilter
 
 // WithResourcePattern filters by resource ID pattern
 func (rf *ResultFilter) WithResourcePattern(pattern string) *ResultFilter {
-	compiled, err := regexp.Compile(pattern)
-	if err != nil {
-		// A regex that will never match
-		compiled = regexp.MustCompile(`\b\B`)
-	}
+	// Invalid patterns compile to a regex that never matches
+	compiled, _ := compilePattern(pattern)
 	rf.criteria.ResourcePattern = compiled
 	return rf
 }
@@ -260,11 +271,8 @@ func (rf *ResultFilter) WithAttributeNames(attributeNames ...string) *ResultFilt
 
 // WithAttributePattern filters by attribute name pattern
 func (rf *ResultFilter) WithAttributePattern(pattern string) *ResultFilter {
-	compiled, err := regexp.Compile(pattern)
-	if err != nil {
-		// A regex that will never match
-		compiled = regexp.MustCompile(`\b\B`)
-	}
+	// Invalid patterns compile to a regex that never matches
+	compiled, _ := compilePattern(pattern)
 	rf.criteria.AttributePattern = compiled
 	return rf
 }
@@ -275,6 +283,12 @@ func (rf *ResultFilter) ExcludeAttributes(attributeNames ...string) *ResultFilte
 	return rf
 }
 
+// WithPinnedAttributes keeps differences in these attributes regardless of other filters
+func (rf *ResultFilter) WithPinnedAttributes(attributeNames ...string) *ResultFilter {
+	rf.criteria.PinnedAttributes = attributeNames
+	return rf
+}
+
 // WithTimeRange filters by time range
 func (rf *ResultFilter) WithTimeRange(after, before *time.Time) *ResultFilter {
 	rf.criteria.After = after
@@ -338,6 +352,23 @@ func (rf *ResultFilter) Apply(results map[string]*interfaces.DriftResult) []*int
 	return resultList
 }
 
+// ResultValidator checks a drift result for internal consistency, e.g.
+// drift.ValidateResult
+type ResultValidator func(result *interfaces.DriftResult) error
+
+// ApplyAndValidate applies the filter and checks every filtered result with
+// validate, so filters that leave results inconsistent (e.g. drifted with
+// every detail filtered out) fail loudly
+func (rf *ResultFilter) ApplyAndValidate(results map[string]*interfaces.DriftResult, validate ResultValidator) ([]*interfaces.DriftResult, error) {
+	filtered := rf.Apply(results)
+	for _, result := range filtered {
+		if err := validate(result); err != nil {
+			return filtered, WrapError(ErrorTypeFilterError, "filter produced an inconsistent result", err)
+		}
+	}
+	return filtered, nil
+}
+
 // matchesResourceCriteria checks if a result matches resource-level criteria
 func (rf *ResultFilter) matchesResourceCriteria(resourceKey string, result *interfaces.DriftResult) bool {
 	// Check drift status
@@ -441,7 +472,7 @@ func (rf *ResultFilter) filterDifferences(result *interfaces.DriftResult) *inter
 
 	// Filter differences
 	for _, diff := range result.DriftDetails {
-		if rf.matchesDifferenceCriteria(*diff) {
+		if rf.isPinned(diff.Attribute) || rf.matchesDifferenceCriteria(*diff) {
 			filteredResult.DriftDetails = append(filteredResult.DriftDetails, diff)
 		}
 	}
@@ -477,6 +508,16 @@ func (rf *ResultFilter) filterDifferences(result *interfaces.DriftResult) *inter
 	return filteredResult
 }
 
+// isPinned reports whether an attribute is always retained
+func (rf *ResultFilter) isPinned(attribute string) bool {
+	for _, pinned := range rf.criteria.PinnedAttributes {
+		if attribute == pinned {
+			return true
+		}
+	}
+	return false
+}
+
 // matchesDifferenceCriteria checks if a difference matches the filter criteria
 func (rf *ResultFilter) matchesDifferenceCriteria(diff interfaces.DriftDetail) bool {
 	// Check attribute names
@@ -1,10 +1,12 @@
 package report
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"firefly-task/pkg/interfaces"
 )
@@ -82,6 +84,27 @@ func TestResultFilter_Apply(t *testing.T) {
 	assert.Len(t, filtered, 1) // Only resources without drift
 }
 
+func TestResultFilter_ApplyAndValidate(t *testing.T) {
+	results := createTestDriftResults()
+	var validated []string
+	validate := func(result *interfaces.DriftResult) error {
+		validated = append(validated, result.ResourceID)
+		return nil
+	}
+
+	filtered, err := NewResultFilter().OnlyWithDrift().ApplyAndValidate(results, validate)
+	require.NoError(t, err)
+	assert.Len(t, filtered, 3)
+	assert.Len(t, validated, 3, "every filtered result should be validated")
+
+	inconsistent := func(result *interfaces.DriftResult) error {
+		return errors.New("drifted without details")
+	}
+	filtered, err = NewResultFilter().ApplyAndValidate(results, inconsistent)
+	assert.True(t, IsReportError(err, ErrorTypeFilterError))
+	assert.Len(t, filtered, 4)
+}
+
 func TestResultFilter_ApplyWithResourcePattern(t *testing.T) {
 	results := createTestDriftResults()
 
@@ -115,6 +138,26 @@ func TestResultFilter_ApplyWithAttributePattern(t *testing.T) {
 	assert.Len(t, filtered, 1) // Only one resource with security_groups difference
 }
 
+func TestResultFilter_PinnedAttributes(t *testing.T) {
+	results := createTestDriftResults()
+
+	// The name filter alone would drop the security_groups difference
+	filter := NewResultFilter().
+		WithAttributeNames("instance_type").
+		WithPinnedAttributes("security_groups")
+	filtered := filter.Apply(results)
+
+	attributes := make(map[string]bool)
+	for _, result := range filtered {
+		for _, detail := range result.DriftDetails {
+			attributes[detail.Attribute] = true
+		}
+	}
+	assert.True(t, attributes["instance_type"])
+	assert.True(t, attributes["security_groups"])
+	assert.False(t, attributes["publicly_accessible"])
+}
+
 func TestResultFilter_ApplyWithValuePattern(t *testing.T) {
 	results := createTestDriftResults()
 
@@ -0,0 +1,66 @@
+package report
+
+import (
+	"sort"
+	"sync"
+)
+
+// formatInfo describes how a report format is served and stored
+type formatInfo struct {
+	mimeType  string
+	extension string
+}
+
+// defaultMIMEType is used for formats without a registered MIME type
+const defaultMIMEType = "application/octet-stream"
+
+var (
+	formatRegistryMu sync.RWMutex
+	formatRegistry   = map[ReportFormat]formatInfo{
+		FormatJSON:    {mimeType: "application/json", extension: ".json"},
+		FormatYAML:    {mimeType: "application/yaml", extension: ".yaml"},
+		FormatTable:   {mimeType: "text/plain; charset=utf-8", extension: ".txt"},
+		FormatConsole: {mimeType: "text/plain; charset=utf-8", extension: ".txt"},
+		FormatCI:      {mimeType: "application/json", extension: ".ci.json"},
+	}
+)
+
+// RegisterFormat sets the MIME type and file extension for a report format,
+// replacing any existing registration
+func RegisterFormat(format ReportFormat, mimeType, extension string) {
+	formatRegistryMu.Lock()
+	defer formatRegistryMu.Unlock()
+	formatRegistry[format] = formatInfo{mimeType: mimeType, extension: extension}
+}
+
+// MIMEType returns the content type for the format, or
+// application/octet-stream if the format is not registered
+func (rf ReportFormat) MIMEType() string {
+	formatRegistryMu.RLock()
+	defer formatRegistryMu.RUnlock()
+	if info, ok := formatRegistry[rf]; ok {
+		return info.mimeType
+	}
+	return defaultMIMEType
+}
+
+// Extension returns the file extension for the format including the leading
+// dot, or an empty string if the format is not registered
+func (rf ReportFormat) Extension() string {
+	formatRegistryMu.RLock()
+	defer formatRegistryMu.RUnlock()
+	return formatRegistry[rf].extension
+}
+
+// supportedFormats lists the format names accepted by
+// ConcreteReportGenerator.GenerateCustomReport. It is the single list behind
+// ListSupportedFormats and ConcreteReportFactory.ListSupportedFormats.
+var supportedFormats = []string{"json", "yaml", "table", "html", "markdown", "sarif", "csv"}
+
+// ListSupportedFormats returns the names of the report formats that can be
+// generated, sorted
+func ListSupportedFormats() []string {
+	names := append([]string(nil), supportedFormats...)
+	sort.Strings(names)
+	return names
+}
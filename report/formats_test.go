@@ -0,0 +1,58 @@
+package report
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportFormat_MIMETypeAndExtension(t *testing.T) {
+	tests := []struct {
+		format    ReportFormat
+		mimeType  string
+		extension string
+	}{
+		{FormatJSON, "application/json", ".json"},
+		{FormatYAML, "application/yaml", ".yaml"},
+		{FormatTable, "text/plain; charset=utf-8", ".txt"},
+		{FormatConsole, "text/plain; charset=utf-8", ".txt"},
+		{FormatCI, "application/json", ".ci.json"},
+		{ReportFormat(999), "application/octet-stream", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			assert.Equal(t, tt.mimeType, tt.format.MIMEType())
+			assert.Equal(t, tt.extension, tt.format.Extension())
+		})
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	custom := ReportFormat(1000)
+	RegisterFormat(custom, "text/html; charset=utf-8", ".html")
+	defer func() {
+		formatRegistryMu.Lock()
+		delete(formatRegistry, custom)
+		formatRegistryMu.Unlock()
+	}()
+
+	assert.Equal(t, "text/html; charset=utf-8", custom.MIMEType())
+	assert.Equal(t, ".html", custom.Extension())
+	assert.Equal(t, "report.html", NewFileWriter(NewReportConfig()).getFilePathForFormat("report.json", custom))
+}
+
+func TestListSupportedFormats(t *testing.T) {
+	formats := ListSupportedFormats()
+	assert.Contains(t, formats, "json")
+	assert.Contains(t, formats, "yaml")
+	assert.Contains(t, formats, "sarif")
+	assert.Contains(t, formats, "csv")
+	assert.IsNonDecreasing(t, formats)
+
+	factoryFormats, err := NewConcreteReportFactory(logrus.New()).ListSupportedFormats(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, formats, factoryFormats)
+}
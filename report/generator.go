@@ -1,6 +1,10 @@
 package report
 
 import (
+	"fmt"
+	"sort"
+	"time"
+
 	"firefly-task/pkg/interfaces"
 )
 
@@ -57,6 +61,54 @@ type ReportConfig struct {
 
 	// ShowProgressIndicator shows progress for long operations
 	ShowProgressIndicator bool
+	// Verbose includes debugging details such as comparison traces
+	Verbose bool
+	// Locale selects the language for human-readable report strings
+	Locale string
+	// FloatPrecision rounds float values to this many decimals for display (optional)
+	FloatPrecision *int
+	// FailOnAttributes makes the CI exit code non-zero when any of these attributes drift
+	FailOnAttributes []string
+	// QuietWhenClean replaces human-readable reports with a one-line
+	// confirmation when no resource has drifted
+	QuietWhenClean bool
+	// HistoryPath points at a drift history file used to add a drift age
+	// section to console and markdown output
+	HistoryPath string
+	// PersistentRuns is the consecutive-run threshold for persistent drift in
+	// the drift age section (DefaultPersistentRuns when zero)
+	PersistentRuns int
+	// RelativePaths records CI artifact paths relative to ArtifactBaseDir
+	// (or the output directory when empty) so artifacts stay portable
+	RelativePaths   bool
+	ArtifactBaseDir string
+	// ShowExitBanner ends console reports with a banner stating whether the
+	// CI exit code will fail the build
+	ShowExitBanner bool
+	// AttributeDisplayOrder lists attributes to show first, in this order;
+	// the remaining attributes follow alphabetically
+	AttributeDisplayOrder []string
+	// Compress gzips report files and core CI artifacts, adding a .gz
+	// extension
+	Compress bool
+	// GroupByModule adds a per-module drift breakdown to console and
+	// markdown reports
+	GroupByModule bool
+	// ResourceTypeLabels maps raw resource types such as aws_db_instance to
+	// display labels; unlisted types are shown as is
+	ResourceTypeLabels map[string]string
+	// WebhookTimeout bounds each webhook request (DefaultWebhookTimeout when
+	// zero)
+	WebhookTimeout time.Duration
+	// WebhookMaxRetries is how many times a webhook request answered with a
+	// 5xx status is retried
+	WebhookMaxRetries int
+	// WebhookRetryBackoff is the wait before the first retry, doubled for each
+	// retry after it (DefaultWebhookRetryBackoff when zero)
+	WebhookRetryBackoff time.Duration
+	// WebhookSecret, when set, signs webhook bodies with HMAC-SHA256 in the
+	// WebhookSignatureHeader
+	WebhookSecret string
 }
 
 // ReportGenerator defines the interface for generating drift reports
@@ -123,6 +175,7 @@ func NewReportConfig() *ReportConfig {
 		FilterSeverity:         interfaces.SeverityNone,
 
 		ShowProgressIndicator:  false,
+		Locale:                 DefaultLocale,
 	}
 }
 
@@ -150,8 +203,187 @@ func (rc *ReportConfig) WithFilterSeverity(severity interfaces.SeverityLevel) *R
 	return rc
 }
 
+// WithVerbose enables or disables verbose output
+func (rc *ReportConfig) WithVerbose(verbose bool) *ReportConfig {
+	rc.Verbose = verbose
+	return rc
+}
+
+// WithLocale sets the locale used for report headers and status words
+func (rc *ReportConfig) WithLocale(locale string) *ReportConfig {
+	rc.Locale = locale
+	return rc
+}
+
+// WithFloatPrecision sets the number of decimals used to display float values
+func (rc *ReportConfig) WithFloatPrecision(precision int) *ReportConfig {
+	rc.FloatPrecision = &precision
+	return rc
+}
+
+// WithFailOnAttributes sets the attributes whose drift always fails the build
+func (rc *ReportConfig) WithFailOnAttributes(attributes []string) *ReportConfig {
+	rc.FailOnAttributes = attributes
+	return rc
+}
+
+// WithQuietWhenClean enables or disables one-line output for clean runs
+func (rc *ReportConfig) WithQuietWhenClean(quiet bool) *ReportConfig {
+	rc.QuietWhenClean = quiet
+	return rc
+}
+
+// WithDriftAge enables the drift age section using the given history file
+func (rc *ReportConfig) WithDriftAge(historyPath string, persistentRuns int) *ReportConfig {
+	rc.HistoryPath = historyPath
+	rc.PersistentRuns = persistentRuns
+	return rc
+}
+
+// WithRelativePaths records artifact paths relative to baseDir. An empty
+// baseDir uses the generator's output directory.
+func (rc *ReportConfig) WithRelativePaths(baseDir string) *ReportConfig {
+	rc.RelativePaths = true
+	rc.ArtifactBaseDir = baseDir
+	return rc
+}
+
+// WithExitBanner enables or disables the console exit banner
+func (rc *ReportConfig) WithExitBanner(enabled bool) *ReportConfig {
+	rc.ShowExitBanner = enabled
+	return rc
+}
+
+// WithAttributeDisplayOrder sets the attributes rendered first in reports
+func (rc *ReportConfig) WithAttributeDisplayOrder(attributes []string) *ReportConfig {
+	rc.AttributeDisplayOrder = attributes
+	return rc
+}
+
+// WithCompression enables or disables gzip compression of written reports
+func (rc *ReportConfig) WithCompression(compress bool) *ReportConfig {
+	rc.Compress = compress
+	return rc
+}
+
+// WithModuleGrouping enables or disables the per-module drift breakdown
+func (rc *ReportConfig) WithModuleGrouping(enabled bool) *ReportConfig {
+	rc.GroupByModule = enabled
+	return rc
+}
+
+// WithResourceTypeLabels sets the display labels for resource types
+func (rc *ReportConfig) WithResourceTypeLabels(labels map[string]string) *ReportConfig {
+	rc.ResourceTypeLabels = labels
+	return rc
+}
+
 // WithColorOutput enables or disables color output
 func (rc *ReportConfig) WithColorOutput(enabled bool) *ReportConfig {
 	rc.ColorOutput = enabled
 	return rc
-}
\ No newline at end of file
+}
+
+// maxFloatPrecision is the largest FloatPrecision accepted by Validate
+const maxFloatPrecision = 15
+
+// Validate checks the config for values no generator can honor
+func (rc *ReportConfig) Validate() error {
+	if rc.FloatPrecision != nil && (*rc.FloatPrecision < 0 || *rc.FloatPrecision > maxFloatPrecision) {
+		return NewReportErrorf(ErrorTypeConfiguration, "float precision must be between 0 and %d, got %d", maxFloatPrecision, *rc.FloatPrecision)
+	}
+	return nil
+}
+
+// quietCleanReport returns the one-line confirmation used instead of a full
+// report when QuietWhenClean is set and no resource has drifted
+func quietCleanReport(config *ReportConfig, results map[string]*interfaces.DriftResult) (string, bool) {
+	if config == nil || !config.QuietWhenClean {
+		return "", false
+	}
+	for _, result := range results {
+		if result != nil && result.IsDrifted {
+			return "", false
+		}
+	}
+	return fmt.Sprintf("%s %s (%d resources checked)\n",
+		localize(config, MsgNoDriftDetected), localize(config, MsgAllInSync), len(results)), true
+}
+
+// orderedDetails returns a copy of details in display order: attributes in
+// config.AttributeDisplayOrder first, in that order, then the rest
+// alphabetically
+func orderedDetails(details []*interfaces.DriftDetail, config *ReportConfig) []*interfaces.DriftDetail {
+	rank := make(map[string]int)
+	if config != nil {
+		for i, attr := range config.AttributeDisplayOrder {
+			if _, seen := rank[attr]; !seen {
+				rank[attr] = i
+			}
+		}
+	}
+
+	ordered := make([]*interfaces.DriftDetail, len(details))
+	copy(ordered, details)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		ra, aListed := rank[a.Attribute]
+		rb, bListed := rank[b.Attribute]
+		switch {
+		case aListed && bListed:
+			return ra < rb
+		case aListed != bListed:
+			return aListed
+		default:
+			return a.Attribute < b.Attribute
+		}
+	})
+	return ordered
+}
+
+// resourceTypeLabel returns the configured display label for resourceType,
+// or resourceType itself when it has none
+func resourceTypeLabel(config *ReportConfig, resourceType string) string {
+	if config != nil {
+		if label := config.ResourceTypeLabels[resourceType]; label != "" {
+			return label
+		}
+	}
+	return resourceType
+}
+
+// TypeCount is the number of drifted resources of one resource type
+type TypeCount struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// driftedByType counts drifted resources per resource type label, sorted by
+// label. Types that share a label are counted together.
+func driftedByType(config *ReportConfig, results map[string]*interfaces.DriftResult) []TypeCount {
+	counts := make(map[string]int)
+	for _, result := range results {
+		if result != nil && result.IsDrifted {
+			counts[resourceTypeLabel(config, result.ResourceType)]++
+		}
+	}
+
+	groups := make([]TypeCount, 0, len(counts))
+	for label, count := range counts {
+		groups = append(groups, TypeCount{Label: label, Count: count})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Label < groups[j].Label })
+	return groups
+}
+
+// resourceLabel returns the name shown in report headers for a result: its
+// display name when enriched from tags, otherwise key
+func resourceLabel(key string, result *interfaces.DriftResult) string {
+	if result != nil && result.DisplayName != "" {
+		return result.DisplayName
+	}
+	return key
+}
@@ -0,0 +1,81 @@
+package report
+
+import (
+	"encoding/json"
+	"sort"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// HeatmapData is a resource by attribute matrix of drift severity for
+// dashboard heatmaps. Cells[i][j] is the severity of Columns[j] drifting on
+// Rows[i]: 0 for no drift, then 1 (low) through 4 (critical).
+type HeatmapData struct {
+	Rows    []string `json:"rows"`
+	Columns []string `json:"columns"`
+	Cells   [][]int  `json:"cells"`
+}
+
+// GenerateHeatmapData builds the severity matrix for results. Rows are all
+// resources and columns are the union of drifted attributes, both sorted.
+func GenerateHeatmapData(results map[string]*interfaces.DriftResult) HeatmapData {
+	heatmap := HeatmapData{
+		Rows:    []string{},
+		Columns: []string{},
+		Cells:   [][]int{},
+	}
+
+	columnSet := make(map[string]bool)
+	for id, result := range results {
+		if result == nil {
+			continue
+		}
+		heatmap.Rows = append(heatmap.Rows, id)
+		if !result.IsDrifted {
+			continue
+		}
+		for _, detail := range result.DriftDetails {
+			if detail != nil {
+				columnSet[detail.Attribute] = true
+			}
+		}
+	}
+	for attr := range columnSet {
+		heatmap.Columns = append(heatmap.Columns, attr)
+	}
+	sort.Strings(heatmap.Rows)
+	sort.Strings(heatmap.Columns)
+
+	columnIndex := make(map[string]int, len(heatmap.Columns))
+	for i, attr := range heatmap.Columns {
+		columnIndex[attr] = i
+	}
+
+	for _, id := range heatmap.Rows {
+		row := make([]int, len(heatmap.Columns))
+		result := results[id]
+		if result.IsDrifted {
+			for _, detail := range result.DriftDetails {
+				if detail == nil {
+					continue
+				}
+				j := columnIndex[detail.Attribute]
+				if value := getSeverityOrder(detail.Severity); value > row[j] {
+					row[j] = value
+				}
+			}
+		}
+		heatmap.Cells = append(heatmap.Cells, row)
+	}
+
+	return heatmap
+}
+
+// JSON serializes the heatmap for visualization tools
+func (h HeatmapData) JSON() ([]byte, error) {
+	data, err := json.Marshal(h)
+	if err != nil {
+		return nil, WrapError(ErrorTypeMarshaling, "failed to marshal heatmap data", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,59 @@
+package report
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func TestGenerateHeatmapData(t *testing.T) {
+	detail := func(attr string, severity interfaces.SeverityLevel) *interfaces.DriftDetail {
+		return &interfaces.DriftDetail{Attribute: attr, Severity: severity}
+	}
+	results := map[string]*interfaces.DriftResult{
+		"web": {
+			ResourceID: "web",
+			IsDrifted:  true,
+			Severity:   interfaces.SeverityCritical,
+			DriftDetails: []*interfaces.DriftDetail{
+				detail("security_groups", interfaces.SeverityCritical),
+				detail("tags", interfaces.SeverityLow),
+			},
+		},
+		"api": {
+			ResourceID:   "api",
+			IsDrifted:    true,
+			Severity:     interfaces.SeverityMedium,
+			DriftDetails: []*interfaces.DriftDetail{detail("instance_type", interfaces.SeverityMedium)},
+		},
+		"db": {ResourceID: "db", Severity: interfaces.SeverityNone},
+	}
+
+	heatmap := GenerateHeatmapData(results)
+
+	assert.Equal(t, []string{"api", "db", "web"}, heatmap.Rows)
+	assert.Equal(t, []string{"instance_type", "security_groups", "tags"}, heatmap.Columns)
+	assert.Equal(t, [][]int{
+		{2, 0, 0},
+		{0, 0, 0},
+		{0, 4, 1},
+	}, heatmap.Cells)
+
+	data, err := heatmap.JSON()
+	require.NoError(t, err)
+	var decoded HeatmapData
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, heatmap, decoded)
+}
+
+func TestGenerateHeatmapData_Empty(t *testing.T) {
+	heatmap := GenerateHeatmapData(nil)
+
+	data, err := heatmap.JSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"rows": [], "columns": [], "cells": []}`, string(data))
+}
@@ -0,0 +1,244 @@
+package report
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"firefly-task/drift"
+	"firefly-task/pkg/interfaces"
+)
+
+// HistoryEntry is a single summary record in a drift history file
+type HistoryEntry struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Summary    CISummary `json:"summary"`
+	DriftScore int       `json:"drift_score"`
+	// DriftedResources lists the IDs of resources that drifted in the run. It
+	// is empty, not omitted, for clean runs.
+	DriftedResources []string `json:"drifted_resources"`
+}
+
+// historyLockTimeout bounds how long a writer waits for the history lock, and
+// historyLockStale is the age after which a lock left by a crashed process is
+// broken
+const (
+	historyLockTimeout = 10 * time.Second
+	historyLockStale   = time.Minute
+)
+
+// HistoryWriter appends run summaries to a JSONL history file and prunes
+// entries older than the configured retention. Writers in other processes
+// are excluded through a lock file next to the history file.
+type HistoryWriter struct {
+	path      string
+	retention time.Duration
+	mu        sync.Mutex
+}
+
+// NewHistoryWriter creates a HistoryWriter. A zero retention keeps all entries.
+func NewHistoryWriter(path string, retention time.Duration) *HistoryWriter {
+	return &HistoryWriter{
+		path:      path,
+		retention: retention,
+	}
+}
+
+// Record summarizes the results and appends them to the history file
+func (hw *HistoryWriter) Record(results map[string]*interfaces.DriftResult) (*HistoryEntry, error) {
+	if results == nil {
+		return nil, NewReportError(ErrorTypeInvalidInput, "results cannot be nil")
+	}
+
+	entry := HistoryEntry{
+		Timestamp:  time.Now().UTC(),
+		Summary:    NewCIReportGenerator().buildCISummary(results),
+		DriftScore: DriftScore(results),
+	}
+	entry.DriftedResources = make([]string, 0)
+	for id, result := range results {
+		if result != nil && result.IsDrifted {
+			entry.DriftedResources = append(entry.DriftedResources, id)
+		}
+	}
+	sort.Strings(entry.DriftedResources)
+
+	if err := hw.Append(entry); err != nil {
+		return nil, err
+	}
+
+	if hw.retention > 0 {
+		if err := hw.Prune(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &entry, nil
+}
+
+// Append writes a single entry to the end of the history file. Each entry is
+// written with one O_APPEND write so concurrent writers never interleave lines.
+func (hw *HistoryWriter) Append(entry HistoryEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return WrapError(ErrorTypeMarshaling, "failed to marshal history entry", err)
+	}
+	line = append(line, '\n')
+
+	hw.mu.Lock()
+	defer hw.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(hw.path), 0755); err != nil {
+		return WrapReportError(ErrorTypeFileOperation, "failed to create history directory", err)
+	}
+
+	unlock, err := lockHistory(hw.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	file, err := os.OpenFile(hw.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return WrapReportError(ErrorTypeFileOperation, "failed to open history file", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(line); err != nil {
+		return WrapReportError(ErrorTypeFileOperation, "failed to append history entry", err)
+	}
+
+	return nil
+}
+
+// Prune removes entries older than the retention period. The file is
+// rewritten to a temporary file and renamed into place while holding the
+// history lock, so concurrent appends are not lost.
+func (hw *HistoryWriter) Prune() error {
+	if hw.retention <= 0 {
+		return nil
+	}
+
+	hw.mu.Lock()
+	defer hw.mu.Unlock()
+
+	if _, err := os.Stat(hw.path); os.IsNotExist(err) {
+		return nil
+	}
+
+	unlock, err := lockHistory(hw.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, err := ReadHistory(hw.path)
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-hw.retention)
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		if entry.Timestamp.Before(cutoff) {
+			continue
+		}
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return WrapError(ErrorTypeMarshaling, "failed to marshal history entry", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(hw.path), filepath.Base(hw.path)+".*.tmp")
+	if err != nil {
+		return WrapReportError(ErrorTypeFileOperation, "failed to create pruned history", err)
+	}
+	tmpPath := tmp.Name()
+	_, err = tmp.Write(buf.Bytes())
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, 0644)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return WrapReportError(ErrorTypeFileOperation, "failed to write pruned history", err)
+	}
+	if err := os.Rename(tmpPath, hw.path); err != nil {
+		os.Remove(tmpPath)
+		return WrapReportError(ErrorTypeFileOperation, "failed to replace history file", err)
+	}
+
+	return nil
+}
+
+// lockHistory takes the lock file guarding path and returns the function that
+// releases it. A lock older than historyLockStale is assumed abandoned.
+func lockHistory(path string) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(historyLockTimeout)
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, WrapReportError(ErrorTypeFileOperation, "failed to lock history file", err)
+		}
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > historyLockStale {
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, NewReportErrorf(ErrorTypeFileOperation, "timed out waiting for history lock %s", lockPath)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// ReadHistory reads all entries from a JSONL history file. A missing file
+// yields an empty history.
+func ReadHistory(path string) ([]HistoryEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, WrapReportError(ErrorTypeFileOperation, "failed to open history file", err)
+	}
+	defer file.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var entry HistoryEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, WrapError(ErrorTypeMarshaling, "failed to parse history entry", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, WrapReportError(ErrorTypeFileOperation, "failed to read history file", err)
+	}
+
+	return entries, nil
+}
+
+// DriftScore sums the default severity-weighted drift score of every result
+func DriftScore(results map[string]*interfaces.DriftResult) int {
+	return drift.ComputeBatchDriftScore(results, nil, nil)
+}
@@ -0,0 +1,109 @@
+package report
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryWriter_Record(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history", "drift.jsonl")
+	writer := NewHistoryWriter(path, 0)
+
+	entry, err := writer.Record(createTestDriftResults())
+	require.NoError(t, err)
+	assert.Equal(t, 3, entry.Summary.ResourcesWithDrift)
+	// medium (2) + critical (10) + high (5)
+	assert.Equal(t, 17, entry.DriftScore)
+
+	entries, err := ReadHistory(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, entry.DriftScore, entries[0].DriftScore)
+	assert.Len(t, entries[0].DriftedResources, 3)
+}
+
+func TestHistoryWriter_Prune(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "drift.jsonl")
+	writer := NewHistoryWriter(path, 24*time.Hour)
+
+	now := time.Now().UTC()
+	for _, age := range []time.Duration{72 * time.Hour, 48 * time.Hour, 2 * time.Hour, time.Hour} {
+		require.NoError(t, writer.Append(HistoryEntry{Timestamp: now.Add(-age), DriftScore: int(age.Hours())}))
+	}
+
+	require.NoError(t, writer.Prune())
+
+	entries, err := ReadHistory(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, 2, entries[0].DriftScore)
+	assert.Equal(t, 1, entries[1].DriftScore)
+}
+
+func TestHistoryWriter_ConcurrentAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "drift.jsonl")
+	writer := NewHistoryWriter(path, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, writer.Append(HistoryEntry{Timestamp: time.Now(), DriftScore: i}))
+		}(i)
+	}
+	wg.Wait()
+
+	entries, err := ReadHistory(path)
+	require.NoError(t, err)
+	assert.Len(t, entries, 20)
+}
+
+func TestHistoryWriter_PruneKeepsConcurrentAppends(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "drift.jsonl")
+	// Separate writers share only the file, like separate processes
+	appender := NewHistoryWriter(path, 0)
+	pruner := NewHistoryWriter(path, 24*time.Hour)
+	require.NoError(t, appender.Append(HistoryEntry{Timestamp: time.Now().Add(-72 * time.Hour)}))
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				assert.NoError(t, pruner.Prune())
+			}
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		require.NoError(t, appender.Append(HistoryEntry{Timestamp: time.Now(), DriftScore: i}))
+	}
+	close(done)
+	wg.Wait()
+	require.NoError(t, pruner.Prune())
+
+	entries, err := ReadHistory(path)
+	require.NoError(t, err)
+	assert.Equal(t, 100, len(entries))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{path}, files, "temporary and lock files should be removed")
+}
+
+func TestReadHistory_MissingFile(t *testing.T) {
+	entries, err := ReadHistory(filepath.Join(t.TempDir(), "missing.jsonl"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,121 @@
+package report
+
+import "sort"
+
+// DefaultLocale is used when no locale is configured or a message is missing
+const DefaultLocale = "en"
+
+// Message keys for localized report strings
+const (
+	MsgSummary            = "summary"
+	MsgTotalResources     = "total_resources"
+	MsgResourcesWithDrift = "resources_with_drift"
+	MsgTotalDifferences   = "total_differences"
+	MsgSeverityBreakdown  = "severity_breakdown"
+	MsgNoDriftDetected    = "no_drift_detected"
+	MsgDetailedResults    = "detailed_results"
+	MsgResultsBySeverity  = "results_by_severity"
+	MsgCritical           = "critical"
+	MsgHigh               = "high"
+	MsgMedium             = "medium"
+	MsgLow                = "low"
+	MsgReportTitle        = "report_title"
+	MsgResult             = "result"
+	MsgAllInSync          = "all_in_sync"
+	MsgActionRequired     = "action_required"
+	MsgActionAdvice       = "action_advice"
+)
+
+// messageCatalog maps locale to message key to translated text
+var messageCatalog = map[string]map[string]string{
+	"en": {
+		MsgSummary:            "Summary",
+		MsgTotalResources:     "Total Resources",
+		MsgResourcesWithDrift: "Resources with Drift",
+		MsgTotalDifferences:   "Total Differences",
+		MsgSeverityBreakdown:  "Severity Breakdown",
+		MsgNoDriftDetected:    "No drift detected!",
+		MsgDetailedResults:    "Detailed Results",
+		MsgResultsBySeverity:  "Results by Severity",
+		MsgCritical:           "Critical",
+		MsgHigh:               "High",
+		MsgMedium:             "Medium",
+		MsgLow:                "Low",
+		MsgReportTitle:        "Terraform Drift Detection Summary",
+		MsgResult:             "Result",
+		MsgAllInSync:          "All resources are in sync.",
+		MsgActionRequired:     "Action Required",
+		MsgActionAdvice:       "Drift detected in infrastructure. Review the detailed report and consider running `terraform plan` and `terraform apply`.",
+	},
+	"es": {
+		MsgSummary:            "Resumen",
+		MsgTotalResources:     "Recursos totales",
+		MsgResourcesWithDrift: "Recursos con desviación",
+		MsgTotalDifferences:   "Diferencias totales",
+		MsgSeverityBreakdown:  "Desglose por severidad",
+		MsgNoDriftDetected:    "¡No se detectó desviación!",
+		MsgDetailedResults:    "Resultados detallados",
+		MsgResultsBySeverity:  "Resultados por severidad",
+		MsgCritical:           "Crítica",
+		MsgHigh:               "Alta",
+		MsgMedium:             "Media",
+		MsgLow:                "Baja",
+		MsgReportTitle:        "Resumen de detección de desviaciones de Terraform",
+		MsgResult:             "Resultado",
+		MsgAllInSync:          "Todos los recursos están sincronizados.",
+		MsgActionRequired:     "Acción requerida",
+		MsgActionAdvice:       "Se detectó desviación en la infraestructura. Revise el informe detallado y considere ejecutar `terraform plan` y `terraform apply`.",
+	},
+	"fr": {
+		MsgSummary:            "Résumé",
+		MsgTotalResources:     "Ressources totales",
+		MsgResourcesWithDrift: "Ressources en dérive",
+		MsgTotalDifferences:   "Différences totales",
+		MsgSeverityBreakdown:  "Répartition par sévérité",
+		MsgNoDriftDetected:    "Aucune dérive détectée !",
+		MsgDetailedResults:    "Résultats détaillés",
+		MsgResultsBySeverity:  "Résultats par sévérité",
+		MsgCritical:           "Critique",
+		MsgHigh:               "Élevée",
+		MsgMedium:             "Moyenne",
+		MsgLow:                "Faible",
+		MsgReportTitle:        "Résumé de la détection de dérive Terraform",
+		MsgResult:             "Résultat",
+		MsgAllInSync:          "Toutes les ressources sont synchronisées.",
+		MsgActionRequired:     "Action requise",
+		MsgActionAdvice:       "Dérive détectée dans l'infrastructure. Consultez le rapport détaillé et envisagez d'exécuter `terraform plan` puis `terraform apply`.",
+	},
+}
+
+// Translate returns the message for key in the given locale, falling back to
+// English and finally to the key itself
+func Translate(locale, key string) string {
+	if messages, ok := messageCatalog[locale]; ok {
+		if msg, ok := messages[key]; ok {
+			return msg
+		}
+	}
+	if msg, ok := messageCatalog[DefaultLocale][key]; ok {
+		return msg
+	}
+	return key
+}
+
+// SupportedLocales returns the locales available in the message catalog
+func SupportedLocales() []string {
+	locales := make([]string, 0, len(messageCatalog))
+	for locale := range messageCatalog {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// localize translates key using the locale from config
+func localize(config *ReportConfig, key string) string {
+	locale := DefaultLocale
+	if config != nil && config.Locale != "" {
+		locale = config.Locale
+	}
+	return Translate(locale, key)
+}
@@ -0,0 +1,56 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func TestTranslate(t *testing.T) {
+	assert.Equal(t, "Summary", Translate("en", MsgSummary))
+	assert.Equal(t, "Resumen", Translate("es", MsgSummary))
+	// Unknown locales and keys fall back to English, then to the key
+	assert.Equal(t, "Summary", Translate("xx", MsgSummary))
+	assert.Equal(t, "missing_key", Translate("es", "missing_key"))
+	assert.Equal(t, []string{"en", "es", "fr"}, SupportedLocales())
+}
+
+func TestConsoleReportGenerator_Locale(t *testing.T) {
+	results := createTestDriftResults()
+
+	generator := NewConsoleReportGenerator()
+	generator.WithConfig(NewReportConfig().WithColorOutput(false).WithLocale("es"))
+
+	output, err := generator.GenerateConsoleReport(results)
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "RESUMEN:")
+	assert.Contains(t, output, "Recursos totales: 4")
+	assert.Contains(t, output, "DESGLOSE POR SEVERIDAD:")
+	assert.Contains(t, output, "Crítica: 1")
+	assert.Contains(t, output, "RESULTADOS DETALLADOS:")
+	assert.NotContains(t, output, "SUMMARY:")
+
+	clean, err := generator.GenerateConsoleReport(map[string]*interfaces.DriftResult{})
+	require.NoError(t, err)
+	assert.Contains(t, clean, "¡No se detectó desviación!")
+}
+
+func TestCIReportGenerator_MarkdownSummaryLocale(t *testing.T) {
+	generator := NewCIReportGeneratorWithConfig(NewReportConfig().WithLocale("fr"), PlatformGeneric, t.TempDir())
+
+	md, err := generator.generateMarkdownSummary(createTestDriftResults())
+	require.NoError(t, err)
+	assert.Contains(t, md, "# Résumé de la détection de dérive Terraform")
+	assert.Contains(t, md, "**Ressources totales**: 4")
+	assert.Contains(t, md, "## ⚠️ Action requise")
+
+	english := NewCIReportGenerator()
+	md, err = english.generateMarkdownSummary(createTestDriftResults())
+	require.NoError(t, err)
+	assert.Contains(t, md, "# Terraform Drift Detection Summary")
+	assert.Contains(t, md, "- **Total Resources**: 4")
+}
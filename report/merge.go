@@ -0,0 +1,124 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"firefly-task/pkg/interfaces"
+	"firefly-task/pkg/logging"
+)
+
+// DuplicatePolicy controls how merging handles a resource ID present in more than one file
+type DuplicatePolicy int
+
+const (
+	// DuplicateError fails the merge when a resource ID appears in more than one file
+	DuplicateError DuplicatePolicy = iota
+	// DuplicateWarn keeps the result from the last file, logs a warning and
+	// records each duplicate ID once in the merged report metadata
+	DuplicateWarn
+)
+
+// MergeOptions configures MergeReportFilesWithOptions
+type MergeOptions struct {
+	DuplicatePolicy DuplicatePolicy
+}
+
+// MergeReportFiles merges several JSON drift reports into a single report,
+// failing on duplicate resource IDs
+func MergeReportFiles(paths []string, out string) error {
+	return MergeReportFilesWithOptions(paths, out, MergeOptions{DuplicatePolicy: DuplicateError})
+}
+
+// MergeReportFilesWithOptions merges several JSON drift reports into a single
+// report with a recomputed summary. Inputs may be either a bare
+// map[string]*interfaces.DriftResult or a full ReportData document.
+func MergeReportFilesWithOptions(paths []string, out string, opts MergeOptions) error {
+	if len(paths) == 0 {
+		return NewReportError(ErrorTypeInvalidInput, "no report files to merge")
+	}
+	if out == "" {
+		return NewReportError(ErrorTypeInvalidInput, "output path cannot be empty")
+	}
+
+	merged := make(map[string]*interfaces.DriftResult)
+	sources := make(map[string]string)
+	duplicateSet := make(map[string]bool)
+
+	for _, path := range paths {
+		results, err := readReportResults(path)
+		if err != nil {
+			return err
+		}
+
+		for id, result := range results {
+			if previous, exists := sources[id]; exists {
+				if opts.DuplicatePolicy == DuplicateError {
+					return NewReportErrorf(ErrorTypeInvalidInput, "duplicate resource %s in %s and %s", id, previous, path).
+						WithContext("resource_id", id)
+				}
+				duplicateSet[id] = true
+			}
+			merged[id] = result
+			sources[id] = path
+		}
+	}
+
+	generator := NewStandardReportGenerator()
+	reportData := generator.buildReportData(merged)
+	reportData.Metadata["merged_from"] = paths
+	if len(duplicateSet) > 0 {
+		duplicates := make([]string, 0, len(duplicateSet))
+		for id := range duplicateSet {
+			duplicates = append(duplicates, id)
+		}
+		sort.Strings(duplicates)
+		logging.Warn("Merged reports contain duplicate resources, keeping the last file's result",
+			"resource_ids", duplicates)
+		reportData.Metadata["duplicate_resources"] = duplicates
+	}
+
+	data, err := json.MarshalIndent(reportData, "", "  ")
+	if err != nil {
+		return WrapError(ErrorTypeMarshaling, "failed to marshal merged report", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+		return WrapReportError(ErrorTypeFileOperation, "failed to create directory", err)
+	}
+
+	if err := os.WriteFile(out, data, 0644); err != nil {
+		return WrapReportError(ErrorTypeFileOperation, "failed to write merged report", err)
+	}
+
+	return nil
+}
+
+// readReportResults reads drift results from a JSON report file
+func readReportResults(path string) (map[string]*interfaces.DriftResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, WrapReportError(ErrorTypeFileOperation, fmt.Sprintf("failed to read %s", path), err)
+	}
+	return parseReportResults(data, path)
+}
+
+// parseReportResults decodes drift results from a JSON report, accepting
+// either a full ReportData document or a bare result map. source names the
+// report in errors.
+func parseReportResults(data []byte, source string) (map[string]*interfaces.DriftResult, error) {
+	var reportData ReportData
+	if err := json.Unmarshal(data, &reportData); err == nil && reportData.Results != nil {
+		return reportData.Results, nil
+	}
+
+	var results map[string]*interfaces.DriftResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, WrapError(ErrorTypeMarshaling, fmt.Sprintf("failed to parse %s", source), err)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,111 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func writeResultsFile(t *testing.T, path string, results map[string]*interfaces.DriftResult) {
+	t.Helper()
+	data, err := json.Marshal(results)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0644))
+}
+
+func TestMergeReportFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	all := createTestDriftResults()
+
+	first := filepath.Join(tempDir, "shard-1.json")
+	second := filepath.Join(tempDir, "shard-2.json")
+	writeResultsFile(t, first, map[string]*interfaces.DriftResult{
+		"aws_instance.web-server-1": all["aws_instance.web-server-1"],
+		"aws_lb.main":               all["aws_lb.main"],
+	})
+	writeResultsFile(t, second, map[string]*interfaces.DriftResult{
+		"aws_instance.web-server-2": all["aws_instance.web-server-2"],
+		"aws_db_instance.database":  all["aws_db_instance.database"],
+	})
+
+	out := filepath.Join(tempDir, "merged", "report.json")
+	require.NoError(t, MergeReportFiles([]string{first, second}, out))
+
+	content, err := os.ReadFile(out)
+	require.NoError(t, err)
+
+	var merged ReportData
+	require.NoError(t, json.Unmarshal(content, &merged))
+	assert.Len(t, merged.Results, 4)
+	assert.Equal(t, 4, merged.Summary.TotalResources)
+	assert.Equal(t, 3, merged.Summary.ResourcesWithDrift)
+
+	// The merged output can itself be merged again
+	again := filepath.Join(tempDir, "again.json")
+	require.NoError(t, MergeReportFiles([]string{out}, again))
+}
+
+func TestMergeReportFiles_Duplicates(t *testing.T) {
+	tempDir := t.TempDir()
+	all := createTestDriftResults()
+
+	first := filepath.Join(tempDir, "shard-1.json")
+	second := filepath.Join(tempDir, "shard-2.json")
+	writeResultsFile(t, first, map[string]*interfaces.DriftResult{
+		"aws_instance.web-server-1": all["aws_instance.web-server-1"],
+		"aws_lb.main":               all["aws_lb.main"],
+	})
+	writeResultsFile(t, second, map[string]*interfaces.DriftResult{
+		"aws_lb.main":              all["aws_instance.web-server-2"],
+		"aws_db_instance.database": all["aws_db_instance.database"],
+	})
+
+	out := filepath.Join(tempDir, "merged.json")
+
+	err := MergeReportFiles([]string{first, second}, out)
+	require.Error(t, err)
+	assert.True(t, IsReportError(err, ErrorTypeInvalidInput))
+	assert.Contains(t, err.Error(), "aws_lb.main")
+	_, statErr := os.Stat(out)
+	assert.True(t, os.IsNotExist(statErr))
+
+	err = MergeReportFilesWithOptions([]string{first, second}, out, MergeOptions{DuplicatePolicy: DuplicateWarn})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(out)
+	require.NoError(t, err)
+
+	var merged ReportData
+	require.NoError(t, json.Unmarshal(content, &merged))
+	assert.Len(t, merged.Results, 3)
+	assert.Equal(t, interfaces.SeverityCritical, merged.Results["aws_lb.main"].Severity)
+	assert.Equal(t, []interface{}{"aws_lb.main"}, merged.Metadata["duplicate_resources"])
+
+	// An ID repeated across three files is still listed once
+	third := filepath.Join(tempDir, "shard-3.json")
+	writeResultsFile(t, third, map[string]*interfaces.DriftResult{
+		"aws_lb.main": all["aws_lb.main"],
+	})
+	err = MergeReportFilesWithOptions([]string{first, second, third}, out, MergeOptions{DuplicatePolicy: DuplicateWarn})
+	require.NoError(t, err)
+
+	content, err = os.ReadFile(out)
+	require.NoError(t, err)
+	merged = ReportData{}
+	require.NoError(t, json.Unmarshal(content, &merged))
+	assert.Equal(t, []interface{}{"aws_lb.main"}, merged.Metadata["duplicate_resources"])
+}
+
+func TestMergeReportFiles_InvalidInput(t *testing.T) {
+	err := MergeReportFiles(nil, "out.json")
+	assert.True(t, IsReportError(err, ErrorTypeInvalidInput))
+
+	err = MergeReportFiles([]string{filepath.Join(t.TempDir(), "missing.json")}, filepath.Join(t.TempDir(), "out.json"))
+	assert.True(t, IsReportError(err, ErrorTypeFileOperation))
+}
@@ -0,0 +1,118 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"firefly-task/pkg/interfaces"
+	"firefly-task/terraform"
+)
+
+// ModuleGroup is the drift rollup for the resources of one Terraform module
+type ModuleGroup struct {
+	// Module is the module path, e.g. "module.app.module.db", or "root"
+	Module           string                   `json:"module"`
+	TotalResources   int                      `json:"total_resources"`
+	DriftedResources int                      `json:"drifted_resources"`
+	HighestSeverity  interfaces.SeverityLevel `json:"highest_severity"`
+	// SeverityCounts counts drifted resources by severity
+	SeverityCounts map[interfaces.SeverityLevel]int `json:"severity_counts"`
+	// Drifted lists the keys of drifted resources in the module, sorted
+	Drifted []string `json:"drifted"`
+}
+
+// resultModule returns the module path of a result from its key, falling
+// back to its resource ID. Results without a parsable address belong to the
+// root module.
+func resultModule(key string, result *interfaces.DriftResult) string {
+	for _, candidate := range []string{key, result.ResourceID} {
+		if address, err := terraform.ParseResourceAddress(candidate); err == nil {
+			return address.ModulePath()
+		}
+	}
+	return terraform.RootModule
+}
+
+// GroupByModule groups results by the module path in their Terraform address
+// so drift can be routed to the team owning each module. The root module
+// comes first, then modules in name order. Nil results are skipped.
+func GroupByModule(results map[string]*interfaces.DriftResult) []ModuleGroup {
+	groups := make(map[string]*ModuleGroup)
+	for key, result := range results {
+		if result == nil {
+			continue
+		}
+		module := resultModule(key, result)
+		group, ok := groups[module]
+		if !ok {
+			group = &ModuleGroup{
+				Module:          module,
+				HighestSeverity: interfaces.SeverityNone,
+				SeverityCounts:  make(map[interfaces.SeverityLevel]int),
+				Drifted:         []string{},
+			}
+			groups[module] = group
+		}
+
+		group.TotalResources++
+		if !result.IsDrifted {
+			continue
+		}
+		group.DriftedResources++
+		group.SeverityCounts[result.Severity]++
+		group.Drifted = append(group.Drifted, key)
+		if getSeverityOrder(result.Severity) > getSeverityOrder(group.HighestSeverity) {
+			group.HighestSeverity = result.Severity
+		}
+	}
+
+	sorted := make([]ModuleGroup, 0, len(groups))
+	for _, group := range groups {
+		sort.Strings(group.Drifted)
+		sorted = append(sorted, *group)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i].Module, sorted[j].Module
+		if (a == terraform.RootModule) != (b == terraform.RootModule) {
+			return a == terraform.RootModule
+		}
+		return a < b
+	})
+	return sorted
+}
+
+// markdownModuleGroups renders module groups as a markdown table
+func markdownModuleGroups(groups []ModuleGroup) string {
+	var md strings.Builder
+	md.WriteString("\n## Drift by Module\n\n| Module | Drifted | Total | Highest Severity |\n|---|---|---|---|\n")
+	for _, group := range groups {
+		md.WriteString(fmt.Sprintf("| `%s` | %d | %d | %s |\n",
+			group.Module, group.DriftedResources, group.TotalResources, strings.ToUpper(string(group.HighestSeverity))))
+	}
+	return md.String()
+}
+
+// generateModuleGroups renders the per-module drift breakdown for the console
+func (crg *ConsoleReportGenerator) generateModuleGroups(results map[string]*interfaces.DriftResult) string {
+	groups := GroupByModule(results)
+	if len(groups) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+	builder.WriteString(crg.colorize("\n📦 Drift by Module:\n", ColorBold+ColorWhite))
+	for _, group := range groups {
+		line := fmt.Sprintf("\n   %s: %d of %d resources drifted", group.Module, group.DriftedResources, group.TotalResources)
+		if group.DriftedResources == 0 {
+			builder.WriteString(crg.colorize(line, ColorGreen) + "\n")
+			continue
+		}
+		line += fmt.Sprintf(" (highest: %s)", strings.ToUpper(string(group.HighestSeverity)))
+		builder.WriteString(crg.colorize(line, crg.getSeverityColor(group.HighestSeverity)+ColorBold) + "\n")
+		for _, key := range group.Drifted {
+			builder.WriteString(fmt.Sprintf("     • %s\n", key))
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,73 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func moduleTestResults() map[string]*interfaces.DriftResult {
+	drifted := func(id string, severity interfaces.SeverityLevel) *interfaces.DriftResult {
+		return &interfaces.DriftResult{
+			ResourceID:   id,
+			IsDrifted:    true,
+			Severity:     severity,
+			DriftDetails: []*interfaces.DriftDetail{{Attribute: "tags", Severity: severity}},
+		}
+	}
+	return map[string]*interfaces.DriftResult{
+		"module.network.aws_instance.nat":           drifted("i-1", interfaces.SeverityMedium),
+		"module.network.aws_instance.bastion":       drifted("i-2", interfaces.SeverityCritical),
+		`module.app["eu.west"].aws_instance.web[0]`: drifted("i-3", interfaces.SeverityHigh),
+		`module.app["eu.west"].aws_instance.web[1]`: {ResourceID: "i-4"},
+		"aws_instance.standalone":                   {ResourceID: "i-5"},
+	}
+}
+
+func TestGroupByModule(t *testing.T) {
+	groups := GroupByModule(moduleTestResults())
+	require.Len(t, groups, 3)
+
+	assert.Equal(t, "root", groups[0].Module)
+	assert.Equal(t, 1, groups[0].TotalResources)
+	assert.Equal(t, 0, groups[0].DriftedResources)
+	assert.Equal(t, interfaces.SeverityNone, groups[0].HighestSeverity)
+
+	assert.Equal(t, `module.app["eu.west"]`, groups[1].Module)
+	assert.Equal(t, 2, groups[1].TotalResources)
+	assert.Equal(t, 1, groups[1].DriftedResources)
+	assert.Equal(t, interfaces.SeverityHigh, groups[1].HighestSeverity)
+
+	assert.Equal(t, "module.network", groups[2].Module)
+	assert.Equal(t, 2, groups[2].TotalResources)
+	assert.Equal(t, 2, groups[2].DriftedResources)
+	assert.Equal(t, interfaces.SeverityCritical, groups[2].HighestSeverity)
+	assert.Equal(t, map[interfaces.SeverityLevel]int{interfaces.SeverityCritical: 1, interfaces.SeverityMedium: 1}, groups[2].SeverityCounts)
+	assert.Equal(t, []string{"module.network.aws_instance.bastion", "module.network.aws_instance.nat"}, groups[2].Drifted)
+}
+
+func TestModuleGroupingRendering(t *testing.T) {
+	config := NewReportConfig().WithColorOutput(false).WithModuleGrouping(true)
+
+	console := NewConsoleReportGenerator()
+	console.WithConfig(config)
+	output, err := console.GenerateConsoleReport(moduleTestResults())
+	require.NoError(t, err)
+	assert.Contains(t, output, "Drift by Module")
+	assert.Contains(t, output, "module.network: 2 of 2 resources drifted (highest: CRITICAL)")
+	assert.Contains(t, output, "root: 0 of 1 resources drifted")
+
+	ci := NewCIReportGeneratorWithConfig(config, PlatformGeneric, t.TempDir())
+	md, err := ci.generateMarkdownSummary(moduleTestResults())
+	require.NoError(t, err)
+	assert.Contains(t, md, "## Drift by Module")
+	assert.Contains(t, md, "| `module.network` | 2 | 2 | CRITICAL |")
+	assert.Contains(t, md, "| `root` | 0 | 1 | NONE |")
+
+	plain, err := NewCIReportGenerator().generateMarkdownSummary(moduleTestResults())
+	require.NoError(t, err)
+	assert.NotContains(t, plain, "Drift by Module", "grouping is opt-in")
+}
@@ -0,0 +1,89 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// PatternHighSeverity groups resources with critical or high severity drift
+const PatternHighSeverity = "high_severity"
+
+// PriorityUrgent is the priority of recommendations that need action first
+const PriorityUrgent = "urgent"
+
+// DriftPattern is a recurring shape found across a set of drift results
+type DriftPattern struct {
+	Type        string   `json:"type"`
+	Description string   `json:"description"`
+	Resources   []string `json:"resources"`
+}
+
+// Recommendation is a suggested action derived from a drift pattern
+type Recommendation struct {
+	Priority    string   `json:"priority"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Resources   []string `json:"resources"`
+}
+
+// DriftAnalysis holds the patterns found in a result set and the
+// recommendations derived from them
+type DriftAnalysis struct {
+	Patterns        []DriftPattern   `json:"patterns"`
+	Recommendations []Recommendation `json:"recommendations"`
+}
+
+// RecommendationConfig tunes when AnalyzeDriftPatterns reports a pattern
+type RecommendationConfig struct {
+	// HighSeverityPatternThreshold is the number of critical or high severity
+	// resources needed before the high severity pattern and its urgent
+	// recommendation are reported
+	HighSeverityPatternThreshold int
+}
+
+// DefaultRecommendationConfig returns the default pattern thresholds
+func DefaultRecommendationConfig() RecommendationConfig {
+	return RecommendationConfig{HighSeverityPatternThreshold: 1}
+}
+
+// AnalyzeDriftPatterns looks for patterns across drift results and suggests
+// actions for them. A zero threshold in config falls back to the default.
+func AnalyzeDriftPatterns(results map[string]*interfaces.DriftResult, config RecommendationConfig) *DriftAnalysis {
+	if config.HighSeverityPatternThreshold <= 0 {
+		config.HighSeverityPatternThreshold = DefaultRecommendationConfig().HighSeverityPatternThreshold
+	}
+
+	analysis := &DriftAnalysis{
+		Patterns:        []DriftPattern{},
+		Recommendations: []Recommendation{},
+	}
+
+	var severe []string
+	for id, result := range results {
+		if result == nil || !result.IsDrifted {
+			continue
+		}
+		if result.Severity == interfaces.SeverityCritical || result.Severity == interfaces.SeverityHigh {
+			severe = append(severe, id)
+		}
+	}
+
+	if len(severe) >= config.HighSeverityPatternThreshold {
+		sort.Strings(severe)
+		analysis.Patterns = append(analysis.Patterns, DriftPattern{
+			Type:        PatternHighSeverity,
+			Description: fmt.Sprintf("%d resources have critical or high severity drift", len(severe)),
+			Resources:   severe,
+		})
+		analysis.Recommendations = append(analysis.Recommendations, Recommendation{
+			Priority:    PriorityUrgent,
+			Title:       "Remediate critical and high severity drift",
+			Description: "Review these resources first and reconcile them with terraform apply or update the configuration to match intended changes.",
+			Resources:   severe,
+		})
+	}
+
+	return analysis
+}
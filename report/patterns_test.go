@@ -0,0 +1,58 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func TestAnalyzeDriftPatterns(t *testing.T) {
+	analysis := AnalyzeDriftPatterns(createTestDriftResults(), DefaultRecommendationConfig())
+
+	require.NotEmpty(t, analysis.Recommendations)
+	assert.Equal(t, PriorityUrgent, analysis.Recommendations[0].Priority)
+	assert.Equal(t, PatternHighSeverity, analysis.Patterns[0].Type)
+}
+
+func TestAnalyzeDriftPatterns_HighSeverityThreshold(t *testing.T) {
+	results := map[string]*interfaces.DriftResult{
+		"aws_instance.bastion": {
+			ResourceID: "aws_instance.bastion",
+			IsDrifted:  true,
+			Severity:   interfaces.SeverityHigh,
+			DriftDetails: []*interfaces.DriftDetail{
+				{Attribute: "key_name", ExpectedValue: "ops", ActualValue: "break-glass", Severity: interfaces.SeverityHigh},
+			},
+		},
+		"aws_instance.web": {
+			ResourceID: "aws_instance.web",
+			IsDrifted:  true,
+			Severity:   interfaces.SeverityLow,
+			DriftDetails: []*interfaces.DriftDetail{
+				{Attribute: "tags", ExpectedValue: "a", ActualValue: "b", Severity: interfaces.SeverityLow},
+			},
+		},
+	}
+
+	hasUrgent := func(analysis *DriftAnalysis) bool {
+		for _, rec := range analysis.Recommendations {
+			if rec.Priority == PriorityUrgent {
+				return true
+			}
+		}
+		return false
+	}
+
+	assert.True(t, hasUrgent(AnalyzeDriftPatterns(results, DefaultRecommendationConfig())))
+
+	config := DefaultRecommendationConfig()
+	config.HighSeverityPatternThreshold = 2
+	analysis := AnalyzeDriftPatterns(results, config)
+	assert.False(t, hasUrgent(analysis))
+	for _, pattern := range analysis.Patterns {
+		assert.NotEqual(t, PatternHighSeverity, pattern.Type)
+	}
+}
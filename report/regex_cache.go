@@ -0,0 +1,36 @@
+package report
+
+import (
+	"regexp"
+	"sync"
+	"sync/atomic"
+)
+
+// neverMatch is cached for invalid patterns so they filter out everything
+var neverMatch = regexp.MustCompile(`\b\B`)
+
+// regexCache holds compiled filter patterns for the life of the process,
+// keyed by pattern string. Compiled regexps are safe for concurrent use.
+var regexCache sync.Map
+
+// regexCompilations counts cache misses, for benchmarks and tests
+var regexCompilations atomic.Int64
+
+// compilePattern returns the cached compiled form of pattern, compiling it
+// on first use. ok is false when the pattern is invalid, in which case the
+// never-matching sentinel is returned.
+func compilePattern(pattern string) (compiled *regexp.Regexp, ok bool) {
+	if cached, found := regexCache.Load(pattern); found {
+		compiled = cached.(*regexp.Regexp)
+		return compiled, compiled != neverMatch
+	}
+
+	regexCompilations.Add(1)
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		compiled = neverMatch
+	}
+	actual, _ := regexCache.LoadOrStore(pattern, compiled)
+	compiled = actual.(*regexp.Regexp)
+	return compiled, compiled != neverMatch
+}
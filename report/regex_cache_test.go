@@ -0,0 +1,49 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompilePattern_Cached(t *testing.T) {
+	pattern := `^aws_instance\.cache-test-[0-9]+$`
+
+	before := regexCompilations.Load()
+	first, ok := compilePattern(pattern)
+	require.True(t, ok)
+	second, ok := compilePattern(pattern)
+	require.True(t, ok)
+
+	assert.Same(t, first, second)
+	assert.Equal(t, int64(1), regexCompilations.Load()-before)
+}
+
+func TestCompilePattern_InvalidNeverMatches(t *testing.T) {
+	for i := 0; i < 2; i++ {
+		compiled, ok := compilePattern("[unclosed")
+		assert.False(t, ok)
+		assert.False(t, compiled.MatchString("[unclosed"))
+		assert.False(t, compiled.MatchString(""))
+	}
+
+	results := createTestDriftResults()
+	assert.Empty(t, NewResultFilter().WithResourcePattern("[unclosed").Apply(results))
+	assert.Empty(t, NewResultFilter().WithAttributePattern("(unclosed").OnlyWithDrift().Apply(results))
+}
+
+func BenchmarkResultFilter_PresetPatterns(b *testing.B) {
+	presets := NewPresetFilters()
+	before := regexCompilations.Load()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		presets.SecurityRelated()
+		presets.NetworkRelated()
+		NewResultFilter().WithResourcePattern(`^aws_instance\.`)
+	}
+	b.StopTimer()
+
+	b.ReportMetric(float64(regexCompilations.Load()-before)/float64(b.N), "compilations/op")
+}
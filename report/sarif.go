@@ -0,0 +1,200 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// SARIF document constants
+const (
+	// SARIFVersion is the SARIF specification version emitted
+	SARIFVersion = "2.1.0"
+	// SARIFSchema is the JSON schema of SARIF 2.1.0 documents
+	SARIFSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+	// DefaultSARIFToolName is the tool driver name used when none is set
+	DefaultSARIFToolName = "firefly-drift"
+)
+
+// SARIFLog is the root of a SARIF document
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun is one analysis run with the rules it checked and its results
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+// SARIFTool describes the tool that produced a run
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver is the tool component that defines the rules
+type SARIFDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version,omitempty"`
+	Rules   []SARIFRule `json:"rules"`
+}
+
+// SARIFRule is a reporting descriptor, one per drifted attribute name
+type SARIFRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription SARIFMessage `json:"shortDescription"`
+}
+
+// SARIFResult is one drifted attribute of a resource
+type SARIFResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             SARIFMessage      `json:"message"`
+	Locations           []SARIFLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+}
+
+// SARIFMessage is a plain text message
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFLocation locates a result. Drift has no source position, so only the
+// logical location (the resource) is set.
+type SARIFLocation struct {
+	LogicalLocations []SARIFLogicalLocation `json:"logicalLocations"`
+}
+
+// SARIFLogicalLocation names the drifted resource
+type SARIFLogicalLocation struct {
+	Name string `json:"name"`
+	Kind string `json:"kind,omitempty"`
+}
+
+// SARIFGenerator converts drift results into SARIF 2.1.0 for code scanning
+// dashboards
+type SARIFGenerator struct {
+	// ToolName is the driver name (DefaultSARIFToolName when empty)
+	ToolName string
+	// ToolVersion is the driver version (optional)
+	ToolVersion string
+}
+
+// NewSARIFGenerator creates a SARIFGenerator with the default tool name
+func NewSARIFGenerator() *SARIFGenerator {
+	return &SARIFGenerator{ToolName: DefaultSARIFToolName}
+}
+
+// sarifLevel maps a drift severity to a SARIF result level
+func sarifLevel(severity interfaces.SeverityLevel) string {
+	switch severity {
+	case interfaces.SeverityCritical, interfaces.SeverityHigh:
+		return "error"
+	case interfaces.SeverityMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifRuleID derives the rule ID for a drifted attribute
+func sarifRuleID(attribute string) string {
+	return "drift/" + attribute
+}
+
+// Generate builds a SARIF document with one result per drifted attribute.
+// Results are ordered by resource key, and rules by ID.
+func (g *SARIFGenerator) Generate(results map[string]*interfaces.DriftResult) ([]byte, error) {
+	if results == nil {
+		return nil, NewReportError(ErrorTypeInvalidInput, "results cannot be nil")
+	}
+
+	keys := make([]string, 0, len(results))
+	for key := range results {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	ruleAttributes := make(map[string]string)
+	for _, result := range results {
+		if result == nil || !result.IsDrifted {
+			continue
+		}
+		for _, detail := range result.DriftDetails {
+			if detail != nil {
+				ruleAttributes[sarifRuleID(detail.Attribute)] = detail.Attribute
+			}
+		}
+	}
+	sortedRuleIDs := make([]string, 0, len(ruleAttributes))
+	for id := range ruleAttributes {
+		sortedRuleIDs = append(sortedRuleIDs, id)
+	}
+	sort.Strings(sortedRuleIDs)
+
+	rules := make([]SARIFRule, 0, len(sortedRuleIDs))
+	ruleIndex := make(map[string]int, len(sortedRuleIDs))
+	for i, id := range sortedRuleIDs {
+		attribute := ruleAttributes[id]
+		ruleIndex[id] = i
+		rules = append(rules, SARIFRule{
+			ID:               id,
+			Name:             attribute,
+			ShortDescription: SARIFMessage{Text: fmt.Sprintf("%s differs from the Terraform configuration", attribute)},
+		})
+	}
+
+	sarifResults := []SARIFResult{}
+	for _, key := range keys {
+		result := results[key]
+		if result == nil || !result.IsDrifted {
+			continue
+		}
+		resourceID := result.ResourceID
+		if resourceID == "" {
+			resourceID = key
+		}
+		for _, detail := range orderedDetails(result.DriftDetails, nil) {
+			if detail == nil {
+				continue
+			}
+			id := sarifRuleID(detail.Attribute)
+			sarifResults = append(sarifResults, SARIFResult{
+				RuleID:    id,
+				RuleIndex: ruleIndex[id],
+				Level:     sarifLevel(detail.Severity),
+				Message: SARIFMessage{Text: fmt.Sprintf("%s drifted on %s: expected %v, actual %v",
+					detail.Attribute, resourceID, detail.ExpectedValue, detail.ActualValue)},
+				Locations: []SARIFLocation{{
+					LogicalLocations: []SARIFLogicalLocation{{Name: resourceID, Kind: "resource"}},
+				}},
+				PartialFingerprints: map[string]string{"driftFingerprint": DriftFingerprint(resourceID, detail)},
+			})
+		}
+	}
+
+	toolName := g.ToolName
+	if toolName == "" {
+		toolName = DefaultSARIFToolName
+	}
+	log := SARIFLog{
+		Schema:  SARIFSchema,
+		Version: SARIFVersion,
+		Runs: []SARIFRun{{
+			Tool:    SARIFTool{Driver: SARIFDriver{Name: toolName, Version: g.ToolVersion, Rules: rules}},
+			Results: sarifResults,
+		}},
+	}
+
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return nil, WrapError(ErrorTypeMarshaling, "failed to marshal SARIF report", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,78 @@
+package report
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func TestSARIFGenerator_Generate(t *testing.T) {
+	results := createTestReportData()
+	results["aws_instance.test"].DriftDetails = append(results["aws_instance.test"].DriftDetails,
+		&interfaces.DriftDetail{Attribute: "tags", ExpectedValue: "a", ActualValue: "b", Severity: interfaces.SeverityMedium},
+		&interfaces.DriftDetail{Attribute: "monitoring", ExpectedValue: true, ActualValue: false, Severity: interfaces.SeverityLow},
+	)
+
+	generator := NewSARIFGenerator()
+	generator.ToolVersion = "1.2.3"
+	data, err := generator.Generate(results)
+	require.NoError(t, err)
+	require.True(t, json.Valid(data))
+
+	// Check the required fields on the raw document rather than the Go types
+	var raw map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &raw))
+	assert.Equal(t, SARIFVersion, raw["version"])
+	assert.Equal(t, SARIFSchema, raw["$schema"])
+
+	var log SARIFLog
+	require.NoError(t, json.Unmarshal(data, &log))
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, DefaultSARIFToolName, run.Tool.Driver.Name)
+	assert.Equal(t, "1.2.3", run.Tool.Driver.Version)
+
+	ruleIDs := make([]string, 0, len(run.Tool.Driver.Rules))
+	for _, rule := range run.Tool.Driver.Rules {
+		ruleIDs = append(ruleIDs, rule.ID)
+		assert.NotEmpty(t, rule.ShortDescription.Text)
+	}
+	assert.Equal(t, []string{"drift/instance_type", "drift/monitoring", "drift/public_access_block", "drift/tags"}, ruleIDs)
+
+	require.Len(t, run.Results, 4)
+	levels := make(map[string]string)
+	for _, result := range run.Results {
+		require.Len(t, result.Locations, 1)
+		require.Len(t, result.Locations[0].LogicalLocations, 1)
+		location := result.Locations[0].LogicalLocations[0]
+		levels[location.Name+" "+result.RuleID] = result.Level
+		assert.Equal(t, result.RuleID, run.Tool.Driver.Rules[result.RuleIndex].ID)
+		assert.NotEmpty(t, result.Message.Text)
+		assert.NotEmpty(t, result.PartialFingerprints["driftFingerprint"])
+	}
+	assert.Equal(t, map[string]string{
+		"aws_instance.test drift/instance_type":        "error",
+		"aws_instance.test drift/tags":                 "warning",
+		"aws_instance.test drift/monitoring":           "note",
+		"aws_s3_bucket.data drift/public_access_block": "error",
+	}, levels)
+	assert.Equal(t, "aws_instance.test", run.Results[0].Locations[0].LogicalLocations[0].Name, "results should be ordered by resource key")
+}
+
+func TestSARIFGenerator_Empty(t *testing.T) {
+	data, err := NewSARIFGenerator().Generate(map[string]*interfaces.DriftResult{
+		"aws_instance.clean": {ResourceID: "aws_instance.clean"},
+	})
+	require.NoError(t, err)
+
+	// results and rules must be present as empty arrays, not null
+	assert.Contains(t, string(data), `"results": []`)
+	assert.Contains(t, string(data), `"rules": []`)
+
+	_, err = NewSARIFGenerator().Generate(nil)
+	assert.True(t, IsReportError(err, ErrorTypeInvalidInput))
+}
@@ -0,0 +1,305 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	"firefly-task/pkg/interfaces"
+)
+
+const (
+	asffSchemaVersion = "2018-10-08"
+	asffGeneratorID   = "firefly-drift-detection"
+	asffFindingType   = "Software and Configuration Checks/Industry and Regulatory Standards/Configuration Drift"
+
+	// asffTitleMaxLength and asffDescriptionMaxLength are the ASFF character
+	// limits for Title and Description
+	asffTitleMaxLength       = 256
+	asffDescriptionMaxLength = 1024
+
+	// securityHubBatchSize is the most findings BatchImportFindings accepts
+	// per call
+	securityHubBatchSize = 100
+)
+
+// securityAttributes are the drifted attributes reported to Security Hub
+var securityAttributes = map[string]bool{
+	"security_groups":             true,
+	"iam_instance_profile":        true,
+	"key_name":                    true,
+	"vpc_id":                      true,
+	"subnet_id":                   true,
+	"public_ip":                   true,
+	"associate_public_ip_address": true,
+	"disable_api_termination":     true,
+	"metadata_options":            true,
+	"policy":                      true,
+	"encrypted":                   true,
+	"kms_key_id":                  true,
+	"server_side_encryption":      true,
+	"public_access_block":         true,
+}
+
+// ASFFFinding is the subset of the AWS Security Finding Format populated for
+// a drifted attribute
+type ASFFFinding struct {
+	SchemaVersion string         `json:"SchemaVersion"`
+	ID            string         `json:"Id"`
+	ProductArn    string         `json:"ProductArn"`
+	GeneratorID   string         `json:"GeneratorId"`
+	AwsAccountID  string         `json:"AwsAccountId"`
+	Types         []string       `json:"Types"`
+	CreatedAt     string         `json:"CreatedAt"`
+	UpdatedAt     string         `json:"UpdatedAt"`
+	Severity      ASFFSeverity   `json:"Severity"`
+	Title         string         `json:"Title"`
+	Description   string         `json:"Description"`
+	Resources     []ASFFResource `json:"Resources"`
+}
+
+// ASFFSeverity is the severity of an ASFF finding
+type ASFFSeverity struct {
+	Label string `json:"Label"`
+}
+
+// ASFFResource identifies the resource an ASFF finding applies to
+type ASFFResource struct {
+	Type   string `json:"Type"`
+	ID     string `json:"Id"`
+	Region string `json:"Region,omitempty"`
+}
+
+// asffSeverityLabel maps a drift severity to an ASFF severity label
+func asffSeverityLabel(severity interfaces.SeverityLevel) string {
+	switch severity {
+	case interfaces.SeverityCritical:
+		return "CRITICAL"
+	case interfaces.SeverityHigh:
+		return "HIGH"
+	case interfaces.SeverityMedium:
+		return "MEDIUM"
+	case interfaces.SeverityLow:
+		return "LOW"
+	default:
+		return "INFORMATIONAL"
+	}
+}
+
+// asffResource describes a drifted resource using ASFF resource types and,
+// where it can be derived, the resource ARN
+func asffResource(result *interfaces.DriftResult, accountID, region string) ASFFResource {
+	id := result.ResourceID
+	resource := ASFFResource{Type: "Other", ID: id, Region: region}
+
+	switch result.ResourceType {
+	case "aws_instance":
+		resource.Type = "AwsEc2Instance"
+		if strings.HasPrefix(id, "i-") {
+			resource.ID = fmt.Sprintf("arn:aws:ec2:%s:%s:instance/%s", region, accountID, id)
+		}
+	case "aws_security_group":
+		resource.Type = "AwsEc2SecurityGroup"
+		if strings.HasPrefix(id, "sg-") {
+			resource.ID = fmt.Sprintf("arn:aws:ec2:%s:%s:security-group/%s", region, accountID, id)
+		}
+	case "aws_s3_bucket":
+		resource.Type = "AwsS3Bucket"
+		if !strings.HasPrefix(id, "arn:") {
+			resource.ID = "arn:aws:s3:::" + id
+		}
+	}
+	return resource
+}
+
+// GenerateASFF builds AWS Security Finding Format findings for drift on
+// security-relevant attributes, one finding per drifted attribute. Finding
+// IDs are drift fingerprints, so re-importing the same drift updates the
+// existing finding instead of creating a new one.
+func GenerateASFF(results map[string]*interfaces.DriftResult, accountID, region string) ([]byte, error) {
+	if results == nil {
+		return nil, NewReportError(ErrorTypeInvalidInput, "results cannot be nil")
+	}
+	if accountID == "" || region == "" {
+		return nil, NewReportError(ErrorTypeConfiguration, "account ID and region are required for ASFF findings")
+	}
+
+	keys := make([]string, 0, len(results))
+	for key := range results {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	productArn := fmt.Sprintf("arn:aws:securityhub:%s:%s:product/%s/default", region, accountID, accountID)
+	findings := []ASFFFinding{}
+	for _, key := range keys {
+		result := results[key]
+		if result == nil || !result.IsDrifted {
+			continue
+		}
+		detected := result.DetectionTime
+		if detected.IsZero() {
+			detected = time.Now()
+		}
+		timestamp := detected.UTC().Format(time.RFC3339)
+
+		for _, detail := range orderedDetails(result.DriftDetails, nil) {
+			if detail == nil || !securityAttributes[detail.Attribute] {
+				continue
+			}
+			findings = append(findings, ASFFFinding{
+				SchemaVersion: asffSchemaVersion,
+				ID:            asffGeneratorID + "/" + DriftFingerprint(result.ResourceID, detail),
+				ProductArn:    productArn,
+				GeneratorID:   asffGeneratorID,
+				AwsAccountID:  accountID,
+				Types:         []string{asffFindingType},
+				CreatedAt:     timestamp,
+				UpdatedAt:     timestamp,
+				Severity:      ASFFSeverity{Label: asffSeverityLabel(detail.Severity)},
+				Title: truncateASFF(fmt.Sprintf("%s drifted from Terraform on %s",
+					detail.Attribute, result.ResourceID), asffTitleMaxLength),
+				Description: truncateASFF(fmt.Sprintf("Expected %v, found %v.",
+					detail.ExpectedValue, detail.ActualValue), asffDescriptionMaxLength),
+				Resources: []ASFFResource{asffResource(result, accountID, region)},
+			})
+		}
+	}
+
+	data, err := json.Marshal(findings)
+	if err != nil {
+		return nil, WrapError(ErrorTypeMarshaling, "failed to marshal ASFF findings", err)
+	}
+	return data, nil
+}
+
+// truncateASFF shortens s to the ASFF field limit of n characters, cutting
+// on a rune boundary so multi-byte values stay valid UTF-8
+func truncateASFF(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-3]) + "..."
+}
+
+// SecurityHubConfig identifies the Security Hub endpoint and credentials used
+// by SendToSecurityHub
+type SecurityHubConfig struct {
+	Region      string
+	Credentials awssdk.CredentialsProvider
+	// Endpoint overrides https://securityhub.<region>.amazonaws.com
+	Endpoint string
+	// HTTPClient is used for the requests; a client with a 30 second timeout
+	// is used when nil
+	HTTPClient *http.Client
+}
+
+// securityHubImportResponse is the BatchImportFindings response body
+type securityHubImportResponse struct {
+	FailedCount    int `json:"FailedCount"`
+	FailedFindings []struct {
+		ID           string `json:"Id"`
+		ErrorCode    string `json:"ErrorCode"`
+		ErrorMessage string `json:"ErrorMessage"`
+	} `json:"FailedFindings"`
+}
+
+// SendToSecurityHub imports findings produced by GenerateASFF through the
+// Security Hub BatchImportFindings API, in batches of 100. Requests are
+// signed with Signature Version 4.
+func SendToSecurityHub(ctx context.Context, config SecurityHubConfig, findings []byte) error {
+	if config.Region == "" {
+		return NewReportError(ErrorTypeConfiguration, "Security Hub region is required")
+	}
+	if config.Credentials == nil {
+		return NewReportError(ErrorTypeConfiguration, "Security Hub credentials are required")
+	}
+
+	var all []json.RawMessage
+	if err := json.Unmarshal(findings, &all); err != nil {
+		return WrapError(ErrorTypeInvalidInput, "findings must be a JSON array of ASFF findings", err)
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://securityhub.%s.amazonaws.com", config.Region)
+	}
+	url := strings.TrimRight(endpoint, "/") + "/findings/import"
+
+	client := config.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	for start := 0; start < len(all); start += securityHubBatchSize {
+		end := start + securityHubBatchSize
+		if end > len(all) {
+			end = len(all)
+		}
+		if err := importFindingsBatch(ctx, client, config, url, all[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importFindingsBatch sends one signed BatchImportFindings request
+func importFindingsBatch(ctx context.Context, client *http.Client, config SecurityHubConfig, url string, batch []json.RawMessage) error {
+	body, err := json.Marshal(map[string][]json.RawMessage{"Findings": batch})
+	if err != nil {
+		return WrapError(ErrorTypeMarshaling, "failed to marshal Security Hub request", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return WrapError(ErrorTypeConfiguration, "failed to build Security Hub request", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	credentials, err := config.Credentials.Retrieve(ctx)
+	if err != nil {
+		return WrapError(ErrorTypeConfiguration, "failed to retrieve AWS credentials", err)
+	}
+	sum := sha256.Sum256(body)
+	if err := v4.NewSigner().SignHTTP(ctx, credentials, req, hex.EncodeToString(sum[:]), "securityhub", config.Region, time.Now()); err != nil {
+		return WrapError(ErrorTypeConfiguration, "failed to sign Security Hub request", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return WrapError(ErrorTypeGenerationFailed, "failed to send findings to Security Hub", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return NewReportErrorf(ErrorTypeGenerationFailed, "Security Hub returned %s: %s",
+			resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	var result securityHubImportResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return WrapError(ErrorTypeMarshaling, "failed to decode Security Hub response", err)
+	}
+	if result.FailedCount > 0 {
+		first := result.FailedFindings
+		detail := ""
+		if len(first) > 0 {
+			detail = fmt.Sprintf(" (first: %s %s: %s)", first[0].ID, first[0].ErrorCode, first[0].ErrorMessage)
+		}
+		return NewReportErrorf(ErrorTypeGenerationFailed, "Security Hub rejected %d findings%s", result.FailedCount, detail)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func securityHubTestResults() map[string]*interfaces.DriftResult {
+	detected := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	return map[string]*interfaces.DriftResult{
+		"i-0abc": {
+			ResourceID:    "i-0abc",
+			ResourceType:  "aws_instance",
+			IsDrifted:     true,
+			Severity:      interfaces.SeverityCritical,
+			DetectionTime: detected,
+			DriftDetails: []*interfaces.DriftDetail{
+				{Attribute: "security_groups", ExpectedValue: "sg-1", ActualValue: "sg-2", Severity: interfaces.SeverityCritical},
+				{Attribute: "key_name", ExpectedValue: "deploy", ActualValue: "admin", Severity: interfaces.SeverityHigh},
+				{Attribute: "tags", ExpectedValue: "a", ActualValue: "b", Severity: interfaces.SeverityMedium},
+			},
+		},
+		"logs-bucket": {
+			ResourceID:    "logs-bucket",
+			ResourceType:  "aws_s3_bucket",
+			IsDrifted:     true,
+			Severity:      interfaces.SeverityLow,
+			DetectionTime: detected,
+			DriftDetails: []*interfaces.DriftDetail{
+				{Attribute: "encrypted", ExpectedValue: true, ActualValue: false, Severity: interfaces.SeverityLow},
+			},
+		},
+		"i-clean": {ResourceID: "i-clean", ResourceType: "aws_instance"},
+	}
+}
+
+func TestGenerateASFF(t *testing.T) {
+	data, err := GenerateASFF(securityHubTestResults(), "123456789012", "us-east-1")
+	require.NoError(t, err)
+
+	var findings []map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &findings))
+	require.Len(t, findings, 3, "tags drift is not security relevant")
+
+	required := []string{"SchemaVersion", "Id", "ProductArn", "GeneratorId", "AwsAccountId",
+		"Types", "CreatedAt", "UpdatedAt", "Severity", "Title", "Description", "Resources"}
+	for _, finding := range findings {
+		for _, field := range required {
+			assert.NotEmpty(t, finding[field], "finding %v missing %s", finding["Id"], field)
+		}
+		assert.Equal(t, "2018-10-08", finding["SchemaVersion"])
+		assert.Equal(t, "arn:aws:securityhub:us-east-1:123456789012:product/123456789012/default", finding["ProductArn"])
+		assert.Equal(t, "2024-05-01T10:00:00Z", finding["CreatedAt"])
+	}
+
+	var typed []ASFFFinding
+	require.NoError(t, json.Unmarshal(data, &typed))
+	labels := make(map[string]string)
+	for _, finding := range typed {
+		labels[finding.Title] = finding.Severity.Label
+	}
+	assert.Equal(t, map[string]string{
+		"key_name drifted from Terraform on i-0abc":        "HIGH",
+		"security_groups drifted from Terraform on i-0abc": "CRITICAL",
+		"encrypted drifted from Terraform on logs-bucket":  "LOW",
+	}, labels)
+
+	assert.Equal(t, ASFFResource{Type: "AwsEc2Instance", ID: "arn:aws:ec2:us-east-1:123456789012:instance/i-0abc", Region: "us-east-1"}, typed[0].Resources[0])
+	assert.Equal(t, "AwsS3Bucket", typed[2].Resources[0].Type)
+	assert.Equal(t, "arn:aws:s3:::logs-bucket", typed[2].Resources[0].ID)
+
+	again, err := GenerateASFF(securityHubTestResults(), "123456789012", "us-east-1")
+	require.NoError(t, err)
+	assert.JSONEq(t, string(data), string(again), "finding IDs should be stable across runs")
+
+	assert.Equal(t, "INFORMATIONAL", asffSeverityLabel(interfaces.SeverityNone))
+
+	_, err = GenerateASFF(securityHubTestResults(), "", "us-east-1")
+	assert.Error(t, err)
+	_, err = GenerateASFF(nil, "123456789012", "us-east-1")
+	assert.Error(t, err)
+}
+
+func TestGenerateASFF_TruncatesOnRuneBoundaries(t *testing.T) {
+	longID := strings.Repeat("é", 300)
+	results := map[string]*interfaces.DriftResult{
+		longID: {
+			ResourceID: longID,
+			IsDrifted:  true,
+			DriftDetails: []*interfaces.DriftDetail{
+				{Attribute: "policy", ExpectedValue: strings.Repeat("ü", 1100), ActualValue: "{}", Severity: interfaces.SeverityHigh},
+			},
+		},
+	}
+
+	data, err := GenerateASFF(results, "123456789012", "us-east-1")
+	require.NoError(t, err)
+	var findings []ASFFFinding
+	require.NoError(t, json.Unmarshal(data, &findings))
+	require.Len(t, findings, 1)
+
+	title := findings[0].Title
+	assert.True(t, utf8.ValidString(title))
+	assert.Equal(t, asffTitleMaxLength, utf8.RuneCountInString(title))
+	assert.True(t, strings.HasSuffix(title, "..."))
+
+	description := findings[0].Description
+	assert.True(t, utf8.ValidString(description))
+	assert.Equal(t, asffDescriptionMaxLength, utf8.RuneCountInString(description))
+}
+
+func TestSendToSecurityHub(t *testing.T) {
+	var gotPath, gotAuth string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		gotBody, _ = io.ReadAll(r.Body)
+		w.Write([]byte(`{"FailedCount":0,"SuccessCount":3,"FailedFindings":[]}`))
+	}))
+	defer server.Close()
+
+	findings, err := GenerateASFF(securityHubTestResults(), "123456789012", "us-east-1")
+	require.NoError(t, err)
+
+	config := SecurityHubConfig{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+		Endpoint:    server.URL,
+	}
+	require.NoError(t, SendToSecurityHub(context.Background(), config, findings))
+	assert.Equal(t, "/findings/import", gotPath)
+	assert.True(t, strings.HasPrefix(gotAuth, "AWS4-HMAC-SHA256 Credential=AKID/"), gotAuth)
+	assert.Contains(t, gotAuth, "/us-east-1/securityhub/aws4_request")
+
+	var request struct{ Findings []ASFFFinding }
+	require.NoError(t, json.Unmarshal(gotBody, &request))
+	assert.Len(t, request.Findings, 3)
+
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"FailedCount":1,"FailedFindings":[{"Id":"x","ErrorCode":"InvalidInput","ErrorMessage":"bad"}]}`))
+	}))
+	defer rejecting.Close()
+	config.Endpoint = rejecting.URL
+	err = SendToSecurityHub(context.Background(), config, findings)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rejected 1 findings")
+
+	config.Credentials = nil
+	assert.Error(t, SendToSecurityHub(context.Background(), config, findings))
+}
@@ -0,0 +1,139 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// serviceNowCategory is the incident category used for drift incidents
+const serviceNowCategory = "infrastructure"
+
+// ServiceNowIncident is the subset of the ServiceNow incident table fields
+// populated for a drift run. Urgency and impact use ServiceNow's numeric
+// choice values: "1" high, "2" medium, "3" low.
+type ServiceNowIncident struct {
+	ShortDescription string `json:"short_description"`
+	Description      string `json:"description"`
+	Urgency          string `json:"urgency"`
+	Impact           string `json:"impact"`
+	Category         string `json:"category"`
+}
+
+// ServiceNowConfig identifies the ServiceNow instance and credentials used by
+// SendToServiceNow
+type ServiceNowConfig struct {
+	// InstanceURL is the instance base URL, e.g. https://example.service-now.com
+	InstanceURL string
+	Username    string
+	Password    string
+	// HTTPClient is used for the request; a client with a 30 second timeout
+	// is used when nil
+	HTTPClient *http.Client
+}
+
+// serviceNowPriority maps the highest drift severity to incident urgency and impact
+func serviceNowPriority(severity interfaces.SeverityLevel) (urgency, impact string) {
+	switch severity {
+	case interfaces.SeverityCritical:
+		return "1", "1"
+	case interfaces.SeverityHigh:
+		return "1", "2"
+	case interfaces.SeverityMedium:
+		return "2", "2"
+	default:
+		return "3", "3"
+	}
+}
+
+// GenerateServiceNowPayload builds an incident table payload for the drift
+// results. The description is the markdown summary used for CI artifacts.
+func GenerateServiceNowPayload(results map[string]*interfaces.DriftResult) ([]byte, error) {
+	if results == nil {
+		return nil, NewReportError(ErrorTypeInvalidInput, "results cannot be nil")
+	}
+
+	drifted := 0
+	highest := interfaces.SeverityNone
+	for _, result := range results {
+		if result == nil || !result.IsDrifted {
+			continue
+		}
+		drifted++
+		if getSeverityOrder(result.Severity) > getSeverityOrder(highest) {
+			highest = result.Severity
+		}
+	}
+
+	description, err := NewCIReportGenerator().generateMarkdownSummary(results)
+	if err != nil {
+		return nil, err
+	}
+
+	shortDescription := "No Terraform drift detected"
+	if drifted > 0 {
+		shortDescription = fmt.Sprintf("Terraform drift detected in %d of %d resources (highest severity: %s)",
+			drifted, len(results), strings.ToUpper(string(highest)))
+	}
+
+	urgency, impact := serviceNowPriority(highest)
+	incident := ServiceNowIncident{
+		ShortDescription: shortDescription,
+		Description:      description,
+		Urgency:          urgency,
+		Impact:           impact,
+		Category:         serviceNowCategory,
+	}
+
+	data, err := json.Marshal(incident)
+	if err != nil {
+		return nil, WrapError(ErrorTypeMarshaling, "failed to marshal ServiceNow incident", err)
+	}
+	return data, nil
+}
+
+// SendToServiceNow creates an incident from payload through the ServiceNow
+// Table API using basic authentication
+func SendToServiceNow(ctx context.Context, config ServiceNowConfig, payload []byte) error {
+	if config.InstanceURL == "" {
+		return NewReportError(ErrorTypeConfiguration, "ServiceNow instance URL is required")
+	}
+	if config.Username == "" {
+		return NewReportError(ErrorTypeConfiguration, "ServiceNow username is required")
+	}
+
+	url := strings.TrimRight(config.InstanceURL, "/") + "/api/now/table/incident"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return WrapError(ErrorTypeConfiguration, "failed to build ServiceNow request", err)
+	}
+	req.SetBasicAuth(config.Username, config.Password)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	client := config.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return WrapError(ErrorTypeGenerationFailed, "failed to send ServiceNow incident", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return NewReportErrorf(ErrorTypeGenerationFailed, "ServiceNow returned %s: %s",
+			resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func TestGenerateServiceNowPayload(t *testing.T) {
+	payload, err := GenerateServiceNowPayload(createTestReportData())
+	require.NoError(t, err)
+
+	var fields map[string]string
+	require.NoError(t, json.Unmarshal(payload, &fields))
+	assert.Len(t, fields, 5)
+	assert.Equal(t, "1", fields["urgency"])
+	assert.Equal(t, "1", fields["impact"])
+	assert.Equal(t, "infrastructure", fields["category"])
+	assert.Contains(t, fields["short_description"], "2 of 3 resources")
+	assert.Contains(t, fields["short_description"], "CRITICAL")
+	assert.Contains(t, fields["description"], "# Terraform Drift Detection Summary")
+
+	clean := map[string]*interfaces.DriftResult{"aws_instance.clean": {ResourceID: "aws_instance.clean"}}
+	payload, err = GenerateServiceNowPayload(clean)
+	require.NoError(t, err)
+	var incident ServiceNowIncident
+	require.NoError(t, json.Unmarshal(payload, &incident))
+	assert.Equal(t, "3", incident.Urgency)
+	assert.Equal(t, "No Terraform drift detected", incident.ShortDescription)
+
+	_, err = GenerateServiceNowPayload(nil)
+	assert.Error(t, err)
+}
+
+func TestSendToServiceNow(t *testing.T) {
+	var gotPath, gotUser, gotPass string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotUser, gotPass, _ = r.BasicAuth()
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	payload, err := GenerateServiceNowPayload(createTestReportData())
+	require.NoError(t, err)
+
+	config := ServiceNowConfig{InstanceURL: server.URL + "/", Username: "drift-bot", Password: "secret"}
+	require.NoError(t, SendToServiceNow(context.Background(), config, payload))
+	assert.Equal(t, "/api/now/table/incident", gotPath)
+	assert.Equal(t, "drift-bot", gotUser)
+	assert.Equal(t, "secret", gotPass)
+	assert.JSONEq(t, string(payload), string(gotBody))
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid credentials", http.StatusUnauthorized)
+	}))
+	defer failing.Close()
+	config.InstanceURL = failing.URL
+	err = SendToServiceNow(context.Background(), config, payload)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid credentials")
+}
@@ -0,0 +1,221 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// Slack message limits
+const (
+	// DefaultSlackMaxBlocks is Slack's limit on blocks per message
+	DefaultSlackMaxBlocks = 50
+	// DefaultSlackMaxResources is the number of drifted resources listed
+	DefaultSlackMaxResources = 10
+	// slackMaxAttributes caps the attributes named per listed resource
+	slackMaxAttributes = 5
+)
+
+// slackSeverityColors are the attachment colors per severity bucket
+var slackSeverityColors = map[interfaces.SeverityLevel]string{
+	interfaces.SeverityCritical: "#E01E5A",
+	interfaces.SeverityHigh:     "#FF8C00",
+	interfaces.SeverityMedium:   "#ECB22E",
+	interfaces.SeverityLow:      "#36C5F0",
+}
+
+// SlackMessage is an incoming webhook message using Block Kit
+type SlackMessage struct {
+	// Text is the notification fallback text
+	Text        string            `json:"text"`
+	Blocks      []SlackBlock      `json:"blocks"`
+	Attachments []SlackAttachment `json:"attachments,omitempty"`
+}
+
+// SlackBlock is a header, section, divider or context block
+type SlackBlock struct {
+	Type     string      `json:"type"`
+	Text     *SlackText  `json:"text,omitempty"`
+	Elements []SlackText `json:"elements,omitempty"`
+}
+
+// SlackText is a plain_text or mrkdwn text object
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// SlackAttachment is a colored bar summarizing one severity bucket
+type SlackAttachment struct {
+	Color string `json:"color"`
+	Text  string `json:"text"`
+}
+
+// SlackNotifier posts drift summaries to a Slack incoming webhook
+type SlackNotifier struct {
+	// MaxBlocks caps the blocks in a message (DefaultSlackMaxBlocks when
+	// zero). When listing resources would exceed it, only the summary is sent.
+	MaxBlocks int
+	// MaxResources caps the drifted resources listed, most severe first
+	// (DefaultSlackMaxResources when zero)
+	MaxResources int
+	// HTTPClient is used for the request; a client with a 30 second timeout
+	// is used when nil
+	HTTPClient *http.Client
+}
+
+// NewSlackNotifier creates a SlackNotifier with the default limits
+func NewSlackNotifier() *SlackNotifier {
+	return &SlackNotifier{
+		MaxBlocks:    DefaultSlackMaxBlocks,
+		MaxResources: DefaultSlackMaxResources,
+	}
+}
+
+// BuildMessage builds the Slack message for results: a header, a summary,
+// the most severe drifted resources and one colored attachment per severity
+// bucket with drift
+func (n *SlackNotifier) BuildMessage(results map[string]*interfaces.DriftResult) (*SlackMessage, error) {
+	if results == nil {
+		return nil, NewReportError(ErrorTypeInvalidInput, "results cannot be nil")
+	}
+
+	maxBlocks := n.MaxBlocks
+	if maxBlocks <= 0 {
+		maxBlocks = DefaultSlackMaxBlocks
+	}
+	maxResources := n.MaxResources
+	if maxResources <= 0 {
+		maxResources = DefaultSlackMaxResources
+	}
+
+	summary := Summarize(results)
+	drifted := make([]*interfaces.DriftResult, 0, summary.DriftedResources)
+	for _, result := range results {
+		if result != nil && result.IsDrifted {
+			drifted = append(drifted, result)
+		}
+	}
+	sort.Slice(drifted, func(i, j int) bool {
+		a, b := getSeverityOrder(drifted[i].Severity), getSeverityOrder(drifted[j].Severity)
+		if a != b {
+			return a > b
+		}
+		return drifted[i].ResourceID < drifted[j].ResourceID
+	})
+
+	title := "No Terraform drift detected"
+	if summary.DriftedResources > 0 {
+		title = fmt.Sprintf("Terraform drift detected in %d of %d resources", summary.DriftedResources, summary.TotalResources)
+	}
+	message := &SlackMessage{
+		Text: title,
+		Blocks: []SlackBlock{
+			{Type: "header", Text: &SlackText{Type: "plain_text", Text: title}},
+			{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: fmt.Sprintf(
+				"*Highest severity:* %s\n*Differences:* %d\n*Clean resources:* %d",
+				strings.ToUpper(string(summary.HighestSeverity)), summary.TotalDifferences, summary.CleanResources)}},
+		},
+	}
+
+	for _, severity := range []interfaces.SeverityLevel{
+		interfaces.SeverityCritical, interfaces.SeverityHigh, interfaces.SeverityMedium, interfaces.SeverityLow,
+	} {
+		if count := summary.SeverityCounts[severity]; count > 0 {
+			message.Attachments = append(message.Attachments, SlackAttachment{
+				Color: slackSeverityColors[severity],
+				Text:  fmt.Sprintf("*%s*: %d resources", strings.ToUpper(string(severity)), count),
+			})
+		}
+	}
+
+	if len(drifted) == 0 {
+		return message, nil
+	}
+
+	listed := drifted
+	if len(listed) > maxResources {
+		listed = listed[:maxResources]
+	}
+	resourceBlocks := []SlackBlock{{Type: "divider"}}
+	for _, result := range listed {
+		resourceBlocks = append(resourceBlocks, SlackBlock{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: slackResourceLine(result)}})
+	}
+	if remaining := len(drifted) - len(listed); remaining > 0 {
+		resourceBlocks = append(resourceBlocks, SlackBlock{Type: "context", Elements: []SlackText{
+			{Type: "mrkdwn", Text: fmt.Sprintf("...and %d more drifted resources", remaining)},
+		}})
+	}
+
+	if len(message.Blocks)+len(resourceBlocks) > maxBlocks {
+		message.Blocks = append(message.Blocks, SlackBlock{Type: "context", Elements: []SlackText{
+			{Type: "mrkdwn", Text: "Too many drifted resources to list; see the CI report for details"},
+		}})
+		return message, nil
+	}
+	message.Blocks = append(message.Blocks, resourceBlocks...)
+	return message, nil
+}
+
+// slackResourceLine describes one drifted resource and its drifted attributes
+func slackResourceLine(result *interfaces.DriftResult) string {
+	var attributes []string
+	for _, detail := range result.DriftDetails {
+		if detail != nil {
+			attributes = append(attributes, "`"+detail.Attribute+"`")
+		}
+	}
+	if len(attributes) > slackMaxAttributes {
+		attributes = append(attributes[:slackMaxAttributes], fmt.Sprintf("+%d more", len(attributes)-slackMaxAttributes))
+	}
+	return fmt.Sprintf("*%s* (%s): %s", result.ResourceID, strings.ToUpper(string(result.Severity)), strings.Join(attributes, ", "))
+}
+
+// Notify posts the Slack message for results to webhookURL
+func (n *SlackNotifier) Notify(ctx context.Context, results map[string]*interfaces.DriftResult, webhookURL string) error {
+	if webhookURL == "" {
+		return NewReportError(ErrorTypeConfiguration, "Slack webhook URL is required")
+	}
+
+	message, err := n.BuildMessage(results)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return WrapError(ErrorTypeMarshaling, "failed to marshal Slack message", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return WrapError(ErrorTypeConfiguration, "failed to build Slack request", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := n.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return WrapError(ErrorTypeGenerationFailed, "failed to send Slack notification", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return NewReportErrorf(ErrorTypeGenerationFailed, "Slack returned %s: %s",
+			resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
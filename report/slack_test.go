@@ -0,0 +1,109 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func TestSlackNotifier_Notify(t *testing.T) {
+	var contentType string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		body, _ = io.ReadAll(r.Body)
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	require.NoError(t, NewSlackNotifier().Notify(context.Background(), createTestReportData(), server.URL))
+	assert.Equal(t, "application/json", contentType)
+
+	var message SlackMessage
+	require.NoError(t, json.Unmarshal(body, &message))
+	assert.Equal(t, "Terraform drift detected in 2 of 3 resources", message.Text)
+
+	require.Len(t, message.Blocks, 5)
+	assert.Equal(t, "header", message.Blocks[0].Type)
+	assert.Equal(t, "plain_text", message.Blocks[0].Text.Type)
+	assert.Contains(t, message.Blocks[1].Text.Text, "CRITICAL")
+	assert.Equal(t, "divider", message.Blocks[2].Type)
+	// Most severe resource first
+	assert.Equal(t, "*aws_s3_bucket.data* (CRITICAL): `public_access_block`", message.Blocks[3].Text.Text)
+	assert.Equal(t, "*aws_instance.test* (HIGH): `instance_type`", message.Blocks[4].Text.Text)
+
+	require.Len(t, message.Attachments, 2)
+	assert.Equal(t, slackSeverityColors[interfaces.SeverityCritical], message.Attachments[0].Color)
+	assert.Equal(t, "*CRITICAL*: 1 resources", message.Attachments[0].Text)
+	assert.Equal(t, slackSeverityColors[interfaces.SeverityHigh], message.Attachments[1].Color)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer failing.Close()
+	err := NewSlackNotifier().Notify(context.Background(), createTestReportData(), failing.URL)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid_token")
+
+	assert.Error(t, NewSlackNotifier().Notify(context.Background(), createTestReportData(), ""))
+}
+
+func TestSlackNotifier_BuildMessage_Limits(t *testing.T) {
+	results := make(map[string]*interfaces.DriftResult)
+	for i := 0; i < 15; i++ {
+		id := fmt.Sprintf("aws_instance.web_%02d", i)
+		results[id] = &interfaces.DriftResult{
+			ResourceID: id,
+			IsDrifted:  true,
+			Severity:   interfaces.SeverityMedium,
+			DriftDetails: []*interfaces.DriftDetail{
+				{Attribute: "tags", Severity: interfaces.SeverityMedium},
+			},
+		}
+	}
+
+	notifier := NewSlackNotifier()
+	message, err := notifier.BuildMessage(results)
+	require.NoError(t, err)
+	// header, summary, divider, 10 resources and the "more" context
+	require.Len(t, message.Blocks, 14)
+	last := message.Blocks[len(message.Blocks)-1]
+	assert.Equal(t, "context", last.Type)
+	assert.Equal(t, "...and 5 more drifted resources", last.Elements[0].Text)
+
+	// Falls back to a summary-only message when the list would not fit
+	notifier.MaxBlocks = 8
+	message, err = notifier.BuildMessage(results)
+	require.NoError(t, err)
+	require.Len(t, message.Blocks, 3)
+	assert.Contains(t, message.Blocks[2].Elements[0].Text, "Too many drifted resources")
+	require.Len(t, message.Attachments, 1)
+	assert.Equal(t, "*MEDIUM*: 15 resources", message.Attachments[0].Text)
+
+	clean := map[string]*interfaces.DriftResult{"aws_instance.clean": {ResourceID: "aws_instance.clean"}}
+	message, err = notifier.BuildMessage(clean)
+	require.NoError(t, err)
+	assert.Equal(t, "No Terraform drift detected", message.Text)
+	assert.Len(t, message.Blocks, 2)
+	assert.Empty(t, message.Attachments)
+
+	_, err = notifier.BuildMessage(nil)
+	assert.Error(t, err)
+}
+
+func TestSlackResourceLine_TruncatesAttributes(t *testing.T) {
+	result := &interfaces.DriftResult{ResourceID: "aws_instance.web", Severity: interfaces.SeverityLow}
+	for _, attr := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		result.DriftDetails = append(result.DriftDetails, &interfaces.DriftDetail{Attribute: attr})
+	}
+	assert.Equal(t, "*aws_instance.web* (LOW): `a`, `b`, `c`, `d`, `e`, +2 more", slackResourceLine(result))
+}
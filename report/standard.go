@@ -156,7 +156,7 @@ func (srg *StandardReportGenerator) GenerateTableReport(results map[string]*inte
 		var differences string
 		if result.IsDrifted {
 			var diffNames []string
-			for _, diff := range result.DriftDetails {
+			for _, diff := range orderedDetails(result.DriftDetails, srg.config) {
 				diffNames = append(diffNames, diff.Attribute)
 			}
 			differences = strings.Join(diffNames, ", ")
@@ -3,6 +3,8 @@ package report
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -145,14 +147,12 @@ func TestStandardReportGenerator_WriteToFile(t *testing.T) {
 	// Test JSON file writing
 	data, err := generator.GenerateJSONReport(results)
 	require.NoError(t, err)
-	err = generator.WriteToFile(data, "test-report.json")
+	path := filepath.Join(t.TempDir(), "test-report.json")
+	require.NoError(t, generator.WriteToFile(data, path))
 
-	// We expect this to work in a real environment, but in tests it might fail due to file system
-	// The important thing is that the method exists and handles the call properly
-	if err != nil {
-		// Check that it's a reasonable error (like file system access)
-		assert.Contains(t, err.Error(), "file")
-	}
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, data, written)
 }
 
 func TestStandardReportGenerator_FilterBySeverity(t *testing.T) {
@@ -0,0 +1,84 @@
+package report
+
+import (
+	"sort"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// maxTopAttributes caps Summary.TopAttributes
+const maxTopAttributes = 5
+
+// AttributeCount is the number of drifted resources an attribute drifted on
+type AttributeCount struct {
+	Attribute string `json:"attribute"`
+	Count     int    `json:"count"`
+}
+
+// Summary is a typed rollup of a drift result set
+type Summary struct {
+	TotalResources   int `json:"total_resources"`
+	DriftedResources int `json:"drifted_resources"`
+	CleanResources   int `json:"clean_resources"`
+	TotalDifferences int `json:"total_differences"`
+	// SeverityCounts counts resources by their overall severity, including
+	// clean resources under SeverityNone
+	SeverityCounts  map[interfaces.SeverityLevel]int `json:"severity_counts"`
+	HighestSeverity interfaces.SeverityLevel         `json:"highest_severity"`
+	// TopAttributes lists the attributes that drifted on the most resources,
+	// most frequent first
+	TopAttributes []AttributeCount `json:"top_attributes"`
+}
+
+// Summarize counts resources, differences and severities in results. Nil
+// results are skipped.
+func Summarize(results map[string]*interfaces.DriftResult) Summary {
+	summary := Summary{
+		SeverityCounts:  make(map[interfaces.SeverityLevel]int),
+		HighestSeverity: interfaces.SeverityNone,
+		TopAttributes:   []AttributeCount{},
+	}
+
+	attributeCounts := make(map[string]int)
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		summary.TotalResources++
+		summary.SeverityCounts[result.Severity]++
+
+		if !result.IsDrifted {
+			continue
+		}
+		summary.DriftedResources++
+		summary.TotalDifferences += len(result.DriftDetails)
+		if getSeverityOrder(result.Severity) > getSeverityOrder(summary.HighestSeverity) {
+			summary.HighestSeverity = result.Severity
+		}
+
+		seen := make(map[string]bool)
+		for _, detail := range result.DriftDetails {
+			if detail != nil && !seen[detail.Attribute] {
+				seen[detail.Attribute] = true
+				attributeCounts[detail.Attribute]++
+			}
+		}
+	}
+	summary.CleanResources = summary.TotalResources - summary.DriftedResources
+
+	for attr, count := range attributeCounts {
+		summary.TopAttributes = append(summary.TopAttributes, AttributeCount{Attribute: attr, Count: count})
+	}
+	sort.Slice(summary.TopAttributes, func(i, j int) bool {
+		a, b := summary.TopAttributes[i], summary.TopAttributes[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Attribute < b.Attribute
+	})
+	if len(summary.TopAttributes) > maxTopAttributes {
+		summary.TopAttributes = summary.TopAttributes[:maxTopAttributes]
+	}
+
+	return summary
+}
@@ -0,0 +1,63 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"firefly-task/pkg/interfaces"
+)
+
+func TestSummarize(t *testing.T) {
+	detail := func(attr string, severity interfaces.SeverityLevel) *interfaces.DriftDetail {
+		return &interfaces.DriftDetail{Attribute: attr, ExpectedValue: "a", ActualValue: "b", Severity: severity}
+	}
+	results := map[string]*interfaces.DriftResult{
+		"aws_instance.web": {
+			ResourceID: "aws_instance.web",
+			IsDrifted:  true,
+			Severity:   interfaces.SeverityCritical,
+			DriftDetails: []*interfaces.DriftDetail{
+				detail("security_groups", interfaces.SeverityCritical),
+				detail("tags", interfaces.SeverityMedium),
+			},
+		},
+		"aws_instance.api": {
+			ResourceID:   "aws_instance.api",
+			IsDrifted:    true,
+			Severity:     interfaces.SeverityMedium,
+			DriftDetails: []*interfaces.DriftDetail{detail("tags", interfaces.SeverityMedium)},
+		},
+		"aws_instance.worker": {
+			ResourceID:   "aws_instance.worker",
+			IsDrifted:    true,
+			Severity:     interfaces.SeverityLow,
+			DriftDetails: []*interfaces.DriftDetail{detail("monitoring", interfaces.SeverityLow)},
+		},
+		"aws_instance.clean": {ResourceID: "aws_instance.clean", Severity: interfaces.SeverityNone},
+		"aws_instance.nil":   nil,
+	}
+
+	summary := Summarize(results)
+
+	assert.Equal(t, 4, summary.TotalResources)
+	assert.Equal(t, 3, summary.DriftedResources)
+	assert.Equal(t, 1, summary.CleanResources)
+	assert.Equal(t, 4, summary.TotalDifferences)
+	assert.Equal(t, interfaces.SeverityCritical, summary.HighestSeverity)
+	assert.Equal(t, map[interfaces.SeverityLevel]int{
+		interfaces.SeverityCritical: 1,
+		interfaces.SeverityMedium:   1,
+		interfaces.SeverityLow:      1,
+		interfaces.SeverityNone:     1,
+	}, summary.SeverityCounts)
+	assert.Equal(t, []AttributeCount{
+		{Attribute: "tags", Count: 2},
+		{Attribute: "monitoring", Count: 1},
+		{Attribute: "security_groups", Count: 1},
+	}, summary.TopAttributes)
+
+	ci := NewCIReportGenerator().buildCISummary(results)
+	assert.Equal(t, summary.DriftedResources, ci.ResourcesWithDrift)
+	assert.Equal(t, "CRITICAL", ci.HighestSeverity)
+}
@@ -0,0 +1,158 @@
+package report
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"firefly-task/pkg/interfaces"
+)
+
+// WebhookSchemaVersion is the version of the webhook payload shape. It is
+// sent as the payload's schema_version field and the X-Firefly-Schema-Version
+// header so receivers can detect breaking changes, and is bumped whenever a
+// field is renamed, removed or changes type.
+//
+// Version 1 is an object with schema_version, generated_at (RFC 3339),
+// summary (see Summary) and results, the drift results keyed by resource ID.
+const WebhookSchemaVersion = "1"
+
+// WebhookSchemaVersionHeader carries WebhookSchemaVersion on webhook requests
+const WebhookSchemaVersionHeader = "X-Firefly-Schema-Version"
+
+// WebhookPayload is the JSON body posted by SendToWebhook
+type WebhookPayload struct {
+	SchemaVersion string                             `json:"schema_version"`
+	GeneratedAt   time.Time                          `json:"generated_at"`
+	Summary       Summary                            `json:"summary"`
+	Results       map[string]*interfaces.DriftResult `json:"results"`
+}
+
+// GenerateWebhookPayload builds the webhook body for results
+func GenerateWebhookPayload(results map[string]*interfaces.DriftResult) ([]byte, error) {
+	payload := WebhookPayload{
+		SchemaVersion: WebhookSchemaVersion,
+		GeneratedAt:   time.Now().UTC(),
+		Summary:       Summarize(results),
+		Results:       results,
+	}
+	if payload.Results == nil {
+		payload.Results = map[string]*interfaces.DriftResult{}
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, WrapError(ErrorTypeMarshaling, "failed to marshal webhook payload", err)
+	}
+	return data, nil
+}
+
+// WebhookSignatureHeader carries the HMAC-SHA256 of the request body, keyed
+// with ReportConfig.WebhookSecret, as "sha256=<hex>"
+const WebhookSignatureHeader = "X-Firefly-Signature"
+
+// Webhook delivery defaults
+const (
+	DefaultWebhookTimeout      = 30 * time.Second
+	DefaultWebhookRetryBackoff = 500 * time.Millisecond
+)
+
+// maxWebhookErrorBody caps the response body quoted in webhook errors
+const maxWebhookErrorBody = 512
+
+// SendToWebhook posts the webhook payload for results to webhookURL. Requests
+// answered with a 5xx status are retried up to WebhookMaxRetries times with
+// exponential backoff; any other non-2xx status fails immediately.
+func (ru *ReportUploader) SendToWebhook(results map[string]*interfaces.DriftResult, webhookURL string) error {
+	if webhookURL == "" {
+		return NewReportError(ErrorTypeConfiguration, "webhook URL is required")
+	}
+
+	payload, err := GenerateWebhookPayload(results)
+	if err != nil {
+		return err
+	}
+
+	config := ru.config
+	if config == nil {
+		config = NewReportConfig()
+	}
+	timeout := config.WebhookTimeout
+	if timeout <= 0 {
+		timeout = DefaultWebhookTimeout
+	}
+	backoff := config.WebhookRetryBackoff
+	if backoff <= 0 {
+		backoff = DefaultWebhookRetryBackoff
+	}
+
+	client := &http.Client{Timeout: timeout}
+	for attempt := 0; ; attempt++ {
+		err := postWebhook(client, webhookURL, payload, config.WebhookSecret)
+		if err == nil {
+			return nil
+		}
+		var statusErr *webhookStatusError
+		if !errors.As(err, &statusErr) || statusErr.statusCode < 500 || attempt >= config.WebhookMaxRetries {
+			return err
+		}
+		time.Sleep(backoff << attempt)
+	}
+}
+
+// webhookStatusError is a non-2xx webhook response
+type webhookStatusError struct {
+	statusCode int
+	status     string
+	body       string
+}
+
+func (e *webhookStatusError) Error() string {
+	return fmt.Sprintf("webhook returned %s: %s", e.status, e.body)
+}
+
+// postWebhook makes a single webhook request
+func postWebhook(client *http.Client, webhookURL string, payload []byte, secret string) error {
+	req, err := http.NewRequest(http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return WrapError(ErrorTypeConfiguration, "failed to build webhook request", err)
+	}
+	req.Header.Set("Content-Type", FormatJSON.MIMEType())
+	req.Header.Set(WebhookSchemaVersionHeader, WebhookSchemaVersion)
+	if secret != "" {
+		req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(payload, secret))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return WrapError(ErrorTypeGenerationFailed, "failed to send webhook", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookErrorBody+1))
+		text := strings.TrimSpace(string(body))
+		if len(body) > maxWebhookErrorBody {
+			text = strings.TrimSpace(string(body[:maxWebhookErrorBody])) + "..."
+		}
+		return WrapError(ErrorTypeGenerationFailed, "webhook delivery failed",
+			&webhookStatusError{statusCode: resp.StatusCode, status: resp.Status, body: text})
+	}
+
+	return nil
+}
+
+// SignWebhookPayload returns the WebhookSignatureHeader value for payload
+func SignWebhookPayload(payload []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
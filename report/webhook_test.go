@@ -0,0 +1,136 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportUploader_SendToWebhook(t *testing.T) {
+	var header string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get(WebhookSchemaVersionHeader)
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	uploader := NewReportUploader(NewReportConfig())
+	data := createTestReportData()
+	require.NoError(t, uploader.SendToWebhook(data, server.URL))
+
+	assert.Equal(t, WebhookSchemaVersion, header)
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, WebhookSchemaVersion, payload["schema_version"])
+	assert.Contains(t, payload, "summary")
+	assert.Len(t, payload["results"], len(data))
+}
+
+func TestReportUploader_SendToWebhook_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad payload", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	uploader := NewReportUploader(NewReportConfig())
+	err := uploader.SendToWebhook(createTestReportData(), server.URL)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "400")
+	assert.Contains(t, err.Error(), "bad payload")
+}
+
+func TestReportUploader_SendToWebhook_Signature(t *testing.T) {
+	var contentType, signature string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		signature = r.Header.Get(WebhookSignatureHeader)
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	config := NewReportConfig()
+	config.WebhookSecret = "s3cret"
+	require.NoError(t, NewReportUploader(config).SendToWebhook(createTestReportData(), server.URL))
+
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, SignWebhookPayload(body, "s3cret"), signature)
+	assert.True(t, strings.HasPrefix(signature, "sha256="))
+	assert.NotEqual(t, SignWebhookPayload(body, "other"), signature)
+
+	// Unsigned without a secret
+	require.NoError(t, NewReportUploader(NewReportConfig()).SendToWebhook(createTestReportData(), server.URL))
+	assert.Empty(t, signature)
+}
+
+func TestReportUploader_SendToWebhook_RetryThenSuccess(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			http.Error(w, "try again", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := NewReportConfig()
+	config.WebhookMaxRetries = 3
+	config.WebhookRetryBackoff = time.Millisecond
+	require.NoError(t, NewReportUploader(config).SendToWebhook(createTestReportData(), server.URL))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestReportUploader_SendToWebhook_PermanentFailure(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		http.Error(w, strings.Repeat("x", 2*maxWebhookErrorBody), http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	config := NewReportConfig()
+	config.WebhookMaxRetries = 2
+	config.WebhookRetryBackoff = time.Millisecond
+	err := NewReportUploader(config).SendToWebhook(createTestReportData(), server.URL)
+	require.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts), "expected the first attempt plus two retries")
+	assert.True(t, IsReportError(err, ErrorTypeGenerationFailed))
+	assert.Contains(t, err.Error(), "500")
+	assert.Contains(t, err.Error(), "...")
+	assert.NotContains(t, err.Error(), strings.Repeat("x", maxWebhookErrorBody+1))
+
+	// Client errors are not retried
+	atomic.StoreInt32(&attempts, 0)
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer rejecting.Close()
+	require.Error(t, NewReportUploader(config).SendToWebhook(createTestReportData(), rejecting.URL))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestReportUploader_SendToWebhook_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	config := NewReportConfig()
+	config.WebhookTimeout = 20 * time.Millisecond
+	err := NewReportUploader(config).SendToWebhook(createTestReportData(), server.URL)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send webhook")
+}
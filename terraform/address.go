@@ -0,0 +1,106 @@
+package terraform
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RootModule is the module path of resources not declared inside a module
+const RootModule = "root"
+
+// ResourceAddress is a parsed Terraform resource address such as
+// module.app["eu.west"].aws_instance.web[0]
+type ResourceAddress struct {
+	// Module is the module path, e.g. `module.app["eu.west"]`, or "" for the
+	// root module
+	Module string
+	Type   string
+	Name   string
+	// Index is the count or for_each key including brackets, e.g. "[0]"
+	Index string
+}
+
+// ModulePath returns Module, or RootModule for root module resources
+func (a ResourceAddress) ModulePath() string {
+	if a.Module == "" {
+		return RootModule
+	}
+	return a.Module
+}
+
+// String renders the address in Terraform syntax
+func (a ResourceAddress) String() string {
+	address := a.Type + "." + a.Name + a.Index
+	if a.Module != "" {
+		address = a.Module + "." + address
+	}
+	return address
+}
+
+// ParseResourceAddress parses a resource address. Dots inside index brackets
+// and quoted keys are not treated as separators, so for_each keys such as
+// ["eu.west"] are kept intact.
+func ParseResourceAddress(address string) (ResourceAddress, error) {
+	parts, err := splitAddress(address)
+	if err != nil {
+		return ResourceAddress{}, err
+	}
+
+	var modules []string
+	for len(parts) >= 2 && parts[0] == "module" {
+		modules = append(modules, "module."+parts[1])
+		parts = parts[2:]
+	}
+	if len(parts) == 3 && parts[0] == "data" {
+		parts = []string{"data." + parts[1], parts[2]}
+	}
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return ResourceAddress{}, fmt.Errorf("invalid resource address %q: expected [module.<name>.]<type>.<name>", address)
+	}
+
+	name, index := parts[1], ""
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		name, index = name[:i], name[i:]
+	}
+	return ResourceAddress{
+		Module: strings.Join(modules, "."),
+		Type:   parts[0],
+		Name:   name,
+		Index:  index,
+	}, nil
+}
+
+// splitAddress splits address on dots outside brackets and quotes
+func splitAddress(address string) ([]string, error) {
+	var parts []string
+	var current strings.Builder
+	depth, quoted := 0, false
+	for i := 0; i < len(address); i++ {
+		c := address[i]
+		switch {
+		case quoted && c == '\\' && i+1 < len(address):
+			current.WriteByte(c)
+			i++
+			c = address[i]
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("invalid resource address %q: unbalanced brackets", address)
+			}
+		case c == '.' && depth == 0:
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteByte(c)
+	}
+	if depth != 0 || quoted {
+		return nil, fmt.Errorf("invalid resource address %q: unterminated index", address)
+	}
+	return append(parts, current.String()), nil
+}
@@ -0,0 +1,47 @@
+package terraform
+
+import (
+	"testing"
+)
+
+func TestParseResourceAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		want    ResourceAddress
+		module  string
+	}{
+		{"aws_instance.web", ResourceAddress{Type: "aws_instance", Name: "web"}, RootModule},
+		{"aws_instance.web[0]", ResourceAddress{Type: "aws_instance", Name: "web", Index: "[0]"}, RootModule},
+		{"module.app.aws_instance.web", ResourceAddress{Module: "module.app", Type: "aws_instance", Name: "web"}, "module.app"},
+		{
+			`module.app["eu.west"].module.db.aws_db_instance.main["a.b"]`,
+			ResourceAddress{Module: `module.app["eu.west"].module.db`, Type: "aws_db_instance", Name: "main", Index: `["a.b"]`},
+			`module.app["eu.west"].module.db`,
+		},
+		{"data.aws_ami.ubuntu", ResourceAddress{Type: "data.aws_ami", Name: "ubuntu"}, RootModule},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			got, err := ParseResourceAddress(tt.address)
+			if err != nil {
+				t.Fatalf("ParseResourceAddress() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseResourceAddress() = %+v, want %+v", got, tt.want)
+			}
+			if got.ModulePath() != tt.module {
+				t.Errorf("ModulePath() = %q, want %q", got.ModulePath(), tt.module)
+			}
+			if got.String() != tt.address {
+				t.Errorf("String() = %q, want %q", got.String(), tt.address)
+			}
+		})
+	}
+
+	for _, invalid := range []string{"", "web", "module.app", `aws_instance.web["a`, "aws_instance.web]"} {
+		if _, err := ParseResourceAddress(invalid); err == nil {
+			t.Errorf("ParseResourceAddress(%q) expected an error", invalid)
+		}
+	}
+}
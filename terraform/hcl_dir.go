@@ -0,0 +1,194 @@
+package terraform
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// ParseHCLDir reads the .tf files in dir and returns the aws_instance
+// resources they declare, keyed by address (e.g. aws_instance.web).
+// Attributes whose values reference variables, other resources or functions
+// cannot be resolved without a plan; they are left empty and listed in
+// TerraformConfig.UnknownAttributes so drift detection skips them.
+// Subdirectories are not read.
+func ParseHCLDir(dir string) (map[string]*TerraformConfig, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Terraform files in %s: %w", dir, err)
+	}
+	sort.Strings(files)
+
+	parser := hclparse.NewParser()
+	configs := make(map[string]*TerraformConfig)
+	for _, file := range files {
+		parsed, diags := parser.ParseHCLFile(file)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("failed to parse %s: %s", file, diags.Error())
+		}
+		body, ok := parsed.Body.(*hclsyntax.Body)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse %s: unexpected body type %T", file, parsed.Body)
+		}
+
+		for _, block := range body.Blocks {
+			if block.Type != "resource" || len(block.Labels) != 2 || block.Labels[0] != "aws_instance" {
+				continue
+			}
+			address := block.Labels[0] + "." + block.Labels[1]
+			if _, exists := configs[address]; exists {
+				return nil, fmt.Errorf("duplicate resource %s in %s", address, file)
+			}
+			configs[address] = hclInstanceConfig(address, block.Labels[1], block.Body)
+		}
+	}
+	return configs, nil
+}
+
+// hclInstanceConfig maps the attributes of an aws_instance block. Attribute
+// names in UnknownAttributes use the drift detector's names, so
+// vpc_security_group_ids is recorded as security_groups.
+func hclInstanceConfig(address, name string, body *hclsyntax.Body) *TerraformConfig {
+	config := &TerraformConfig{
+		ResourceID:   address,
+		ResourceName: name,
+	}
+	unknown := func(attr string) {
+		config.UnknownAttributes = append(config.UnknownAttributes, attr)
+	}
+
+	stringFields := map[string]*string{
+		"instance_type":     &config.InstanceType,
+		"ami":               &config.AMI,
+		"subnet_id":         &config.SubnetID,
+		"key_name":          &config.KeyName,
+		"availability_zone": &config.AvailabilityZone,
+		"private_ip":        &config.PrivateIP,
+	}
+	boolFields := map[string]**bool{
+		"monitoring":                  &config.Monitoring,
+		"ebs_optimized":               &config.EBSOptimized,
+		"associate_public_ip_address": &config.AssociatePublicIPAddress,
+		"source_dest_check":           &config.SourceDestCheck,
+	}
+
+	for attrName, attr := range body.Attributes {
+		value, known := staticValue(attr.Expr)
+		switch {
+		case stringFields[attrName] != nil:
+			if s, ok := ctyString(value); known && ok {
+				*stringFields[attrName] = s
+			} else {
+				unknown(attrName)
+			}
+		case boolFields[attrName] != nil:
+			if b, ok := ctyBool(value); known && ok {
+				*boolFields[attrName] = &b
+			} else {
+				unknown(attrName)
+			}
+		case attrName == "tags":
+			if tags, ok := ctyStringMap(value); known && ok {
+				config.Tags = tags
+			} else {
+				unknown("tags")
+			}
+		case attrName == "vpc_security_group_ids":
+			if ids, ok := ctyStringList(value); known && ok {
+				config.SecurityGroups = ids
+			} else {
+				config.SecurityGroupRefs = expressionRefs(attr.Expr)
+				unknown("security_groups")
+			}
+		}
+	}
+	sort.Strings(config.UnknownAttributes)
+	return config
+}
+
+// staticValue evaluates expr without variables or functions and reports
+// whether the result is fully known
+func staticValue(expr hcl.Expression) (cty.Value, bool) {
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || !value.IsWhollyKnown() || value.IsNull() {
+		return cty.NullVal(cty.DynamicPseudoType), false
+	}
+	return value, true
+}
+
+// expressionRefs returns the references an expression makes, such as
+// aws_security_group.web.id
+func expressionRefs(expr hcl.Expression) []string {
+	var refs []string
+	for _, traversal := range expr.Variables() {
+		parts := []string{traversal.RootName()}
+		for _, step := range traversal[1:] {
+			if attr, ok := step.(hcl.TraverseAttr); ok {
+				parts = append(parts, attr.Name)
+			}
+		}
+		refs = append(refs, strings.Join(parts, "."))
+	}
+	return refs
+}
+
+func ctyString(value cty.Value) (string, bool) {
+	if value.IsNull() || !value.IsKnown() {
+		return "", false
+	}
+	converted, err := convert.Convert(value, cty.String)
+	if err != nil {
+		return "", false
+	}
+	return converted.AsString(), true
+}
+
+func ctyBool(value cty.Value) (bool, bool) {
+	if value.IsNull() || !value.IsKnown() {
+		return false, false
+	}
+	converted, err := convert.Convert(value, cty.Bool)
+	if err != nil {
+		return false, false
+	}
+	return converted.True(), true
+}
+
+func ctyStringMap(value cty.Value) (map[string]string, bool) {
+	if value.IsNull() || !(value.Type().IsObjectType() || value.Type().IsMapType()) {
+		return nil, false
+	}
+	m := make(map[string]string)
+	for it := value.ElementIterator(); it.Next(); {
+		key, element := it.Element()
+		s, ok := ctyString(element)
+		if !ok {
+			return nil, false
+		}
+		m[key.AsString()] = s
+	}
+	return m, true
+}
+
+func ctyStringList(value cty.Value) ([]string, bool) {
+	if value.IsNull() || !(value.Type().IsTupleType() || value.Type().IsListType() || value.Type().IsSetType()) {
+		return nil, false
+	}
+	var list []string
+	for it := value.ElementIterator(); it.Next(); {
+		_, element := it.Element()
+		s, ok := ctyString(element)
+		if !ok {
+			return nil, false
+		}
+		list = append(list, s)
+	}
+	return list, true
+}
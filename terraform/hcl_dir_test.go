@@ -0,0 +1,88 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseHCLDir(t *testing.T) {
+	configs, err := ParseHCLDir(filepath.Join("testdata", "hcl_instances"))
+	if err != nil {
+		t.Fatalf("ParseHCLDir() error = %v", err)
+	}
+
+	if len(configs) != 3 {
+		t.Fatalf("Expected 3 aws_instance resources, got %d: %v", len(configs), configs)
+	}
+
+	web := configs["aws_instance.web"]
+	if web == nil {
+		t.Fatal("Expected aws_instance.web")
+	}
+	if web.ResourceName != "web" || web.AMI != "ami-12345678" || web.InstanceType != "t3.micro" || web.SubnetID != "subnet-12345" {
+		t.Errorf("Unexpected web instance: %+v", web)
+	}
+	if web.Monitoring == nil || !*web.Monitoring {
+		t.Errorf("Expected monitoring to be true, got %v", web.Monitoring)
+	}
+	if !reflect.DeepEqual(web.SecurityGroups, []string{"sg-12345", "sg-67890"}) {
+		t.Errorf("Unexpected security groups: %v", web.SecurityGroups)
+	}
+	if !reflect.DeepEqual(web.Tags, map[string]string{"Name": "web", "Environment": "prod"}) {
+		t.Errorf("Unexpected tags: %v", web.Tags)
+	}
+	if len(web.UnknownAttributes) != 0 {
+		t.Errorf("Expected no unknown attributes, got %v", web.UnknownAttributes)
+	}
+
+	app := configs["aws_instance.app"]
+	if app == nil {
+		t.Fatal("Expected aws_instance.app")
+	}
+	wantUnknown := []string{"ami", "instance_type", "security_groups", "tags"}
+	if !reflect.DeepEqual(app.UnknownAttributes, wantUnknown) {
+		t.Errorf("Expected unknown attributes %v, got %v", wantUnknown, app.UnknownAttributes)
+	}
+	if app.AMI != "" || app.InstanceType != "" || app.Tags != nil {
+		t.Errorf("Expected unresolved values to be left empty, got %+v", app)
+	}
+	if !reflect.DeepEqual(app.SecurityGroupRefs, []string{"aws_security_group.app.id"}) {
+		t.Errorf("Expected security group references to be recorded, got %v", app.SecurityGroupRefs)
+	}
+
+	// Interpolations of literals resolve statically
+	if worker := configs["aws_instance.worker"]; worker == nil || worker.InstanceType != "m5.large" || worker.KeyName != "deploy" {
+		t.Errorf("Unexpected worker instance: %+v", worker)
+	}
+}
+
+func TestParseHCLDir_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`resource "aws_instance" "web" {`), 0644); err != nil {
+		t.Fatalf("Failed to write fixture: %v", err)
+	}
+	if _, err := ParseHCLDir(dir); err == nil {
+		t.Error("Expected an error for invalid HCL")
+	}
+
+	dir = t.TempDir()
+	block := []byte(`resource "aws_instance" "web" {
+  ami = "ami-1"
+}
+`)
+	for _, name := range []string{"a.tf", "b.tf"} {
+		if err := os.WriteFile(filepath.Join(dir, name), block, 0644); err != nil {
+			t.Fatalf("Failed to write fixture: %v", err)
+		}
+	}
+	if _, err := ParseHCLDir(dir); err == nil {
+		t.Error("Expected an error for a resource declared twice")
+	}
+
+	configs, err := ParseHCLDir(t.TempDir())
+	if err != nil || len(configs) != 0 {
+		t.Errorf("Expected no resources and no error for an empty directory, got %v, %v", configs, err)
+	}
+}
@@ -41,6 +41,10 @@ type TerraformConfig struct {
 	// Metadata
 	TerraformVersion string `json:"terraform_version,omitempty"`
 	ProviderVersion  string `json:"provider_version,omitempty"`
+
+	// UnknownAttributes lists attributes whose values could not be resolved
+	// statically (e.g. they reference variables); drift detection skips them
+	UnknownAttributes []string `json:"unknown_attributes,omitempty"`
 }
 
 // BlockDevice represents EBS block device configuration
@@ -69,6 +73,45 @@ type EC2InstanceConfig struct {
 	ResourceName      string            `json:"resource_name"`
 }
 
+// S3BucketConfig represents the expected S3 bucket configuration, merged from
+// aws_s3_bucket and its companion resources (versioning, policy, lifecycle,
+// public access block, encryption and logging)
+type S3BucketConfig struct {
+	ResourceName         string                     `json:"resource_name"`
+	Bucket               string                     `json:"bucket"`
+	Versioning           bool                       `json:"versioning"`
+	Policy               string                     `json:"policy,omitempty"`
+	LifecycleRules       []S3LifecycleRuleConfig    `json:"lifecycle_rule,omitempty"`
+	PublicAccessBlock    *S3PublicAccessBlockConfig `json:"public_access_block,omitempty"`
+	ServerSideEncryption *S3EncryptionConfig        `json:"server_side_encryption_configuration,omitempty"`
+	LoggingTarget        string                     `json:"logging_target_bucket,omitempty"`
+	Tags                 map[string]string          `json:"tags,omitempty"`
+}
+
+// S3LifecycleRuleConfig represents a lifecycle rule of an S3 bucket
+type S3LifecycleRuleConfig struct {
+	ID                     string `json:"id"`
+	Prefix                 string `json:"prefix,omitempty"`
+	Status                 string `json:"status"`
+	ExpirationDays         *int   `json:"expiration_days,omitempty"`
+	TransitionDays         *int   `json:"transition_days,omitempty"`
+	TransitionStorageClass string `json:"transition_storage_class,omitempty"`
+}
+
+// S3PublicAccessBlockConfig represents an aws_s3_bucket_public_access_block
+type S3PublicAccessBlockConfig struct {
+	BlockPublicACLs       bool `json:"block_public_acls"`
+	BlockPublicPolicy     bool `json:"block_public_policy"`
+	IgnorePublicACLs      bool `json:"ignore_public_acls"`
+	RestrictPublicBuckets bool `json:"restrict_public_buckets"`
+}
+
+// S3EncryptionConfig represents the default encryption rule of an S3 bucket
+type S3EncryptionConfig struct {
+	SSEAlgorithm   string `json:"sse_algorithm"`
+	KMSMasterKeyID string `json:"kms_master_key_id,omitempty"`
+}
+
 // ResourceMapping represents the mapping between Terraform resources and AWS resources
 type ResourceMapping struct {
 	TerraformID  string `json:"terraform_id"`  // e.g., "aws_instance.web"
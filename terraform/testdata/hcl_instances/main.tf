@@ -0,0 +1,27 @@
+variable "instance_type" {
+  default = "t3.micro"
+}
+
+resource "aws_instance" "web" {
+  ami                    = "ami-12345678"
+  instance_type          = "t3.micro"
+  subnet_id              = "subnet-12345"
+  monitoring             = true
+  vpc_security_group_ids = ["sg-12345", "sg-67890"]
+
+  tags = {
+    Name        = "web"
+    Environment = "prod"
+  }
+}
+
+resource "aws_instance" "app" {
+  ami                    = data.aws_ami.ubuntu.id
+  instance_type          = var.instance_type
+  vpc_security_group_ids = [aws_security_group.app.id]
+
+  tags = {
+    Name  = "app"
+    Owner = var.owner
+  }
+}
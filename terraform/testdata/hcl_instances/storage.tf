@@ -0,0 +1,9 @@
+resource "aws_s3_bucket" "logs" {
+  bucket = "app-logs"
+}
+
+resource "aws_instance" "worker" {
+  ami           = "ami-87654321"
+  instance_type = "m5.${"large"}"
+  key_name      = "deploy"
+}
@@ -0,0 +1,210 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// rawState is the on-disk layout of a terraform.tfstate file (state version 4)
+type rawState struct {
+	Version          int                `json:"version"`
+	TerraformVersion string             `json:"terraform_version"`
+	Resources        []rawStateResource `json:"resources"`
+	// Values is only set on the output of `terraform show -json`
+	Values *tfjson.StateValues `json:"values"`
+}
+
+// rawStateResource is a resource block of a state file. Its instances hold
+// the attributes, one per count index or for_each key.
+type rawStateResource struct {
+	Module    string             `json:"module"`
+	Mode      string             `json:"mode"`
+	Type      string             `json:"type"`
+	Name      string             `json:"name"`
+	Instances []rawStateInstance `json:"instances"`
+}
+
+// rawStateInstance is one instance of a state resource
+type rawStateInstance struct {
+	IndexKey   interface{}            `json:"index_key"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// ParseStateFile reads a terraform.tfstate file and returns the managed
+// aws_instance resources it records, keyed by resource address. Resources
+// with count or for_each yield one entry per instance, addressed with their
+// index key (e.g. aws_instance.web[0] or aws_instance.web["blue"]). The JSON
+// printed by `terraform show -json` is accepted as well.
+func ParseStateFile(path string) (map[string]*TerraformConfig, error) {
+	if path == "" {
+		return nil, fmt.Errorf("state file path cannot be empty")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	var state rawState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file: %w", err)
+	}
+
+	configs := make(map[string]*TerraformConfig)
+	if state.Values != nil && state.Values.RootModule != nil {
+		collectStateModule(state.Values.RootModule, state.TerraformVersion, configs)
+		return configs, nil
+	}
+
+	for _, resource := range state.Resources {
+		if resource.Mode != "managed" || resource.Type != "aws_instance" {
+			continue
+		}
+		for _, instance := range resource.Instances {
+			address := ResourceAddress{
+				Module: resource.Module,
+				Type:   resource.Type,
+				Name:   resource.Name,
+				Index:  formatIndexKey(instance.IndexKey),
+			}.String()
+			configs[address] = stateAttributesToConfig(address, resource.Name, instance.Attributes, state.TerraformVersion)
+		}
+	}
+	return configs, nil
+}
+
+// collectStateModule adds the aws_instance resources of a `terraform show
+// -json` module and its children to configs
+func collectStateModule(module *tfjson.StateModule, terraformVersion string, configs map[string]*TerraformConfig) {
+	for _, resource := range module.Resources {
+		if resource.Mode != tfjson.ManagedResourceMode || resource.Type != "aws_instance" {
+			continue
+		}
+		configs[resource.Address] = stateAttributesToConfig(resource.Address, resource.Name, resource.AttributeValues, terraformVersion)
+	}
+	for _, child := range module.ChildModules {
+		collectStateModule(child, terraformVersion, configs)
+	}
+}
+
+// formatIndexKey renders a count index or for_each key in address syntax, or
+// "" for resources without one
+func formatIndexKey(key interface{}) string {
+	switch k := key.(type) {
+	case nil:
+		return ""
+	case float64:
+		return fmt.Sprintf("[%d]", int(k))
+	case string:
+		return "[" + strconv.Quote(k) + "]"
+	default:
+		return fmt.Sprintf("[%v]", k)
+	}
+}
+
+// stateAttributesToConfig maps the attributes of an aws_instance state
+// instance onto a TerraformConfig
+func stateAttributesToConfig(address, name string, attrs map[string]interface{}, terraformVersion string) *TerraformConfig {
+	config := &TerraformConfig{
+		ResourceID:       address,
+		ResourceName:     name,
+		InstanceID:       stateString(attrs, "id"),
+		InstanceType:     stateString(attrs, "instance_type"),
+		AMI:              stateString(attrs, "ami"),
+		KeyName:          stateString(attrs, "key_name"),
+		SubnetID:         stateString(attrs, "subnet_id"),
+		AvailabilityZone: stateString(attrs, "availability_zone"),
+		PrivateIP:        stateString(attrs, "private_ip"),
+		PublicIP:         stateString(attrs, "public_ip"),
+		EBSOptimized:     stateBool(attrs, "ebs_optimized"),
+		Monitoring:       stateBool(attrs, "monitoring"),
+		Tags:             stateStringMap(attrs, "tags"),
+		SecurityGroups:   stateStringList(attrs, "vpc_security_group_ids"),
+
+		AssociatePublicIPAddress: stateBool(attrs, "associate_public_ip_address"),
+		SourceDestCheck:          stateBool(attrs, "source_dest_check"),
+		TerraformVersion:         terraformVersion,
+	}
+
+	if devices := stateBlocks(attrs, "root_block_device"); len(devices) > 0 {
+		config.RootBlockDevice = stateBlockDevice(devices[0])
+	}
+	for _, device := range stateBlocks(attrs, "ebs_block_device") {
+		config.EBSBlockDevices = append(config.EBSBlockDevices, stateBlockDevice(device))
+	}
+	return config
+}
+
+// stateBlockDevice maps a root_block_device or ebs_block_device block
+func stateBlockDevice(attrs map[string]interface{}) *BlockDevice {
+	return &BlockDevice{
+		DeviceName:          stateString(attrs, "device_name"),
+		VolumeType:          stateString(attrs, "volume_type"),
+		VolumeSize:          stateInt(attrs, "volume_size"),
+		IOPS:                stateInt(attrs, "iops"),
+		Throughput:          stateInt(attrs, "throughput"),
+		Encrypted:           stateBool(attrs, "encrypted"),
+		KMSKeyID:            stateString(attrs, "kms_key_id"),
+		DeleteOnTermination: stateBool(attrs, "delete_on_termination"),
+		SnapshotID:          stateString(attrs, "snapshot_id"),
+		Tags:                stateStringMap(attrs, "tags"),
+	}
+}
+
+func stateString(attrs map[string]interface{}, key string) string {
+	s, _ := attrs[key].(string)
+	return s
+}
+
+func stateInt(attrs map[string]interface{}, key string) int {
+	n, _ := attrs[key].(float64)
+	return int(n)
+}
+
+func stateBool(attrs map[string]interface{}, key string) *bool {
+	b, ok := attrs[key].(bool)
+	if !ok {
+		return nil
+	}
+	return &b
+}
+
+func stateStringList(attrs map[string]interface{}, key string) []string {
+	items, _ := attrs[key].([]interface{})
+	var list []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+func stateStringMap(attrs map[string]interface{}, key string) map[string]string {
+	values, ok := attrs[key].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	m := make(map[string]string, len(values))
+	for k, v := range values {
+		if s, ok := v.(string); ok {
+			m[k] = s
+		}
+	}
+	return m
+}
+
+func stateBlocks(attrs map[string]interface{}, key string) []map[string]interface{} {
+	items, _ := attrs[key].([]interface{})
+	var blocks []map[string]interface{}
+	for _, item := range items {
+		if block, ok := item.(map[string]interface{}); ok {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
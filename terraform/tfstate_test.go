@@ -0,0 +1,157 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// sampleRawState is a terraform.tfstate with a counted instance, a for_each
+// instance in a module, and resources that are not extracted
+const sampleRawState = `{
+  "version": 4,
+  "terraform_version": "1.5.7",
+  "serial": 12,
+  "lineage": "3f2c",
+  "outputs": {},
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "aws_instance",
+      "name": "web",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [
+        {
+          "index_key": 0,
+          "schema_version": 1,
+          "attributes": {
+            "id": "i-0aaa",
+            "ami": "ami-12345678",
+            "instance_type": "t3.micro",
+            "availability_zone": "us-east-1a",
+            "subnet_id": "subnet-1",
+            "monitoring": true,
+            "ebs_optimized": false,
+            "vpc_security_group_ids": ["sg-1", "sg-2"],
+            "tags": {"Name": "web-0"},
+            "root_block_device": [
+              {"device_name": "/dev/xvda", "volume_type": "gp3", "volume_size": 20, "encrypted": true}
+            ]
+          }
+        },
+        {
+          "index_key": 1,
+          "schema_version": 1,
+          "attributes": {
+            "id": "i-0bbb",
+            "ami": "ami-12345678",
+            "instance_type": "t3.small",
+            "tags": {"Name": "web-1"}
+          }
+        }
+      ]
+    },
+    {
+      "module": "module.workers",
+      "mode": "managed",
+      "type": "aws_instance",
+      "name": "worker",
+      "instances": [
+        {
+          "index_key": "blue",
+          "attributes": {"id": "i-0ccc", "instance_type": "m5.large"}
+        }
+      ]
+    },
+    {
+      "mode": "data",
+      "type": "aws_instance",
+      "name": "existing",
+      "instances": [{"attributes": {"id": "i-0ddd"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "aws_s3_bucket",
+      "name": "logs",
+      "instances": [{"attributes": {"id": "app-logs"}}]
+    }
+  ]
+}`
+
+func TestParseStateFile(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "terraform.tfstate")
+	if err := os.WriteFile(statePath, []byte(sampleRawState), 0644); err != nil {
+		t.Fatalf("Failed to write state file: %v", err)
+	}
+
+	configs, err := ParseStateFile(statePath)
+	if err != nil {
+		t.Fatalf("ParseStateFile() error = %v", err)
+	}
+
+	if len(configs) != 3 {
+		t.Fatalf("Expected 3 instances, got %d: %v", len(configs), configs)
+	}
+
+	first := configs["aws_instance.web[0]"]
+	if first == nil {
+		t.Fatal("Expected aws_instance.web[0]")
+	}
+	if first.InstanceID != "i-0aaa" || first.InstanceType != "t3.micro" || first.ResourceName != "web" {
+		t.Errorf("Unexpected first instance: %+v", first)
+	}
+	if first.Monitoring == nil || !*first.Monitoring || first.EBSOptimized == nil || *first.EBSOptimized {
+		t.Errorf("Expected monitoring and ebs_optimized to be mapped, got %v and %v", first.Monitoring, first.EBSOptimized)
+	}
+	if len(first.SecurityGroups) != 2 || first.Tags["Name"] != "web-0" {
+		t.Errorf("Expected security groups and tags to be mapped, got %v and %v", first.SecurityGroups, first.Tags)
+	}
+	if first.RootBlockDevice == nil || first.RootBlockDevice.VolumeSize != 20 || first.RootBlockDevice.Encrypted == nil || !*first.RootBlockDevice.Encrypted {
+		t.Errorf("Unexpected root block device: %+v", first.RootBlockDevice)
+	}
+	if first.TerraformVersion != "1.5.7" {
+		t.Errorf("Expected terraform version 1.5.7, got %q", first.TerraformVersion)
+	}
+
+	second := configs["aws_instance.web[1]"]
+	if second == nil || second.InstanceID != "i-0bbb" || second.InstanceType != "t3.small" {
+		t.Errorf("Unexpected second instance: %+v", second)
+	}
+
+	if worker := configs[`module.workers.aws_instance.worker["blue"]`]; worker == nil || worker.InstanceID != "i-0ccc" {
+		t.Errorf("Expected for_each instance in module, got %v", configs)
+	}
+}
+
+func TestParseStateFile_ShowJSON(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(statePath, []byte(sampleIntegrationState), 0644); err != nil {
+		t.Fatalf("Failed to write state file: %v", err)
+	}
+
+	configs, err := ParseStateFile(statePath)
+	if err != nil {
+		t.Fatalf("ParseStateFile() error = %v", err)
+	}
+	web := configs["aws_instance.web"]
+	if web == nil || web.InstanceType != "t3.micro" || web.KeyName != "my-key" {
+		t.Errorf("Expected aws_instance.web from terraform show -json output, got %v", configs)
+	}
+}
+
+func TestParseStateFile_Errors(t *testing.T) {
+	if _, err := ParseStateFile(""); err == nil {
+		t.Error("Expected an error for an empty path")
+	}
+	if _, err := ParseStateFile(filepath.Join(t.TempDir(), "missing.tfstate")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+
+	statePath := filepath.Join(t.TempDir(), "terraform.tfstate")
+	if err := os.WriteFile(statePath, []byte("{not json"), 0644); err != nil {
+		t.Fatalf("Failed to write state file: %v", err)
+	}
+	if _, err := ParseStateFile(statePath); err == nil {
+		t.Error("Expected an error for invalid JSON")
+	}
+}